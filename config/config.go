@@ -3,9 +3,14 @@ package config
 import "github.com/jinzhu/configor"
 
 type Config struct {
-	AppConfig   AppConfig `env:"APPCONFIG"`
-	DBConfig    DBConfig
-	RedisConfig RedisConfig
+	AppConfig       AppConfig `env:"APPCONFIG"`
+	DBConfig        DBConfig
+	RedisConfig     RedisConfig
+	AuthConfig      AuthConfig
+	SearchConfig    SearchConfig
+	AiringConfig    AiringConfig
+	GraphQLConfig   GraphQLConfig
+	RateLimitConfig RateLimitConfig
 }
 
 type AppConfig struct {
@@ -13,6 +18,13 @@ type AppConfig struct {
 	Port    int    `env:"PORT" default:"3000"`
 	Version string `default:"x.x.x" env:"VERSION"`
 	Env     string `default:"development" env:"ENV"`
+	// Largest complexity and nesting depth a GraphQL operation may have
+	MaxQueryComplexity int `default:"20000" env:"GRAPHQL_MAX_COMPLEXITY"`
+	MaxQueryDepth      int `default:"10" env:"GRAPHQL_MAX_DEPTH"`
+	// On shutdown readiness fails for ShutdownDrainSeconds before the server stops
+	// accepting connections, then in-flight requests get ShutdownTimeoutSeconds to finish
+	ShutdownDrainSeconds   int `default:"5" env:"SHUTDOWN_DRAIN_SECONDS"`
+	ShutdownTimeoutSeconds int `default:"30" env:"SHUTDOWN_TIMEOUT_SECONDS"`
 }
 
 type DBConfig struct {
@@ -49,6 +61,57 @@ type RedisConfig struct {
 	EpisodeTTLMinutes   int `default:"15" env:"CACHE_EPISODE_TTL_MINUTES"`
 	SeasonTTLMinutes    int `default:"60" env:"CACHE_SEASON_TTL_MINUTES"`
 	LockTTLSeconds      int `default:"30" env:"CACHE_LOCK_TTL_SECONDS"`
+
+	// Search suggestions also follow index refreshes, so they are kept briefly
+	SearchSuggestionTTLMinutes int `default:"5" env:"CACHE_SEARCH_SUGGESTION_TTL_MINUTES"`
+
+	// Casts only change when anime are imported, so shared casts are kept for longer
+	SharedCastTTLMinutes int `default:"360" env:"CACHE_SHARED_CAST_TTL_MINUTES"`
+}
+
+type AuthConfig struct {
+	// Shared secret for HS256 tokens
+	JWTSecret string `default:"" env:"JWT_SECRET"`
+	// Path to a JWKS file with the public keys for RS256 tokens
+	JWKSFile string `default:"" env:"JWT_JWKS_FILE"`
+	// Expected iss and aud claims; skipped when empty
+	Issuer   string `default:"" env:"JWT_ISSUER"`
+	Audience string `default:"" env:"JWT_AUDIENCE"`
+}
+
+type SearchConfig struct {
+	// How often the title index picks up anime changed since the last load
+	RefreshIntervalSeconds int `default:"30" env:"SEARCH_REFRESH_INTERVAL_SECONDS"`
+}
+
+type AiringConfig struct {
+	// How often the scheduler looks for episodes that started airing; 0 disables it
+	PollIntervalSeconds int `default:"15" env:"AIRING_POLL_INTERVAL_SECONDS"`
+}
+
+type GraphQLConfig struct {
+	// How long a query registered through automatic persisted queries is kept
+	APQTTLMinutes int `default:"1440" env:"APQ_TTL_MINUTES"`
+	// Queries kept in memory for automatic persisted queries when Redis is disabled
+	APQCacheSize int `default:"1000" env:"APQ_CACHE_SIZE"`
+	// JSON manifest of pre-registered operations keyed by their sha256 hash
+	PersistedQueriesFile string `default:"" env:"PERSISTED_QUERIES_FILE"`
+	// Only accept operations from the manifest, by hash
+	PersistedQueriesOnly bool `default:"false" env:"PERSISTED_QUERIES_ONLY"`
+}
+
+type RateLimitConfig struct {
+	// Off by default: behind the gateway every anonymous client shares the gateway's
+	// address, so only enable it with API keys or TrustForwardedFor set
+	Enabled bool `default:"false" env:"RATE_LIMIT_ENABLED"`
+	// Requests a client may make a second on average, and in one burst
+	RequestsPerSecond float64 `default:"20" env:"RATE_LIMIT_REQUESTS_PER_SECOND"`
+	Burst             int     `default:"100" env:"RATE_LIMIT_BURST"`
+	// Comma separated API keys, sent in X-API-Key, that are limited per key; requests
+	// with any other key are limited by IP
+	APIKeys string `default:"" env:"RATE_LIMIT_API_KEYS"`
+	// Take the client IP from X-Forwarded-For; only set behind a proxy that overwrites it
+	TrustForwardedFor bool `default:"false" env:"RATE_LIMIT_TRUST_FORWARDED_FOR"`
 }
 
 func LoadConfigOrPanic() Config {
@@ -12,6 +12,8 @@ import (
 	"github.com/weeb-vip/anime-api/internal/db"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
 var (
@@ -76,3 +78,27 @@ func MigrateDown() error {
 
 	return m.Down()
 }
+
+// LatestMigrationVersion returns the version of the newest embedded migration, which
+// the database is at once MigrateUp has run
+func LatestMigrationVersion() (uint, error) {
+	files, err := migrations.ReadDir("migrations")
+	if err != nil {
+		return 0, err
+	}
+	var latest uint
+	for _, file := range files {
+		prefix, _, found := strings.Cut(file.Name(), "_")
+		if !found {
+			continue
+		}
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			continue
+		}
+		if uint(version) > latest {
+			latest = uint(version)
+		}
+	}
+	return latest, nil
+}
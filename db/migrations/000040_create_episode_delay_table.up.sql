@@ -0,0 +1,13 @@
+CREATE TABLE episode_delay (
+    id VARCHAR(36) PRIMARY KEY DEFAULT (UUID()),
+    anime_id VARCHAR(36) NOT NULL,
+    episode_number INT NOT NULL,
+    air_type ENUM('raw', 'sub', 'dub') NOT NULL DEFAULT 'raw',
+    original_air_datetime TIMESTAMP NULL,
+    rescheduled_air_datetime TIMESTAMP NULL,
+    reason VARCHAR(255) NULL,
+    break_weeks INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE INDEX idx_episode_delay_unique (anime_id, episode_number, air_type)
+);
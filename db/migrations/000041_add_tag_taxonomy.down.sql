@@ -0,0 +1,9 @@
+ALTER TABLE anime_tags
+    DROP COLUMN weight;
+
+ALTER TABLE tags
+    DROP INDEX idx_tags_parent_id,
+    DROP INDEX idx_tags_category,
+    DROP COLUMN is_spoiler,
+    DROP COLUMN parent_id,
+    DROP COLUMN category;
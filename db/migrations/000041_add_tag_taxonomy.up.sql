@@ -0,0 +1,39 @@
+-- Tag taxonomy: a category, an optional parent tag and a spoiler flag on tags,
+-- and how relevant each tag is to an anime
+ALTER TABLE tags
+    ADD COLUMN category   ENUM('genre', 'theme', 'demographic', 'setting') NULL AFTER name,
+    ADD COLUMN parent_id  BIGINT NULL AFTER category,
+    ADD COLUMN is_spoiler BOOLEAN NOT NULL DEFAULT FALSE AFTER parent_id,
+    ADD INDEX idx_tags_category (category),
+    ADD INDEX idx_tags_parent_id (parent_id);
+
+-- Weight is 1-100; tags migrated from genres apply to the whole anime
+ALTER TABLE anime_tags
+    ADD COLUMN weight TINYINT UNSIGNED NOT NULL DEFAULT 100 AFTER tag_id;
+
+-- Seed categories for the tags created from MyAnimeList genres, themes and demographics.
+-- Tags not listed stay uncategorised until an admin sets them.
+UPDATE tags SET category = 'genre'
+WHERE name IN ('Action', 'Adventure', 'Avant Garde', 'Award Winning', 'Boys Love', 'Comedy',
+               'Drama', 'Ecchi', 'Erotica', 'Fantasy', 'Girls Love', 'Gourmet', 'Hentai', 'Horror',
+               'Mystery', 'Romance', 'Sci-Fi', 'Slice of Life', 'Sports', 'Supernatural',
+               'Suspense', 'Thriller', 'Shounen Ai', 'Shoujo Ai', 'Yaoi', 'Yuri');
+
+UPDATE tags SET category = 'demographic'
+WHERE name IN ('Shounen', 'Shoujo', 'Seinen', 'Josei', 'Kids');
+
+UPDATE tags SET category = 'setting'
+WHERE name IN ('School', 'Space', 'Historical', 'Military', 'Workplace', 'Urban Fantasy');
+
+UPDATE tags SET category = 'theme'
+WHERE category IS NULL
+  AND name IN ('Adult Cast', 'Anthropomorphic', 'CGDCT', 'Cars', 'Childcare', 'Combat Sports',
+               'Crossdressing', 'Delinquents', 'Dementia', 'Demons', 'Detective', 'Educational',
+               'Game', 'Gag Humor', 'Gore', 'Harem', 'High Stakes Game', 'Idols (Female)',
+               'Idols (Male)', 'Isekai', 'Iyashikei', 'Love Polygon', 'Love Status Quo', 'Magic',
+               'Magical Sex Shift', 'Mahou Shoujo', 'Martial Arts', 'Mecha', 'Medical', 'Music',
+               'Mythology', 'Organized Crime', 'Otaku Culture', 'Parody', 'Performing Arts',
+               'Pets', 'Police', 'Psychological', 'Racing', 'Reincarnation', 'Reverse Harem',
+               'Romantic Subtext', 'Samurai', 'Showbiz', 'Strategy Game', 'Super Power',
+               'Survival', 'Team Sports', 'Time Travel', 'Vampire', 'Video Game', 'Villainess',
+               'Visual Arts');
@@ -0,0 +1,185 @@
+package graph
+
+import (
+	"github.com/weeb-vip/anime-api/graph/generated"
+	"github.com/weeb-vip/anime-api/graph/model"
+)
+
+// Complexity costs: a field costs 1 plus its selection, and list fields multiply
+// their selection by the items they are expected to return
+const (
+	// defaultListLimit is what list queries return when called without a limit
+	defaultListLimit = 10
+	// defaultConnectionSize is the page size of connections called without first
+	defaultConnectionSize = 20
+	// episodeListSize is weighted heavily: episodes are preloaded for every anime in
+	// a list, so selecting them multiplies the rows read by the anime returned
+	episodeListSize = 25
+	// characterListSize is the characters an anime is expected to list
+	characterListSize = 25
+	// staffRoleListSize is the characters a staff member is expected to have voiced
+	staffRoleListSize = 25
+	// tagListSize is the tags an anime is expected to have
+	tagListSize = 15
+	// nestedListSize is expected of the smaller per-anime lists such as seasons and fanart
+	nestedListSize = 5
+	// airTypeCount covers the raw, sub and dub air times of an episode
+	airTypeCount = 3
+	// scheduleDayEpisodes is the releases expected on one day of the weekly schedule
+	scheduleDayEpisodes = 20
+)
+
+func listCost(size int, childComplexity int) int {
+	return 1 + size*childComplexity
+}
+
+// limitCost costs a list query by its limit argument
+func limitCost(limit *int, childComplexity int) int {
+	size := defaultListLimit
+	if limit != nil && *limit > 0 {
+		size = *limit
+	}
+	return listCost(size, childComplexity)
+}
+
+// connectionCost costs a connection by its first argument
+func connectionCost(first *int, childComplexity int) int {
+	size := defaultConnectionSize
+	if first != nil && *first > 0 {
+		size = *first
+	}
+	return listCost(size, childComplexity)
+}
+
+// NewComplexityRoot returns the field costs used to reject operations over the
+// complexity limit. Fields not listed here cost 1 plus their selection.
+func NewComplexityRoot() generated.ComplexityRoot {
+	var root generated.ComplexityRoot
+
+	root.Query.AnimeBySeasonAndYear = func(childComplexity int, seasonName string, year int, limit *int) int {
+		return limitCost(limit, childComplexity)
+	}
+	root.Query.AnimeBySeasons = func(childComplexity int, season string, limit *int) int {
+		return limitCost(limit, childComplexity)
+	}
+	root.Query.CurrentlyAiring = func(childComplexity int, input *model.CurrentlyAiringInput, limit *int) int {
+		return limitCost(limit, childComplexity)
+	}
+	root.Query.MostPopularAnime = func(childComplexity int, limit *int) int {
+		return limitCost(limit, childComplexity)
+	}
+	root.Query.NewestAnime = func(childComplexity int, limit *int) int {
+		return limitCost(limit, childComplexity)
+	}
+	root.Query.TopRatedAnime = func(childComplexity int, limit *int) int {
+		return limitCost(limit, childComplexity)
+	}
+	root.Query.SearchAnime = func(childComplexity int, query string, limit *int) int {
+		return limitCost(limit, childComplexity)
+	}
+	root.Query.SearchSuggestions = func(childComplexity int, prefix string, limit *int) int {
+		return limitCost(limit, childComplexity)
+	}
+	root.Query.SearchStaff = func(childComplexity int, name string, limit *int) int {
+		return limitCost(limit, childComplexity)
+	}
+	root.Query.SharedCast = func(childComplexity int, animeID string, minShared *int, limit *int) int {
+		return limitCost(limit, childComplexity)
+	}
+	root.Query.Tags = func(childComplexity int, prefix *string, category *model.TagCategory, includeSpoilers *bool, limit *int) int {
+		return limitCost(limit, childComplexity)
+	}
+	root.Query.DbSearch = func(childComplexity int, searchQuery model.AnimeSearchInput) int {
+		return limitCost(&searchQuery.PerPage, childComplexity)
+	}
+	root.Query.Franchise = func(childComplexity int, animeID string, maxDepth *int) int {
+		return listCost(defaultListLimit, childComplexity)
+	}
+	root.Query.EpisodesByAnimeID = func(childComplexity int, animeID string) int {
+		return listCost(episodeListSize, childComplexity)
+	}
+	root.Query.CharactersAndStaffByAnimeID = func(childComplexity int, animeID string, languages []string, roles []string) int {
+		return listCost(characterListSize, childComplexity)
+	}
+	root.Query.CastComparison = func(childComplexity int, animeID string) int {
+		return listCost(characterListSize, childComplexity)
+	}
+
+	root.Query.AnimeBySeasonsConnection = func(childComplexity int, season string, first *int, after *string) int {
+		return connectionCost(first, childComplexity)
+	}
+	root.Query.CurrentlyAiringConnection = func(childComplexity int, input *model.CurrentlyAiringInput, first *int, after *string) int {
+		return connectionCost(first, childComplexity)
+	}
+	root.Query.DbSearchConnection = func(childComplexity int, filter model.AnimeSearchFilterInput, first *int, after *string) int {
+		return connectionCost(first, childComplexity)
+	}
+	root.Query.MostPopularAnimeConnection = func(childComplexity int, first *int, after *string) int {
+		return connectionCost(first, childComplexity)
+	}
+	root.Query.NewestAnimeConnection = func(childComplexity int, first *int, after *string) int {
+		return connectionCost(first, childComplexity)
+	}
+	root.Query.TopRatedAnimeConnection = func(childComplexity int, first *int, after *string) int {
+		return connectionCost(first, childComplexity)
+	}
+	root.Query.AnimeByTag = func(childComplexity int, tags []string, match *model.TagMatch, first *int, after *string) int {
+		return connectionCost(first, childComplexity)
+	}
+
+	root.Entity.FindManyAnimeByIDs = func(childComplexity int, reps []*model.AnimeByIDsInput) int {
+		return listCost(len(reps), childComplexity)
+	}
+
+	root.Anime.Episodes = func(childComplexity int) int {
+		return listCost(episodeListSize, childComplexity)
+	}
+	root.Anime.Seasons = func(childComplexity int) int {
+		return listCost(nestedListSize, childComplexity)
+	}
+	root.Anime.Relations = func(childComplexity int) int {
+		return listCost(nestedListSize, childComplexity)
+	}
+	root.Anime.Fanart = func(childComplexity int) int {
+		return listCost(nestedListSize, childComplexity)
+	}
+	root.Anime.StreamingPlatforms = func(childComplexity int) int {
+		return listCost(nestedListSize, childComplexity)
+	}
+	root.Episode.AirTimes = func(childComplexity int) int {
+		return listCost(airTypeCount, childComplexity)
+	}
+	root.EpisodeAirTime.Streams = func(childComplexity int) int {
+		return listCost(nestedListSize, childComplexity)
+	}
+	root.CharacterWithStaff.Staff = func(childComplexity int) int {
+		return listCost(nestedListSize, childComplexity)
+	}
+	root.AnimeCharacter.Staff = func(childComplexity int) int {
+		return listCost(nestedListSize, childComplexity)
+	}
+	root.AnimeStaff.Characters = func(childComplexity int) int {
+		return listCost(staffRoleListSize, childComplexity)
+	}
+	root.SharedCastResult.SharedStaff = func(childComplexity int) int {
+		return listCost(nestedListSize, childComplexity)
+	}
+	root.AnimeStaff.Roles = func(childComplexity int) int {
+		return listCost(staffRoleListSize, childComplexity)
+	}
+	root.Anime.TagDetails = func(childComplexity int, includeSpoilers *bool) int {
+		return listCost(tagListSize, childComplexity)
+	}
+	root.Tag.Children = func(childComplexity int) int {
+		return listCost(nestedListSize, childComplexity)
+	}
+
+	root.WeeklySchedule.Days = func(childComplexity int) int {
+		return listCost(7, childComplexity)
+	}
+	root.ScheduleDay.Episodes = func(childComplexity int) int {
+		return listCost(scheduleDayEpisodes, childComplexity)
+	}
+
+	return root
+}
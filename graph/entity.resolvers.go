@@ -6,20 +6,24 @@ package graph
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/weeb-vip/anime-api/graph/generated"
 	"github.com/weeb-vip/anime-api/graph/model"
+	"github.com/weeb-vip/anime-api/internal/resolvers"
 )
 
-// FindAnimeByID is the resolver for the findAnimeByID field.
-func (r *entityResolver) FindAnimeByID(ctx context.Context, id string) (*model.Anime, error) {
-	panic(fmt.Errorf("not implemented: FindAnimeByID - findAnimeByID"))
+// FindManyAnimeByIDs is the resolver for the findManyAnimeByIDs field.
+func (r *entityResolver) FindManyAnimeByIDs(ctx context.Context, reps []*model.AnimeByIDsInput) ([]*model.Anime, error) {
+	ids := make([]string, len(reps))
+	for i, rep := range reps {
+		ids[i] = rep.ID
+	}
+	return resolvers.AnimeEntitiesByIDs(ctx, r.AnimeService, ids)
 }
 
 // FindEpisodeByAnimeID is the resolver for the findEpisodeByAnimeID field.
 func (r *entityResolver) FindEpisodeByAnimeID(ctx context.Context, animeID *string) (*model.Episode, error) {
-	panic(fmt.Errorf("not implemented: FindEpisodeByAnimeID - findEpisodeByAnimeID"))
+	return resolvers.EpisodeEntityByAnimeID(ctx, r.AnimeEpisodeService, animeID)
 }
 
 // FindUserAnimeByAnimeID is the resolver for the findUserAnimeByAnimeID field.
@@ -10,6 +10,7 @@ import (
 	"sync"
 
 	"github.com/99designs/gqlgen/plugin/federation/fedruntime"
+	"github.com/weeb-vip/anime-api/graph/model"
 )
 
 var (
@@ -65,6 +66,8 @@ func (ec *executionContext) __resolve_entities(ctx context.Context, representati
 
 	isMulti := func(typeName string) bool {
 		switch typeName {
+		case "Anime":
+			return true
 		default:
 			return false
 		}
@@ -80,26 +83,6 @@ func (ec *executionContext) __resolve_entities(ctx context.Context, representati
 		}()
 
 		switch typeName {
-		case "Anime":
-			resolverName, err := entityResolverNameForAnime(ctx, rep)
-			if err != nil {
-				return fmt.Errorf(`finding resolver for Entity "Anime": %w`, err)
-			}
-			switch resolverName {
-
-			case "findAnimeByID":
-				id0, err := ec.unmarshalNID2string(ctx, rep["id"])
-				if err != nil {
-					return fmt.Errorf(`unmarshalling param 0 for findAnimeByID(): %w`, err)
-				}
-				entity, err := ec.resolvers.Entity().FindAnimeByID(ctx, id0)
-				if err != nil {
-					return fmt.Errorf(`resolving Entity "Anime": %w`, err)
-				}
-
-				list[idx[i]] = entity
-				return nil
-			}
 		case "Episode":
 			resolverName, err := entityResolverNameForEpisode(ctx, rep)
 			if err != nil {
@@ -156,6 +139,30 @@ func (ec *executionContext) __resolve_entities(ctx context.Context, representati
 
 		switch typeName {
 
+		case "Anime":
+			_reps := make([]*model.AnimeByIDsInput, len(reps))
+
+			for i, rep := range reps {
+				id0, err := ec.unmarshalNID2string(ctx, rep["id"])
+				if err != nil {
+					return errors.New(fmt.Sprintf("Field %s undefined in schema.", "id"))
+				}
+
+				_reps[i] = &model.AnimeByIDsInput{
+					ID: id0,
+				}
+			}
+
+			entities, err := ec.resolvers.Entity().FindManyAnimeByIDs(ctx, _reps)
+			if err != nil {
+				return err
+			}
+
+			for i, entity := range entities {
+				list[idx[i]] = entity
+			}
+			return nil
+
 		default:
 			return errors.New("unknown type: " + typeName)
 		}
@@ -221,7 +228,7 @@ func entityResolverNameForAnime(ctx context.Context, rep map[string]interface{})
 		if _, ok = m["id"]; !ok {
 			break
 		}
-		return "findAnimeByID", nil
+		return "findManyAnimeByIDs", nil
 	}
 	return "", fmt.Errorf("%w for Anime", ErrTypeNotFound)
 }
@@ -7,6 +7,8 @@ import (
 	"io"
 	"strconv"
 	"time"
+
+	"github.com/99designs/gqlgen/graphql"
 )
 
 // Anime Type
@@ -33,6 +35,8 @@ type Anime struct {
 	ImageURL *string `json:"imageUrl,omitempty"`
 	// Tags of the anime
 	Tags []string `json:"tags,omitempty"`
+	// Tags of the anime with their category and relevance, most relevant first; spoiler tags are left out unless includeSpoilers is set
+	TagDetails []*AnimeTag `json:"tagDetails"`
 	// Studios of the anime
 	Studios []string `json:"studios,omitempty"`
 	// Anime status (finished, airing, upcoming)
@@ -51,6 +55,10 @@ type Anime struct {
 	EndDate *time.Time `json:"endDate,omitempty"`
 	// Anime broadcast
 	Broadcast *string `json:"broadcast,omitempty"`
+	// The weekly slot of broadcast, or null when it names none or cannot be read
+	BroadcastInfo *BroadcastInfo `json:"broadcastInfo,omitempty"`
+	// Whether the broadcast is on a break: delayed indefinitely or for weeks
+	OnHiatus bool `json:"onHiatus"`
 	// Anime source (myanimelist, anime-planet, anidb, anilist, kitsu, anime_news_network)
 	Source *string `json:"source,omitempty"`
 	// Anime licensors
@@ -66,10 +74,12 @@ type Anime struct {
 	// Fanart / visuals gathered for this anime
 	Fanart []*Fanart `json:"fanart,omitempty"`
 	// Anime seasons
-	Seasons     []*AnimeSeason `json:"seasons,omitempty"`
-	CreatedAt   string         `json:"createdAt"`
-	UpdatedAt   string         `json:"updatedAt"`
-	NextEpisode *Episode       `json:"nextEpisode,omitempty"`
+	Seasons []*AnimeSeason `json:"seasons,omitempty"`
+	// Related anime such as sequels, prequels and side stories
+	Relations   []*AnimeRelation `json:"relations,omitempty"`
+	CreatedAt   string           `json:"createdAt"`
+	UpdatedAt   string           `json:"updatedAt"`
+	NextEpisode *Episode         `json:"nextEpisode,omitempty"`
 }
 
 func (Anime) IsEntity() {}
@@ -79,6 +89,10 @@ type AnimeAPI struct {
 	Version string `json:"version"`
 }
 
+type AnimeByIDsInput struct {
+	ID string `json:"ID"`
+}
+
 type AnimeCharacter struct {
 	// Unique identifier for the character
 	ID string `json:"id"`
@@ -116,6 +130,29 @@ type AnimeCharacter struct {
 	Staff []*AnimeStaff `json:"staff,omitempty"`
 }
 
+// A page of anime; first defaults to 20 and may be at most 100
+type AnimeConnection struct {
+	Edges    []*AnimeEdge `json:"edges"`
+	PageInfo *PageInfo    `json:"pageInfo"`
+	// Number of anime across all pages
+	TotalCount int `json:"totalCount"`
+}
+
+// An anime and its position in a connection
+type AnimeEdge struct {
+	// Opaque position of the anime, valid only for the query that returned it
+	Cursor string `json:"cursor"`
+	Node   *Anime `json:"node"`
+}
+
+// An anime related to another anime
+type AnimeRelation struct {
+	// How the related anime connects to the anime it is listed on
+	RelationType RelationType `json:"relationType"`
+	// The related anime
+	Anime *Anime `json:"anime"`
+}
+
 // Schedule metadata from AnimeSchedule.net
 type AnimeScheduleInfo struct {
 	// Japanese broadcast time
@@ -134,6 +171,29 @@ type AnimeScheduleInfo struct {
 	DubDelayedTimetable *string `json:"dubDelayedTimetable,omitempty"`
 }
 
+// Filters and sort of dbSearchConnection
+type AnimeSearchFilterInput struct {
+	// Search query
+	Query *string `json:"query,omitempty"`
+	// Sort by: titleEn, titleRomaji, rating, ranking, episodeCount, startDate, endDate, createdAt or updatedAt
+	SortBy *string `json:"sortBy,omitempty"`
+	// Sort direction: ASC (default) or DESC; anime without a value for sortBy come last either way
+	SortDirection *string `json:"sortDirection,omitempty"`
+	// Tags, anime must have all of them
+	Tags []string `json:"tags,omitempty"`
+	// Studios, anime must have at least one of them
+	Studios []string `json:"studios,omitempty"`
+	// Anime statuses, anime must have one of them
+	AnimeStatuses []string `json:"animeStatuses,omitempty"`
+}
+
+// An anime matching a title search
+type AnimeSearchHit struct {
+	Anime *Anime `json:"anime"`
+	// Relevance of the match; higher is better and an exact title match scores above 1
+	Score float64 `json:"score"`
+}
+
 type AnimeSearchInput struct {
 	// Search query
 	Query string `json:"query"`
@@ -141,15 +201,15 @@ type AnimeSearchInput struct {
 	Page int `json:"page"`
 	// Items per page
 	PerPage int `json:"perPage"`
-	// Sort by
+	// Sort by: titleEn, titleRomaji, rating, ranking, episodeCount, startDate, endDate, createdAt or updatedAt
 	SortBy *string `json:"sortBy,omitempty"`
-	// Sort direction
+	// Sort direction: ASC (default) or DESC
 	SortDirection *string `json:"sortDirection,omitempty"`
-	// Tags
+	// Tags, anime must have all of them
 	Tags []string `json:"tags,omitempty"`
-	// Studios
+	// Studios, anime must have at least one of them
 	Studios []string `json:"studios,omitempty"`
-	// Anime statuses
+	// Anime statuses, anime must have one of them
 	AnimeStatuses []string `json:"animeStatuses,omitempty"`
 }
 
@@ -197,6 +257,18 @@ type AnimeStaff struct {
 	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
 	// the characters associated with the staff member
 	Characters []*AnimeCharacter `json:"characters,omitempty"`
+	// The staff member's roles, most recently started anime first
+	Roles []*StaffRole `json:"roles"`
+}
+
+// A tag of an anime
+type AnimeTag struct {
+	// ID of the tag
+	TagID string `json:"tagId"`
+	// The tag
+	Tag *Tag `json:"tag"`
+	// How relevant the tag is to the anime, from 1 to 100
+	Weight int `json:"weight"`
 }
 
 type APIInfo struct {
@@ -206,6 +278,28 @@ type APIInfo struct {
 	Name string `json:"name"`
 }
 
+// A weekly broadcast slot
+type BroadcastInfo struct {
+	// Day of the week episodes air, such as Saturday, or null when only a time is given
+	Weekday *string `json:"weekday,omitempty"`
+	// Time of day episodes air in timezone, as HH:MM; late-night slots such as Fridays at 25:30 are given as Saturday 01:30
+	LocalTime string `json:"localTime"`
+	// IANA timezone of localTime, such as Asia/Tokyo, or a fixed offset such as UTC+9 when broadcast gives one
+	Timezone string `json:"timezone"`
+}
+
+// A character and their voice actors grouped by language
+type CastComparison struct {
+	// The character details
+	Character *AnimeCharacter `json:"character"`
+	// Japanese voice actors
+	Japanese []*AnimeStaff `json:"japanese"`
+	// English voice actors
+	English []*AnimeStaff `json:"english"`
+	// Voice actors in any other language, or with no language recorded
+	Other []*AnimeStaff `json:"other"`
+}
+
 type CharacterWithStaff struct {
 	// The character details
 	Character *AnimeCharacter `json:"character"`
@@ -213,6 +307,54 @@ type CharacterWithStaff struct {
 	Staff []*AnimeStaff `json:"staff,omitempty"`
 }
 
+// Fields of a new anime; at least one of titleEn, titleRomaji or titleJp is required
+type CreateAnimeInput struct {
+	// AniDB ID of the anime
+	Anidbid *string `json:"anidbid,omitempty"`
+	// TheTVDB ID of the anime
+	Thetvdbid *string `json:"thetvdbid,omitempty"`
+	// MAL ID of the anime
+	MalID *int `json:"malId,omitempty"`
+	// English title of the anime
+	TitleEn *string `json:"titleEn,omitempty"`
+	// Japanese title of the anime
+	TitleJp *string `json:"titleJp,omitempty"`
+	// Romaji title of the anime
+	TitleRomaji *string `json:"titleRomaji,omitempty"`
+	// Kanji title of the anime
+	TitleKanji *string `json:"titleKanji,omitempty"`
+	// Synonyms of the anime
+	TitleSynonyms []string `json:"titleSynonyms,omitempty"`
+	// Description of the anime
+	Description *string `json:"description,omitempty"`
+	// Image URL of the anime
+	ImageURL *string `json:"imageUrl,omitempty"`
+	// Studios of the anime
+	Studios []string `json:"studios,omitempty"`
+	// Licensors of the anime
+	Licensors []string `json:"licensors,omitempty"`
+	// Anime status (finished, airing, upcoming)
+	AnimeStatus *string `json:"animeStatus,omitempty"`
+	// Anime episode count
+	EpisodeCount *int `json:"episodeCount,omitempty"`
+	// Anime episode duration
+	Duration *string `json:"duration,omitempty"`
+	// Anime rating from 0 to 10
+	Rating *float64 `json:"rating,omitempty"`
+	// Anime rank
+	Ranking *int `json:"ranking,omitempty"`
+	// Anime first air date
+	StartDate *time.Time `json:"startDate,omitempty"`
+	// Anime last air date
+	EndDate *time.Time `json:"endDate,omitempty"`
+	// Anime broadcast (e.g. Wednesdays at 01:29 (JST))
+	Broadcast *string `json:"broadcast,omitempty"`
+	// Anime source
+	Source *string `json:"source,omitempty"`
+	// Seasons the anime airs in (e.g. SPRING_2024)
+	Seasons []string `json:"seasons,omitempty"`
+}
+
 type CurrentlyAiringInput struct {
 	// start date
 	StartDate time.Time `json:"startDate"`
@@ -240,9 +382,11 @@ type Episode struct {
 	// Calculated air time with timezone conversion
 	AirTime *time.Time `json:"airTime,omitempty"`
 	// Precise air times by type (raw/sub/dub) with per-type streaming platforms
-	AirTimes  []*EpisodeAirTime `json:"airTimes,omitempty"`
-	CreatedAt string            `json:"createdAt"`
-	UpdatedAt string            `json:"updatedAt"`
+	AirTimes []*EpisodeAirTime `json:"airTimes,omitempty"`
+	// Delay of the Japanese broadcast, or null when the episode airs as scheduled
+	Delay     *EpisodeDelay `json:"delay,omitempty"`
+	CreatedAt string        `json:"createdAt"`
+	UpdatedAt string        `json:"updatedAt"`
 }
 
 func (Episode) IsEntity() {}
@@ -257,12 +401,120 @@ type EpisodeAirTime struct {
 	Streams []*StreamingPlatform `json:"streams,omitempty"`
 }
 
+// An episode release that just happened
+type EpisodeAiringEvent struct {
+	AnimeID       string    `json:"animeId"`
+	EpisodeNumber int       `json:"episodeNumber"`
+	AirType       AirType   `json:"airType"`
+	AirTime       time.Time `json:"airTime"`
+}
+
+// A postponed episode. Without a rescheduled air time the episode is delayed indefinitely.
+type EpisodeDelay struct {
+	// Air type (raw, sub, dub) the delay applies to
+	AirType AirType `json:"airType"`
+	// When the episode was due to air
+	OriginalAirTime *time.Time `json:"originalAirTime,omitempty"`
+	// When the episode airs instead, or null when not yet known
+	RescheduledAirTime *time.Time `json:"rescheduledAirTime,omitempty"`
+	// Why the episode was postponed
+	Reason *string `json:"reason,omitempty"`
+	// Weeks the series breaks for, 0 for a one-off delay
+	BreakWeeks int `json:"breakWeeks"`
+}
+
+type EpisodeInput struct {
+	// ID of an existing episode of the anime to update
+	ID *string `json:"id,omitempty"`
+	// Episode number
+	EpisodeNumber int `json:"episodeNumber"`
+	// Episode title
+	TitleEn *string `json:"titleEn,omitempty"`
+	// Episode title
+	TitleJp *string `json:"titleJp,omitempty"`
+	// Episode synopsis
+	Synopsis *string `json:"synopsis,omitempty"`
+	// Episode air date
+	AirDate *time.Time `json:"airDate,omitempty"`
+}
+
 type Fanart struct {
 	ID        string  `json:"id"`
 	ImageURL  string  `json:"imageUrl"`
 	SourceURL *string `json:"sourceUrl,omitempty"`
 }
 
+// Position of a connection page
+type PageInfo struct {
+	// Whether more anime follow endCursor
+	HasNextPage bool `json:"hasNextPage"`
+	// Whether anime precede startCursor
+	HasPreviousPage bool `json:"hasPreviousPage"`
+	// Cursor of the first edge of the page
+	StartCursor *string `json:"startCursor,omitempty"`
+	// Cursor of the last edge of the page; pass it as after to get the next page
+	EndCursor *string `json:"endCursor,omitempty"`
+}
+
+// A change to the episode schedule of an anime
+type ScheduleChangedEvent struct {
+	AnimeID   string    `json:"animeId"`
+	ChangedAt time.Time `json:"changedAt"`
+}
+
+type ScheduleDay struct {
+	// Date of the day in the schedule's timezone
+	Date string `json:"date"`
+	// Day of the week, such as Monday
+	Weekday string `json:"weekday"`
+	// Releases of the day ordered by air time
+	Episodes []*ScheduledEpisode `json:"episodes"`
+}
+
+// One raw, sub or dub release of an episode
+type ScheduledEpisode struct {
+	Anime *Anime `json:"anime"`
+	// The episode, when it is listed for the anime
+	Episode       *Episode  `json:"episode,omitempty"`
+	EpisodeNumber int       `json:"episodeNumber"`
+	AirType       AirType   `json:"airType"`
+	AirTime       time.Time `json:"airTime"`
+	// Air time on the clock of the schedule's timezone, as HH:MM
+	LocalTime string `json:"localTime"`
+}
+
+// A lightweight search box suggestion
+type SearchSuggestion struct {
+	ID string `json:"id"`
+	// The title or synonym that matched the prefix
+	Title    string  `json:"title"`
+	ImageURL *string `json:"imageUrl,omitempty"`
+	// Year the anime started airing
+	Year *int `json:"year,omitempty"`
+}
+
+// An anime sharing voice actors with another anime
+type SharedCastResult struct {
+	// ID of the anime sharing the cast
+	AnimeID string `json:"animeId"`
+	// The anime sharing the cast
+	Anime *Anime `json:"anime,omitempty"`
+	// Number of staff members in both casts
+	SharedCount int `json:"sharedCount"`
+	// The staff members in both casts
+	SharedStaff []*AnimeStaff `json:"sharedStaff"`
+}
+
+// A character a staff member voiced, and the anime they appear in
+type StaffRole struct {
+	// The anime the character appears in
+	Anime *Anime `json:"anime,omitempty"`
+	// The character voiced
+	Character *AnimeCharacter `json:"character"`
+	// The language the character was voiced in
+	Language *string `json:"language,omitempty"`
+}
+
 // Streaming platform where an anime is available
 type StreamingPlatform struct {
 	// Platform identifier (e.g., crunchyroll, netflix)
@@ -273,6 +525,84 @@ type StreamingPlatform struct {
 	URL string `json:"url"`
 }
 
+// A tag anime can be browsed by
+type Tag struct {
+	// ID of the tag
+	ID string `json:"id"`
+	// Name of the tag
+	Name string `json:"name"`
+	// Number of anime tagged with it
+	AnimeCount int `json:"animeCount"`
+	// Category of the tag, or null when it has not been categorised
+	Category *TagCategory `json:"category,omitempty"`
+	// Whether the tag gives away a plot point; clients should hide it by default
+	IsSpoiler bool `json:"isSpoiler"`
+	// ID of the broader tag this one belongs under
+	ParentID *string `json:"parentId,omitempty"`
+	// The broader tag this one belongs under
+	Parent *Tag `json:"parent,omitempty"`
+	// Narrower tags under this one, by name
+	Children []*Tag `json:"children"`
+}
+
+// Fields to change on an anime; omitted fields are left as they are
+type UpdateAnimeInput struct {
+	// AniDB ID of the anime
+	Anidbid graphql.Omittable[*string] `json:"anidbid,omitempty"`
+	// TheTVDB ID of the anime
+	Thetvdbid graphql.Omittable[*string] `json:"thetvdbid,omitempty"`
+	// MAL ID of the anime
+	MalID graphql.Omittable[*int] `json:"malId,omitempty"`
+	// English title of the anime
+	TitleEn graphql.Omittable[*string] `json:"titleEn,omitempty"`
+	// Japanese title of the anime
+	TitleJp graphql.Omittable[*string] `json:"titleJp,omitempty"`
+	// Romaji title of the anime
+	TitleRomaji graphql.Omittable[*string] `json:"titleRomaji,omitempty"`
+	// Kanji title of the anime
+	TitleKanji graphql.Omittable[*string] `json:"titleKanji,omitempty"`
+	// Synonyms of the anime
+	TitleSynonyms graphql.Omittable[[]string] `json:"titleSynonyms,omitempty"`
+	// Description of the anime
+	Description graphql.Omittable[*string] `json:"description,omitempty"`
+	// Image URL of the anime
+	ImageURL graphql.Omittable[*string] `json:"imageUrl,omitempty"`
+	// Studios of the anime
+	Studios graphql.Omittable[[]string] `json:"studios,omitempty"`
+	// Licensors of the anime
+	Licensors graphql.Omittable[[]string] `json:"licensors,omitempty"`
+	// Anime status (finished, airing, upcoming)
+	AnimeStatus graphql.Omittable[*string] `json:"animeStatus,omitempty"`
+	// Anime episode count
+	EpisodeCount graphql.Omittable[*int] `json:"episodeCount,omitempty"`
+	// Anime episode duration
+	Duration graphql.Omittable[*string] `json:"duration,omitempty"`
+	// Anime rating from 0 to 10
+	Rating graphql.Omittable[*float64] `json:"rating,omitempty"`
+	// Anime rank
+	Ranking graphql.Omittable[*int] `json:"ranking,omitempty"`
+	// Anime first air date
+	StartDate graphql.Omittable[*time.Time] `json:"startDate,omitempty"`
+	// Anime last air date
+	EndDate graphql.Omittable[*time.Time] `json:"endDate,omitempty"`
+	// Anime broadcast (e.g. Wednesdays at 01:29 (JST))
+	Broadcast graphql.Omittable[*string] `json:"broadcast,omitempty"`
+	// Anime source
+	Source graphql.Omittable[*string] `json:"source,omitempty"`
+	// Seasons the anime airs in (e.g. SPRING_2024)
+	Seasons graphql.Omittable[[]string] `json:"seasons,omitempty"`
+}
+
+// Taxonomy of a tag to change; omitted fields are left as they are
+type UpdateTagInput struct {
+	// Category of the tag; null clears it
+	Category graphql.Omittable[*TagCategory] `json:"category,omitempty"`
+	// Name of the broader tag to put it under; null makes it a top-level tag
+	Parent graphql.Omittable[*string] `json:"parent,omitempty"`
+	// Whether the tag gives away a plot point
+	IsSpoiler *bool `json:"isSpoiler,omitempty"`
+}
+
 type UserAnime struct {
 	AnimeID string `json:"animeID"`
 	Anime   *Anime `json:"anime,omitempty"`
@@ -280,6 +610,14 @@ type UserAnime struct {
 
 func (UserAnime) IsEntity() {}
 
+// The episodes airing in one week, by day in the viewer's timezone
+type WeeklySchedule struct {
+	WeekStart string `json:"weekStart"`
+	Timezone  string `json:"timezone"`
+	// Seven days starting at weekStart
+	Days []*ScheduleDay `json:"days"`
+}
+
 // Air type for schedule times (raw Japanese broadcast, subtitled, dubbed)
 type AirType string
 
@@ -368,3 +706,153 @@ func (e *AnimeSeasonStatus) UnmarshalGQL(v interface{}) error {
 func (e AnimeSeasonStatus) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
+
+// How a related anime connects to the anime it is listed on
+type RelationType string
+
+const (
+	RelationTypeSequel             RelationType = "SEQUEL"
+	RelationTypePrequel            RelationType = "PREQUEL"
+	RelationTypeSideStory          RelationType = "SIDE_STORY"
+	RelationTypeParentStory        RelationType = "PARENT_STORY"
+	RelationTypeAlternativeSetting RelationType = "ALTERNATIVE_SETTING"
+	RelationTypeAlternativeVersion RelationType = "ALTERNATIVE_VERSION"
+	RelationTypeSpinOff            RelationType = "SPIN_OFF"
+	RelationTypeSummary            RelationType = "SUMMARY"
+	RelationTypeFullStory          RelationType = "FULL_STORY"
+	RelationTypeCharacter          RelationType = "CHARACTER"
+	RelationTypeOther              RelationType = "OTHER"
+)
+
+var AllRelationType = []RelationType{
+	RelationTypeSequel,
+	RelationTypePrequel,
+	RelationTypeSideStory,
+	RelationTypeParentStory,
+	RelationTypeAlternativeSetting,
+	RelationTypeAlternativeVersion,
+	RelationTypeSpinOff,
+	RelationTypeSummary,
+	RelationTypeFullStory,
+	RelationTypeCharacter,
+	RelationTypeOther,
+}
+
+func (e RelationType) IsValid() bool {
+	switch e {
+	case RelationTypeSequel, RelationTypePrequel, RelationTypeSideStory, RelationTypeParentStory, RelationTypeAlternativeSetting, RelationTypeAlternativeVersion, RelationTypeSpinOff, RelationTypeSummary, RelationTypeFullStory, RelationTypeCharacter, RelationTypeOther:
+		return true
+	}
+	return false
+}
+
+func (e RelationType) String() string {
+	return string(e)
+}
+
+func (e *RelationType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = RelationType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid RelationType", str)
+	}
+	return nil
+}
+
+func (e RelationType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// Kind of a tag, for grouping the tags of an anime
+type TagCategory string
+
+const (
+	TagCategoryGenre       TagCategory = "GENRE"
+	TagCategoryTheme       TagCategory = "THEME"
+	TagCategoryDemographic TagCategory = "DEMOGRAPHIC"
+	TagCategorySetting     TagCategory = "SETTING"
+)
+
+var AllTagCategory = []TagCategory{
+	TagCategoryGenre,
+	TagCategoryTheme,
+	TagCategoryDemographic,
+	TagCategorySetting,
+}
+
+func (e TagCategory) IsValid() bool {
+	switch e {
+	case TagCategoryGenre, TagCategoryTheme, TagCategoryDemographic, TagCategorySetting:
+		return true
+	}
+	return false
+}
+
+func (e TagCategory) String() string {
+	return string(e)
+}
+
+func (e *TagCategory) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TagCategory(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TagCategory", str)
+	}
+	return nil
+}
+
+func (e TagCategory) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// How animeByTag matches anime against several tags
+type TagMatch string
+
+const (
+	// Anime must have every tag
+	TagMatchAll TagMatch = "ALL"
+	// Anime must have at least one of the tags
+	TagMatchAny TagMatch = "ANY"
+)
+
+var AllTagMatch = []TagMatch{
+	TagMatchAll,
+	TagMatchAny,
+}
+
+func (e TagMatch) IsValid() bool {
+	switch e {
+	case TagMatchAll, TagMatchAny:
+		return true
+	}
+	return false
+}
+
+func (e TagMatch) String() string {
+	return string(e)
+}
+
+func (e *TagMatch) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TagMatch(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TagMatch", str)
+	}
+	return nil
+}
+
+func (e TagMatch) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
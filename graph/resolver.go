@@ -11,10 +11,15 @@ import (
 	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_streaming_platform"
 	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_tag"
 	"github.com/weeb-vip/anime-api/internal/db/repositories/episode_air_time"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/episode_delay"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/tag"
+	"github.com/weeb-vip/anime-api/internal/services/airing"
 	"github.com/weeb-vip/anime-api/internal/services/anime"
 	"github.com/weeb-vip/anime-api/internal/services/anime_character"
 	anime_character_staff_link2 "github.com/weeb-vip/anime-api/internal/services/anime_character_staff_link"
+	"github.com/weeb-vip/anime-api/internal/services/anime_relation"
 	"github.com/weeb-vip/anime-api/internal/services/anime_season"
+	"github.com/weeb-vip/anime-api/internal/services/anime_staff"
 	"github.com/weeb-vip/anime-api/internal/services/episodes"
 )
 
@@ -28,6 +33,8 @@ type CacheServiceInterface interface {
 	SetJSON(ctx context.Context, key string, value interface{}, ttl time.Duration) error
 	GetKeyBuilder() *cache.CacheKeyBuilder
 	GetCurrentlyAiringTTL() time.Duration
+	GetSearchSuggestionTTL() time.Duration
+	GetSharedCastTTL() time.Duration
 }
 
 type Resolver struct {
@@ -36,12 +43,17 @@ type Resolver struct {
 	AnimeEpisodeService                episodes.AnimeEpisodeServiceImpl
 	AnimeCharacterService              anime_character.AnimeCharacterServiceImpl
 	AnimeCharacterWithStaffLinkService anime_character_staff_link2.AnimeCharacterStaffLinkImpl
+	AnimeStaffService                  anime_staff.AnimeStaffServiceImpl
 	AnimeSeasonService                 anime_season.AnimeSeasonServiceImpl
+	AnimeRelationService               anime_relation.AnimeRelationServiceImpl
 	AnimeTagRepository                 anime_tag.AnimeTagRepositoryImpl
+	TagRepository                      tag.TagRepositoryImpl
 	AnimeScheduleRepository            anime_schedule.AnimeScheduleRepositoryImpl
 	AnimeStreamingPlatformRepository   anime_streaming_platform.AnimeStreamingPlatformRepositoryImpl
 	AnimeFanartRepository              anime_fanart.AnimeFanartRepositoryImpl
 	EpisodeAirTimeRepository           episode_air_time.EpisodeAirTimeRepositoryImpl
+	EpisodeDelayRepository             episode_delay.EpisodeDelayRepositoryImpl
 	CacheService                       CacheServiceInterface
+	AiringEvents                       *airing.Events
 	Context                            context.Context
 }
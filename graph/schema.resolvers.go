@@ -6,16 +6,65 @@ package graph
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/weeb-vip/anime-api/graph/generated"
 	"github.com/weeb-vip/anime-api/graph/model"
 	"github.com/weeb-vip/anime-api/internal/resolvers"
 )
 
+// CreateAnime is the resolver for the createAnime field.
+func (r *mutationResolver) CreateAnime(ctx context.Context, input model.CreateAnimeInput) (*model.Anime, error) {
+	return resolvers.CreateAnime(ctx, r.AnimeService, input)
+}
+
+// UpdateAnime is the resolver for the updateAnime field.
+func (r *mutationResolver) UpdateAnime(ctx context.Context, id string, input model.UpdateAnimeInput) (*model.Anime, error) {
+	return resolvers.UpdateAnime(ctx, r.AnimeService, id, input)
+}
+
+// DeleteAnime is the resolver for the deleteAnime field.
+func (r *mutationResolver) DeleteAnime(ctx context.Context, id string) (bool, error) {
+	return resolvers.DeleteAnime(ctx, r.AnimeService, id)
+}
+
+// UpsertEpisodes is the resolver for the upsertEpisodes field.
+func (r *mutationResolver) UpsertEpisodes(ctx context.Context, animeID string, episodes []*model.EpisodeInput) ([]*model.Episode, error) {
+	return resolvers.UpsertEpisodes(ctx, r.AnimeService, r.AnimeEpisodeService, animeID, episodes)
+}
+
+// DeleteEpisode is the resolver for the deleteEpisode field.
+func (r *mutationResolver) DeleteEpisode(ctx context.Context, id string) (bool, error) {
+	return resolvers.DeleteEpisode(ctx, r.AnimeEpisodeService, id)
+}
+
+// SetAnimeTags is the resolver for the setAnimeTags field.
+func (r *mutationResolver) SetAnimeTags(ctx context.Context, animeID string, tags []string) (*model.Anime, error) {
+	return resolvers.SetAnimeTags(ctx, r.AnimeService, r.TagRepository, r.AnimeTagRepository, animeID, tags)
+}
+
+// RenameTag is the resolver for the renameTag field.
+func (r *mutationResolver) RenameTag(ctx context.Context, from string, to string) (*model.Tag, error) {
+	return resolvers.RenameTag(ctx, r.TagRepository, from, to)
+}
+
+// MergeTags is the resolver for the mergeTags field.
+func (r *mutationResolver) MergeTags(ctx context.Context, sources []string, into string) (*model.Tag, error) {
+	return resolvers.MergeTags(ctx, r.TagRepository, sources, into)
+}
+
+// UpdateTag is the resolver for the updateTag field.
+func (r *mutationResolver) UpdateTag(ctx context.Context, name string, input model.UpdateTagInput) (*model.Tag, error) {
+	return resolvers.UpdateTag(ctx, r.TagRepository, name, input)
+}
+
+// SetAnimeTagWeight is the resolver for the setAnimeTagWeight field.
+func (r *mutationResolver) SetAnimeTagWeight(ctx context.Context, animeID string, tag string, weight int) (*model.AnimeTag, error) {
+	return resolvers.SetAnimeTagWeight(ctx, r.TagRepository, r.AnimeTagRepository, animeID, tag, weight)
+}
+
 // DbSearch is the resolver for the dbSearch field.
 func (r *queryResolver) DbSearch(ctx context.Context, searchQuery model.AnimeSearchInput) ([]*model.Anime, error) {
-	return resolvers.DBSearchAnime(ctx, r.AnimeService, searchQuery.Query, searchQuery.Page, searchQuery.PerPage)
+	return resolvers.DBSearchAnime(ctx, r.AnimeService, searchQuery)
 }
 
 // APIInfo is the resolver for the apiInfo field.
@@ -45,7 +94,7 @@ func (r *queryResolver) MostPopularAnime(ctx context.Context, limit *int) ([]*mo
 
 // Episode is the resolver for the episode field.
 func (r *queryResolver) Episode(ctx context.Context, id string) (*model.Episode, error) {
-	panic(fmt.Errorf("not implemented: Episode - episode"))
+	return resolvers.EpisodeByID(ctx, r.AnimeEpisodeService, r.AnimeService, id)
 }
 
 // EpisodesByAnimeID is the resolver for the episodesByAnimeId field.
@@ -55,7 +104,7 @@ func (r *queryResolver) EpisodesByAnimeID(ctx context.Context, animeID string) (
 
 // CurrentlyAiring is the resolver for the currentlyAiring field.
 func (r *queryResolver) CurrentlyAiring(ctx context.Context, input *model.CurrentlyAiringInput, limit *int) ([]*model.Anime, error) {
-	return resolvers.CurrentlyAiring(ctx, r.AnimeService, input, limit, r.CacheService)
+	return resolvers.CurrentlyAiring(ctx, r.AnimeService, r.EpisodeDelayRepository, input, limit, r.CacheService)
 }
 
 // AnimeBySeasons is the resolver for the animeBySeasons field.
@@ -68,12 +117,115 @@ func (r *queryResolver) AnimeBySeasonAndYear(ctx context.Context, seasonName str
 	return resolvers.AnimeBySeasonAndYear(ctx, r.AnimeSeasonService, r.AnimeService, seasonName, year, limit)
 }
 
+// Franchise is the resolver for the franchise field.
+func (r *queryResolver) Franchise(ctx context.Context, animeID string, maxDepth *int) ([]*model.Anime, error) {
+	return resolvers.Franchise(ctx, r.AnimeRelationService, r.AnimeService, animeID, maxDepth)
+}
+
 // CharactersAndStaffByAnimeID is the resolver for the charactersAndStaffByAnimeId field.
-func (r *queryResolver) CharactersAndStaffByAnimeID(ctx context.Context, animeID string) ([]*model.CharacterWithStaff, error) {
-	return resolvers.CharactersAndStaffByAnimeID(ctx, r.AnimeCharacterWithStaffLinkService, animeID)
+func (r *queryResolver) CharactersAndStaffByAnimeID(ctx context.Context, animeID string, languages []string, roles []string) ([]*model.CharacterWithStaff, error) {
+	return resolvers.CharactersAndStaffByAnimeID(ctx, r.AnimeCharacterWithStaffLinkService, animeID, languages, roles)
+}
+
+// CastComparison is the resolver for the castComparison field.
+func (r *queryResolver) CastComparison(ctx context.Context, animeID string) ([]*model.CastComparison, error) {
+	return resolvers.CastComparison(ctx, r.AnimeCharacterWithStaffLinkService, animeID)
+}
+
+// SharedCast is the resolver for the sharedCast field.
+func (r *queryResolver) SharedCast(ctx context.Context, animeID string, minShared *int, limit *int) ([]*model.SharedCastResult, error) {
+	return resolvers.SharedCast(ctx, r.AnimeCharacterWithStaffLinkService, r.CacheService, animeID, minShared, limit)
+}
+
+// Staff is the resolver for the staff field.
+func (r *queryResolver) Staff(ctx context.Context, id string) (*model.AnimeStaff, error) {
+	return resolvers.StaffByID(ctx, r.AnimeStaffService, id)
+}
+
+// Character is the resolver for the character field.
+func (r *queryResolver) Character(ctx context.Context, id string) (*model.AnimeCharacter, error) {
+	return resolvers.CharacterByID(ctx, r.AnimeCharacterService, id)
 }
 
+// SearchStaff is the resolver for the searchStaff field.
+func (r *queryResolver) SearchStaff(ctx context.Context, name string, limit *int) ([]*model.AnimeStaff, error) {
+	return resolvers.SearchStaff(ctx, r.AnimeStaffService, name, limit)
+}
+
+// Tags is the resolver for the tags field.
+func (r *queryResolver) Tags(ctx context.Context, prefix *string, category *model.TagCategory, includeSpoilers *bool, limit *int) ([]*model.Tag, error) {
+	return resolvers.Tags(ctx, r.TagRepository, prefix, category, includeSpoilers, limit)
+}
+
+// AnimeByTag is the resolver for the animeByTag field.
+func (r *queryResolver) AnimeByTag(ctx context.Context, tags []string, match *model.TagMatch, first *int, after *string) (*model.AnimeConnection, error) {
+	return resolvers.AnimeByTag(ctx, r.AnimeService, tags, match, first, after)
+}
+
+// NewestAnimeConnection is the resolver for the newestAnimeConnection field.
+func (r *queryResolver) NewestAnimeConnection(ctx context.Context, first *int, after *string) (*model.AnimeConnection, error) {
+	return resolvers.NewestAnimeConnection(ctx, r.AnimeService, first, after)
+}
+
+// TopRatedAnimeConnection is the resolver for the topRatedAnimeConnection field.
+func (r *queryResolver) TopRatedAnimeConnection(ctx context.Context, first *int, after *string) (*model.AnimeConnection, error) {
+	return resolvers.TopRatedAnimeConnection(ctx, r.AnimeService, first, after)
+}
+
+// MostPopularAnimeConnection is the resolver for the mostPopularAnimeConnection field.
+func (r *queryResolver) MostPopularAnimeConnection(ctx context.Context, first *int, after *string) (*model.AnimeConnection, error) {
+	return resolvers.MostPopularAnimeConnection(ctx, r.AnimeService, first, after)
+}
+
+// CurrentlyAiringConnection is the resolver for the currentlyAiringConnection field.
+func (r *queryResolver) CurrentlyAiringConnection(ctx context.Context, input *model.CurrentlyAiringInput, first *int, after *string) (*model.AnimeConnection, error) {
+	return resolvers.CurrentlyAiringConnection(ctx, r.AnimeService, r.EpisodeDelayRepository, input, first, after, r.CacheService)
+}
+
+// AnimeBySeasonsConnection is the resolver for the animeBySeasonsConnection field.
+func (r *queryResolver) AnimeBySeasonsConnection(ctx context.Context, season string, first *int, after *string) (*model.AnimeConnection, error) {
+	return resolvers.AnimeBySeasonsConnection(ctx, r.AnimeService, season, first, after)
+}
+
+// DbSearchConnection is the resolver for the dbSearchConnection field.
+func (r *queryResolver) DbSearchConnection(ctx context.Context, filter model.AnimeSearchFilterInput, first *int, after *string) (*model.AnimeConnection, error) {
+	return resolvers.DBSearchConnection(ctx, r.AnimeService, filter, first, after)
+}
+
+// SearchAnime is the resolver for the searchAnime field.
+func (r *queryResolver) SearchAnime(ctx context.Context, query string, limit *int) ([]*model.AnimeSearchHit, error) {
+	return resolvers.SearchAnime(ctx, r.AnimeService, query, limit)
+}
+
+// SearchSuggestions is the resolver for the searchSuggestions field.
+func (r *queryResolver) SearchSuggestions(ctx context.Context, prefix string, limit *int) ([]*model.SearchSuggestion, error) {
+	return resolvers.SearchSuggestions(ctx, r.AnimeService, prefix, limit, r.CacheService)
+}
+
+// WeeklySchedule is the resolver for the weeklySchedule field.
+func (r *queryResolver) WeeklySchedule(ctx context.Context, weekStart string, timezone string, airType *model.AirType) (*model.WeeklySchedule, error) {
+	return resolvers.WeeklySchedule(ctx, r.AnimeService, r.EpisodeAirTimeRepository, weekStart, timezone, airType)
+}
+
+// EpisodeAiring is the resolver for the episodeAiring field.
+func (r *subscriptionResolver) EpisodeAiring(ctx context.Context, animeIds []string) (<-chan *model.EpisodeAiringEvent, error) {
+	return resolvers.EpisodeAiring(ctx, r.AiringEvents, animeIds)
+}
+
+// ScheduleChanged is the resolver for the scheduleChanged field.
+func (r *subscriptionResolver) ScheduleChanged(ctx context.Context, animeIds []string) (<-chan *model.ScheduleChangedEvent, error) {
+	return resolvers.ScheduleChanged(ctx, r.AiringEvents, animeIds)
+}
+
+// Mutation returns generated.MutationResolver implementation.
+func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResolver{r} }
+
 // Query returns generated.QueryResolver implementation.
 func (r *Resolver) Query() generated.QueryResolver { return &queryResolver{r} }
 
+// Subscription returns generated.SubscriptionResolver implementation.
+func (r *Resolver) Subscription() generated.SubscriptionResolver { return &subscriptionResolver{r} }
+
+type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
+type subscriptionResolver struct{ *Resolver }
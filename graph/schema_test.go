@@ -0,0 +1,117 @@
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/weeb-vip/anime-api/graph/generated"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime"
+	anime_relation_repo "github.com/weeb-vip/anime-api/internal/db/repositories/anime_relation"
+	"github.com/weeb-vip/anime-api/internal/directives"
+	anime_service "github.com/weeb-vip/anime-api/internal/services/anime"
+	"github.com/weeb-vip/anime-api/internal/services/anime_relation"
+)
+
+// The embedded interfaces panic on the calls these queries do not make
+type fakeAnimeService struct {
+	anime_service.AnimeServiceImpl
+	anime map[string]*anime.Anime
+}
+
+func (f *fakeAnimeService) AnimeByID(ctx context.Context, id string) (*anime.Anime, error) {
+	return f.anime[id], nil
+}
+
+func (f *fakeAnimeService) AnimeByIDs(ctx context.Context, ids []string) ([]*anime.Anime, error) {
+	var found []*anime.Anime
+	for _, id := range ids {
+		if animeEntity, ok := f.anime[id]; ok {
+			found = append(found, animeEntity)
+		}
+	}
+	return found, nil
+}
+
+type fakeAnimeRelationService struct {
+	anime_relation.AnimeRelationServiceImpl
+	relations map[string][]*anime_relation_repo.AnimeRelation
+}
+
+func (f *fakeAnimeRelationService) FindByAnimeID(ctx context.Context, animeID string) ([]*anime_relation_repo.AnimeRelation, error) {
+	return f.relations[animeID], nil
+}
+
+// newTestClient serves the schema with the directives and complexity used in production
+func newTestClient(resolver *Resolver) *client.Client {
+	schema := generated.NewExecutableSchema(generated.Config{
+		Resolvers:  resolver,
+		Directives: directives.GetDirectives(),
+		Complexity: NewComplexityRoot(),
+	})
+	return client.New(handler.NewDefaultServer(schema))
+}
+
+func TestSchemaResolvesAnime(t *testing.T) {
+	titleEn, titleSequel, sequel := "Frieren", "Frieren Season 2", "Sequel"
+	resolver := &Resolver{
+		AnimeService: &fakeAnimeService{anime: map[string]*anime.Anime{
+			"anime-1": {ID: "anime-1", TitleEn: &titleEn},
+			"anime-2": {ID: "anime-2", TitleEn: &titleSequel},
+		}},
+		AnimeRelationService: &fakeAnimeRelationService{relations: map[string][]*anime_relation_repo.AnimeRelation{
+			"anime-1": {{AnimeID: "anime-1", RelatedAnimeID: "anime-2", RelationType: &sequel}},
+		}},
+	}
+	c := newTestClient(resolver)
+
+	t.Run("anime with its related anime", func(t *testing.T) {
+		var resp struct {
+			Anime struct {
+				ID        string
+				TitleEn   *string
+				Relations []struct {
+					RelationType string
+					Anime        struct{ ID string }
+				}
+			}
+		}
+		err := c.Post(`query { anime(id: "anime-1") { id titleEn relations { relationType anime { id } } } }`, &resp)
+		require.NoError(t, err)
+		assert.Equal(t, "anime-1", resp.Anime.ID)
+		require.NotNil(t, resp.Anime.TitleEn)
+		assert.Equal(t, titleEn, *resp.Anime.TitleEn)
+		require.Len(t, resp.Anime.Relations, 1)
+		assert.Equal(t, "SEQUEL", resp.Anime.Relations[0].RelationType)
+		assert.Equal(t, "anime-2", resp.Anime.Relations[0].Anime.ID)
+	})
+
+	t.Run("federation entities", func(t *testing.T) {
+		var resp struct {
+			Entities []struct {
+				ID      string
+				TitleEn *string
+			} `json:"_entities"`
+		}
+		err := c.Post(`query($representations: [_Any!]!) { _entities(representations: $representations) { ... on Anime { id titleEn } } }`, &resp,
+			client.Var("representations", []map[string]interface{}{
+				{"__typename": "Anime", "id": "anime-2"},
+				{"__typename": "Anime", "id": "anime-1"},
+			}))
+		require.NoError(t, err)
+		require.Len(t, resp.Entities, 2)
+		assert.Equal(t, "anime-2", resp.Entities[0].ID)
+		assert.Equal(t, "anime-1", resp.Entities[1].ID)
+	})
+
+	t.Run("scoped mutation needs a token", func(t *testing.T) {
+		var resp struct{ DeleteAnime *bool }
+		err := c.Post(`mutation { deleteAnime(id: "anime-1") }`, &resp)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "authentication required")
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/weeb-vip/anime-api/graph/model"
+	"github.com/weeb-vip/anime-api/internal/dataloaders"
 	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_schedule"
 	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_streaming_platform"
 	"github.com/weeb-vip/anime-api/internal/db/repositories/episode_air_time"
@@ -26,6 +27,17 @@ func (f *fakeStreamingPlatformRepo) FindByAnimeID(animeID string) ([]anime_strea
 	return f.platforms, f.err
 }
 
+func (f *fakeStreamingPlatformRepo) FindByAnimeIDs(animeIDs []string) (map[string][]anime_streaming_platform.AnimeStreamingPlatform, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	result := make(map[string][]anime_streaming_platform.AnimeStreamingPlatform, len(animeIDs))
+	for _, id := range animeIDs {
+		result[id] = f.platforms
+	}
+	return result, nil
+}
+
 type fakeScheduleRepo struct {
 	schedule *anime_schedule.AnimeSchedule
 	err      error
@@ -35,6 +47,19 @@ func (f *fakeScheduleRepo) FindByAnimeID(animeID string) (*anime_schedule.AnimeS
 	return f.schedule, f.err
 }
 
+func (f *fakeScheduleRepo) FindByAnimeIDs(animeIDs []string) (map[string]*anime_schedule.AnimeSchedule, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	result := make(map[string]*anime_schedule.AnimeSchedule, len(animeIDs))
+	if f.schedule != nil {
+		for _, id := range animeIDs {
+			result[id] = f.schedule
+		}
+	}
+	return result, nil
+}
+
 type fakeEpisodeAirTimeRepo struct {
 	airTimes []episode_air_time.EpisodeAirTime
 	err      error
@@ -44,6 +69,17 @@ func (f *fakeEpisodeAirTimeRepo) FindByAnimeID(animeID string) ([]episode_air_ti
 	return f.airTimes, f.err
 }
 
+func (f *fakeEpisodeAirTimeRepo) FindByAnimeIDs(animeIDs []string) (map[string][]episode_air_time.EpisodeAirTime, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	result := make(map[string][]episode_air_time.EpisodeAirTime, len(animeIDs))
+	for _, id := range animeIDs {
+		result[id] = f.airTimes
+	}
+	return result, nil
+}
+
 func (f *fakeEpisodeAirTimeRepo) FindByAnimeIDAndEpisode(animeID string, episodeNumber int) ([]episode_air_time.EpisodeAirTime, error) {
 	return f.airTimes, f.err
 }
@@ -58,6 +94,14 @@ func (f *fakeEpisodeAirTimeRepo) FindSubTimeByAnimeIDAndEpisode(animeID string,
 	return &f.airTimes[0], nil
 }
 
+func (f *fakeEpisodeAirTimeRepo) FindAiringBetween(from time.Time, to time.Time) ([]episode_air_time.EpisodeAirTime, error) {
+	return f.airTimes, f.err
+}
+
+func (f *fakeEpisodeAirTimeRepo) FindUpdatedBetween(from time.Time, to time.Time) ([]episode_air_time.EpisodeAirTime, error) {
+	return f.airTimes, f.err
+}
+
 func TestAnimeResolver_StreamingPlatforms(t *testing.T) {
 	ctx := context.Background()
 	obj := &model.Anime{ID: "anime-1"}
@@ -214,6 +258,23 @@ func TestEpisodeResolver_AirTimes(t *testing.T) {
 		assert.Nil(t, got[0].Streams)
 	})
 
+	t.Run("request loader keeps only this episode's rows", func(t *testing.T) {
+		when := time.Date(2026, 1, 2, 15, 0, 0, 0, time.UTC)
+		repo := &fakeEpisodeAirTimeRepo{airTimes: []episode_air_time.EpisodeAirTime{
+			{AnimeID: animeID, EpisodeNumber: 2, AirType: "sub", AirDatetime: when.AddDate(0, 0, -7)},
+			{AnimeID: animeID, EpisodeNumber: 3, AirType: "sub", AirDatetime: when},
+			{AnimeID: animeID, EpisodeNumber: 3, AirType: "dub", AirDatetime: when.AddDate(0, 0, 14)},
+		}}
+		r := &Resolver{EpisodeAirTimeRepository: repo}
+		loaderCtx := dataloaders.WithLoaders(ctx, dataloaders.NewLoaders(dataloaders.Sources{EpisodeAirTimeRepository: repo}))
+		got, err := r.Episode().AirTimes(loaderCtx, obj)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, model.AirType("sub"), got[0].AirType)
+		assert.Equal(t, when, got[0].AirDatetime)
+		assert.Equal(t, model.AirType("dub"), got[1].AirType)
+	})
+
 	t.Run("invalid streams JSON is ignored gracefully", func(t *testing.T) {
 		when := time.Date(2026, 1, 2, 15, 0, 0, 0, time.UTC)
 		bad := `{not valid json`
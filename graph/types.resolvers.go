@@ -11,6 +11,11 @@ import (
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/weeb-vip/anime-api/graph/generated"
 	"github.com/weeb-vip/anime-api/graph/model"
+	"github.com/weeb-vip/anime-api/internal/dataloaders"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_fanart"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_schedule"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_streaming_platform"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/episode_air_time"
 	"github.com/weeb-vip/anime-api/internal/resolvers"
 )
 
@@ -21,6 +26,11 @@ func (r *animeResolver) Tags(ctx context.Context, obj *model.Anime) ([]string, e
 		return obj.Tags, nil
 	}
 
+	// Batch with the other anime in this request when a loader is installed
+	if loaders := dataloaders.For(ctx); loaders != nil && loaders.Tags != nil {
+		return loaders.Tags.Load(ctx, obj.ID)
+	}
+
 	// Fetch tags from the anime_tags junction table
 	tags, err := r.AnimeTagRepository.GetTagNamesForAnime(obj.ID)
 	if err != nil {
@@ -29,6 +39,11 @@ func (r *animeResolver) Tags(ctx context.Context, obj *model.Anime) ([]string, e
 	return tags, nil
 }
 
+// TagDetails is the resolver for the tagDetails field.
+func (r *animeResolver) TagDetails(ctx context.Context, obj *model.Anime, includeSpoilers *bool) ([]*model.AnimeTag, error) {
+	return resolvers.AnimeTagDetails(ctx, r.AnimeTagRepository, obj.ID, includeSpoilers)
+}
+
 // Episodes is the resolver for the episodes field.
 func (r *animeResolver) Episodes(ctx context.Context, obj *model.Anime) ([]*model.Episode, error) {
 	// Check if episodes are already in the Episodes field (preloaded)
@@ -47,13 +62,33 @@ func (r *animeResolver) Episodes(ctx context.Context, obj *model.Anime) ([]*mode
 	return resolvers.EpisodesByAnimeID(ctx, r.AnimeEpisodeService, animeID)
 }
 
+// BroadcastInfo is the resolver for the broadcastInfo field.
+func (r *animeResolver) BroadcastInfo(ctx context.Context, obj *model.Anime) (*model.BroadcastInfo, error) {
+	return resolvers.BroadcastInfo(ctx, obj.Broadcast)
+}
+
+// OnHiatus is the resolver for the onHiatus field.
+func (r *animeResolver) OnHiatus(ctx context.Context, obj *model.Anime) (bool, error) {
+	if r.EpisodeDelayRepository == nil {
+		return false, nil
+	}
+	animeID := obj.ID
+	return resolvers.OnHiatus(ctx, r.EpisodeDelayRepository, animeID)
+}
+
 // ScheduleInfo is the resolver for the scheduleInfo field.
 func (r *animeResolver) ScheduleInfo(ctx context.Context, obj *model.Anime) (*model.AnimeScheduleInfo, error) {
 	if r.AnimeScheduleRepository == nil {
 		return nil, nil
 	}
-	schedule, err := r.AnimeScheduleRepository.FindByAnimeID(obj.ID)
-	if err != nil {
+	var schedule *anime_schedule.AnimeSchedule
+	var err error
+	if loaders := dataloaders.For(ctx); loaders != nil && loaders.Schedule != nil {
+		schedule, err = loaders.Schedule.Load(ctx, obj.ID)
+	} else {
+		schedule, err = r.AnimeScheduleRepository.FindByAnimeID(obj.ID)
+	}
+	if err != nil || schedule == nil {
 		return nil, nil // Not found is not an error
 	}
 	return &model.AnimeScheduleInfo{
@@ -72,7 +107,13 @@ func (r *animeResolver) StreamingPlatforms(ctx context.Context, obj *model.Anime
 	if r.AnimeStreamingPlatformRepository == nil {
 		return nil, nil
 	}
-	platforms, err := r.AnimeStreamingPlatformRepository.FindByAnimeID(obj.ID)
+	var platforms []anime_streaming_platform.AnimeStreamingPlatform
+	var err error
+	if loaders := dataloaders.For(ctx); loaders != nil && loaders.StreamingPlatforms != nil {
+		platforms, err = loaders.StreamingPlatforms.Load(ctx, obj.ID)
+	} else {
+		platforms, err = r.AnimeStreamingPlatformRepository.FindByAnimeID(obj.ID)
+	}
 	if err != nil {
 		return nil, nil
 	}
@@ -92,7 +133,13 @@ func (r *animeResolver) Fanart(ctx context.Context, obj *model.Anime) ([]*model.
 	if r.AnimeFanartRepository == nil {
 		return nil, nil
 	}
-	fanart, err := r.AnimeFanartRepository.FindByAnimeID(obj.ID)
+	var fanart []anime_fanart.Fanart
+	var err error
+	if loaders := dataloaders.For(ctx); loaders != nil && loaders.Fanart != nil {
+		fanart, err = loaders.Fanart.Load(ctx, obj.ID)
+	} else {
+		fanart, err = r.AnimeFanartRepository.FindByAnimeID(obj.ID)
+	}
 	if err != nil {
 		return nil, nil
 	}
@@ -113,6 +160,18 @@ func (r *animeResolver) Seasons(ctx context.Context, obj *model.Anime) ([]*model
 	return resolvers.AnimeSeasons(ctx, r.AnimeSeasonService, animeID)
 }
 
+// Relations is the resolver for the relations field.
+func (r *animeResolver) Relations(ctx context.Context, obj *model.Anime) ([]*model.AnimeRelation, error) {
+	if obj.Relations != nil {
+		return obj.Relations, nil
+	}
+	if r.AnimeRelationService == nil {
+		return nil, nil
+	}
+	animeID := obj.ID
+	return resolvers.AnimeRelations(ctx, r.AnimeRelationService, r.AnimeService, animeID)
+}
+
 // NextEpisode is the resolver for the nextEpisode field.
 func (r *animeResolver) NextEpisode(ctx context.Context, obj *model.Anime) (*model.Episode, error) {
 	if obj.NextEpisode != nil {
@@ -122,6 +181,29 @@ func (r *animeResolver) NextEpisode(ctx context.Context, obj *model.Anime) (*mod
 	return resolvers.NextEpisode(ctx, r.AnimeEpisodeService, animeID)
 }
 
+// Staff is the resolver for the staff field.
+func (r *animeCharacterResolver) Staff(ctx context.Context, obj *model.AnimeCharacter) ([]*model.AnimeStaff, error) {
+	if obj.Staff != nil {
+		return obj.Staff, nil
+	}
+	return resolvers.CharacterStaff(ctx, r.AnimeCharacterWithStaffLinkService, obj.ID)
+}
+
+// Characters is the resolver for the characters field.
+func (r *animeStaffResolver) Characters(ctx context.Context, obj *model.AnimeStaff) ([]*model.AnimeCharacter, error) {
+	return resolvers.StaffCharacters(ctx, r.AnimeCharacterWithStaffLinkService, obj.ID)
+}
+
+// Roles is the resolver for the roles field.
+func (r *animeStaffResolver) Roles(ctx context.Context, obj *model.AnimeStaff) ([]*model.StaffRole, error) {
+	return resolvers.StaffRoles(ctx, r.AnimeCharacterWithStaffLinkService, obj)
+}
+
+// Tag is the resolver for the tag field.
+func (r *animeTagResolver) Tag(ctx context.Context, obj *model.AnimeTag) (*model.Tag, error) {
+	return resolvers.AnimeTagTag(ctx, r.TagRepository, obj)
+}
+
 // AnimeAPI is the resolver for the animeApi field.
 func (r *apiInfoResolver) AnimeAPI(ctx context.Context, obj *model.APIInfo) (*model.AnimeAPI, error) {
 	return resolvers.AnimeAPI(r.Config)
@@ -132,9 +214,24 @@ func (r *episodeResolver) AirTimes(ctx context.Context, obj *model.Episode) ([]*
 	if r.EpisodeAirTimeRepository == nil || obj.AnimeID == nil || obj.EpisodeNumber == nil {
 		return nil, nil
 	}
-	airTimes, err := r.EpisodeAirTimeRepository.FindByAnimeIDAndEpisode(*obj.AnimeID, *obj.EpisodeNumber)
-	if err != nil {
-		return nil, nil
+	var airTimes []episode_air_time.EpisodeAirTime
+	if loaders := dataloaders.For(ctx); loaders != nil && loaders.EpisodeAirTimes != nil {
+		// The loader batches by anime, so pick out this episode's rows
+		animeAirTimes, err := loaders.EpisodeAirTimes.Load(ctx, *obj.AnimeID)
+		if err != nil {
+			return nil, nil
+		}
+		for _, at := range animeAirTimes {
+			if at.EpisodeNumber == *obj.EpisodeNumber {
+				airTimes = append(airTimes, at)
+			}
+		}
+	} else {
+		var err error
+		airTimes, err = r.EpisodeAirTimeRepository.FindByAnimeIDAndEpisode(*obj.AnimeID, *obj.EpisodeNumber)
+		if err != nil {
+			return nil, nil
+		}
 	}
 	var result []*model.EpisodeAirTime
 	for _, at := range airTimes {
@@ -166,6 +263,43 @@ func (r *episodeResolver) AirTimes(ctx context.Context, obj *model.Episode) ([]*
 	return result, nil
 }
 
+// Delay is the resolver for the delay field.
+func (r *episodeResolver) Delay(ctx context.Context, obj *model.Episode) (*model.EpisodeDelay, error) {
+	if obj.Delay != nil {
+		return obj.Delay, nil
+	}
+	if r.EpisodeDelayRepository == nil || obj.AnimeID == nil || obj.EpisodeNumber == nil {
+		return nil, nil
+	}
+	return resolvers.EpisodeDelay(ctx, r.EpisodeDelayRepository, *obj.AnimeID, *obj.EpisodeNumber)
+}
+
+// Anime is the resolver for the anime field.
+func (r *sharedCastResultResolver) Anime(ctx context.Context, obj *model.SharedCastResult) (*model.Anime, error) {
+	if obj.Anime != nil {
+		return obj.Anime, nil
+	}
+	return resolvers.SharedCastAnime(ctx, r.AnimeService, obj)
+}
+
+// Anime is the resolver for the anime field.
+func (r *staffRoleResolver) Anime(ctx context.Context, obj *model.StaffRole) (*model.Anime, error) {
+	if obj.Anime != nil {
+		return obj.Anime, nil
+	}
+	return resolvers.StaffRoleAnime(ctx, r.AnimeService, obj)
+}
+
+// Parent is the resolver for the parent field.
+func (r *tagResolver) Parent(ctx context.Context, obj *model.Tag) (*model.Tag, error) {
+	return resolvers.TagParent(ctx, r.TagRepository, obj)
+}
+
+// Children is the resolver for the children field.
+func (r *tagResolver) Children(ctx context.Context, obj *model.Tag) ([]*model.Tag, error) {
+	return resolvers.TagChildren(ctx, r.TagRepository, obj)
+}
+
 // Anime is the resolver for the anime field.
 func (r *userAnimeResolver) Anime(ctx context.Context, obj *model.UserAnime) (*model.Anime, error) {
 	animeID := obj.AnimeID
@@ -175,18 +309,46 @@ func (r *userAnimeResolver) Anime(ctx context.Context, obj *model.UserAnime) (*m
 // Anime returns generated.AnimeResolver implementation.
 func (r *Resolver) Anime() generated.AnimeResolver { return &animeResolver{r} }
 
+// AnimeCharacter returns generated.AnimeCharacterResolver implementation.
+func (r *Resolver) AnimeCharacter() generated.AnimeCharacterResolver {
+	return &animeCharacterResolver{r}
+}
+
+// AnimeStaff returns generated.AnimeStaffResolver implementation.
+func (r *Resolver) AnimeStaff() generated.AnimeStaffResolver { return &animeStaffResolver{r} }
+
+// AnimeTag returns generated.AnimeTagResolver implementation.
+func (r *Resolver) AnimeTag() generated.AnimeTagResolver { return &animeTagResolver{r} }
+
 // ApiInfo returns generated.ApiInfoResolver implementation.
 func (r *Resolver) ApiInfo() generated.ApiInfoResolver { return &apiInfoResolver{r} }
 
 // Episode returns generated.EpisodeResolver implementation.
 func (r *Resolver) Episode() generated.EpisodeResolver { return &episodeResolver{r} }
 
+// SharedCastResult returns generated.SharedCastResultResolver implementation.
+func (r *Resolver) SharedCastResult() generated.SharedCastResultResolver {
+	return &sharedCastResultResolver{r}
+}
+
+// StaffRole returns generated.StaffRoleResolver implementation.
+func (r *Resolver) StaffRole() generated.StaffRoleResolver { return &staffRoleResolver{r} }
+
+// Tag returns generated.TagResolver implementation.
+func (r *Resolver) Tag() generated.TagResolver { return &tagResolver{r} }
+
 // UserAnime returns generated.UserAnimeResolver implementation.
 func (r *Resolver) UserAnime() generated.UserAnimeResolver { return &userAnimeResolver{r} }
 
 type animeResolver struct{ *Resolver }
+type animeCharacterResolver struct{ *Resolver }
+type animeStaffResolver struct{ *Resolver }
+type animeTagResolver struct{ *Resolver }
 type apiInfoResolver struct{ *Resolver }
 type episodeResolver struct{ *Resolver }
+type sharedCastResultResolver struct{ *Resolver }
+type staffRoleResolver struct{ *Resolver }
+type tagResolver struct{ *Resolver }
 type userAnimeResolver struct{ *Resolver }
 
 // !!! WARNING !!!
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/weeb-vip/anime-api/internal/calendar"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime"
+	"github.com/weeb-vip/anime-api/internal/logger"
+	"github.com/weeb-vip/anime-api/internal/services/anime_calendar"
+)
+
+const (
+	defaultCalendarDays = 14
+	maxCalendarDays     = 60
+	maxCalendarAnimeIDs = 100
+	// calendarMaxAge is how long clients and proxies may reuse a feed; calendar apps
+	// poll far less often than this anyway
+	calendarMaxAge = 15 * time.Minute
+)
+
+// CalendarHandler serves the airing schedule as an iCalendar feed. ?season=FALL_2024
+// covers a season, ?animeIds=a,b covers those anime, and otherwise the feed covers
+// the currently airing window of ?days days from now.
+func CalendarHandler(calendarService anime_calendar.AnimeCalendarServiceImpl) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		log := logger.FromCtx(ctx)
+		query := r.URL.Query()
+
+		var cal *calendar.Calendar
+		var err error
+		switch {
+		case query.Get("season") != "":
+			season, parseErr := anime.ParseSeason(query.Get("season"))
+			if parseErr != nil {
+				http.Error(w, parseErr.Error(), http.StatusBadRequest)
+				return
+			}
+			cal, err = calendarService.SeasonCalendar(ctx, string(season))
+		case query.Get("animeIds") != "":
+			ids := splitIDs(query.Get("animeIds"))
+			if len(ids) == 0 || len(ids) > maxCalendarAnimeIDs {
+				http.Error(w, fmt.Sprintf("animeIds must list between 1 and %d IDs", maxCalendarAnimeIDs), http.StatusBadRequest)
+				return
+			}
+			cal, err = calendarService.AnimeCalendar(ctx, ids)
+		default:
+			days := defaultCalendarDays
+			if raw := query.Get("days"); raw != "" {
+				days, err = strconv.Atoi(raw)
+				if err != nil || days < 1 || days > maxCalendarDays {
+					http.Error(w, fmt.Sprintf("days must be between 1 and %d", maxCalendarDays), http.StatusBadRequest)
+					return
+				}
+			}
+			cal, err = calendarService.AiringCalendar(ctx, time.Now(), days)
+		}
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to build calendar")
+			http.Error(w, "failed to build calendar", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+		w.Header().Set("Content-Disposition", `inline; filename="anime.ics"`)
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(calendarMaxAge.Seconds())))
+		if err := cal.Encode(w, time.Now()); err != nil {
+			log.Warn().Err(err).Msg("Failed to write calendar")
+		}
+	}
+}
+
+// splitIDs splits a comma separated list, dropping blanks and duplicates
+func splitIDs(value string) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, id := range strings.Split(value, ",") {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids
+}
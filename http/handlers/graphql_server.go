@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/weeb-vip/anime-api/config"
+	"github.com/weeb-vip/anime-api/http/middleware"
+	"github.com/weeb-vip/anime-api/internal/cache"
+	"github.com/weeb-vip/anime-api/internal/persistedqueries"
+)
+
+// newGraphQLServer sets up the transports of handler.NewDefaultServer, with persisted
+// queries kept in Redis when it is enabled and in an in-memory LRU otherwise, and
+// operations over the configured complexity or depth rejected. It fails when the
+// persisted query manifest cannot be loaded.
+func newGraphQLServer(schema graphql.ExecutableSchema, conf config.Config, cacheInstance cache.Cache) (*handler.Server, error) {
+	srv := handler.New(schema)
+
+	srv.AddTransport(transport.Websocket{
+		KeepAlivePingInterval: 10 * time.Second,
+	})
+	srv.AddTransport(transport.Options{})
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.AddTransport(transport.MultipartForm{})
+
+	srv.SetQueryCache(lru.New(1000))
+
+	srv.Use(extension.Introspection{})
+	srv.Use(&middleware.QueryLimitsExtension{
+		MaxComplexity: conf.AppConfig.MaxQueryComplexity,
+		MaxDepth:      conf.AppConfig.MaxQueryDepth,
+	})
+
+	queryStore := cacheInstance
+	if _, ok := cacheInstance.(*cache.RedisCache); !ok {
+		queryStore = cache.NewMemoryCache(conf.GraphQLConfig.APQCacheSize)
+	}
+	persistedQueries, err := persistedqueries.NewExtension(conf.GraphQLConfig, queryStore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up persisted queries: %w", err)
+	}
+	srv.Use(persistedQueries)
+
+	return srv, nil
+}
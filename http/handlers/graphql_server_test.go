@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/weeb-vip/anime-api/config"
+	"github.com/weeb-vip/anime-api/graph"
+	"github.com/weeb-vip/anime-api/graph/generated"
+	"github.com/weeb-vip/anime-api/internal/cache"
+)
+
+func TestNewGraphQLServer(t *testing.T) {
+	schema := generated.NewExecutableSchema(generated.Config{Resolvers: &graph.Resolver{}})
+
+	tests := []struct {
+		name    string
+		conf    config.GraphQLConfig
+		wantErr bool
+	}{
+		{name: "automatic persisted queries", conf: config.GraphQLConfig{APQCacheSize: 10}},
+		{name: "missing manifest", conf: config.GraphQLConfig{PersistedQueriesFile: filepath.Join(t.TempDir(), "missing.json")}, wantErr: true},
+		{name: "allow-list without manifest", conf: config.GraphQLConfig{PersistedQueriesOnly: true}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, err := newGraphQLServer(schema, config.Config{GraphQLConfig: tt.conf}, cache.NewNoOpCache())
+			if tt.wantErr {
+				if err == nil || srv != nil {
+					t.Fatalf("Expected an error, got %v, %v", srv, err)
+				}
+				return
+			}
+			if err != nil || srv == nil {
+				t.Fatalf("Expected a server, got %v, %v", srv, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/weeb-vip/anime-api/internal/cache"
+)
+
+type fakeDatabase struct {
+	pingErr      error
+	version      uint
+	dirty        bool
+	migrationErr error
+}
+
+func (f *fakeDatabase) Ping(ctx context.Context) error {
+	return f.pingErr
+}
+
+func (f *fakeDatabase) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	return f.version, f.dirty, f.migrationErr
+}
+
+type fakeCache struct {
+	*cache.NoOpCache
+	pingErr error
+}
+
+func (f *fakeCache) Ping(ctx context.Context) error {
+	return f.pingErr
+}
+
+func TestReadinessHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		database     *fakeDatabase
+		cache        cache.Cache
+		drain        bool
+		wantCode     int
+		wantStatus   string
+		wantCache    string
+		wantMigrated string
+	}{
+		{"ready", &fakeDatabase{version: 40}, &fakeCache{NoOpCache: cache.NewNoOpCache()}, false, http.StatusOK, "ok", "ok", "ok"},
+		{"cache disabled", &fakeDatabase{version: 40}, cache.NewNoOpCache(), false, http.StatusOK, "ok", "disabled", "ok"},
+		{"migrations pending", &fakeDatabase{version: 36}, cache.NewNoOpCache(), false, http.StatusOK, "ok", "disabled", "pending"},
+		{"database unreachable", &fakeDatabase{pingErr: errors.New("connection refused"), migrationErr: errors.New("connection refused")}, cache.NewNoOpCache(), false, http.StatusServiceUnavailable, "unavailable", "disabled", "unavailable"},
+		{"cache unreachable", &fakeDatabase{version: 40}, &fakeCache{NoOpCache: cache.NewNoOpCache(), pingErr: errors.New("i/o timeout")}, false, http.StatusServiceUnavailable, "unavailable", "unavailable", "ok"},
+		{"dirty migration", &fakeDatabase{version: 40, dirty: true}, cache.NewNoOpCache(), false, http.StatusServiceUnavailable, "unavailable", "disabled", "dirty"},
+		{"draining", &fakeDatabase{version: 40}, cache.NewNoOpCache(), true, http.StatusServiceUnavailable, "draining", "disabled", "ok"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			health := NewHealth(tt.database, tt.cache, 40)
+			if tt.drain {
+				health.Drain()
+			}
+
+			rec := httptest.NewRecorder()
+			health.ReadinessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			if rec.Code != tt.wantCode {
+				t.Errorf("expected status code %d, got %d", tt.wantCode, rec.Code)
+			}
+			var report readinessReport
+			if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+				t.Fatalf("failed to decode report: %v", err)
+			}
+			if report.Status != tt.wantStatus || report.Cache.Status != tt.wantCache || report.Migration.Status != tt.wantMigrated {
+				t.Errorf("unexpected report: %+v", report)
+			}
+			if report.Migration.Latest != 40 {
+				t.Errorf("expected latest migration 40, got %d", report.Migration.Latest)
+			}
+		})
+	}
+}
@@ -2,14 +2,17 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"time"
 
-	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/weeb-vip/anime-api/config"
+	migrations "github.com/weeb-vip/anime-api/db"
 	"github.com/weeb-vip/anime-api/graph"
 	"github.com/weeb-vip/anime-api/graph/generated"
 	"github.com/weeb-vip/anime-api/http/middleware"
 	"github.com/weeb-vip/anime-api/internal/cache"
+	"github.com/weeb-vip/anime-api/internal/dataloaders"
 	"github.com/weeb-vip/anime-api/internal/db"
 	anime2 "github.com/weeb-vip/anime-api/internal/db/repositories/anime"
 	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_character"
@@ -17,100 +20,50 @@ import (
 	anime3 "github.com/weeb-vip/anime-api/internal/db/repositories/anime_episode"
 	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_schedule"
 	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_fanart"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_relation"
 	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_season"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_staff"
 	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_streaming_platform"
 	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_tag"
 	"github.com/weeb-vip/anime-api/internal/db/repositories/episode_air_time"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/episode_delay"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/tag"
 	"github.com/weeb-vip/anime-api/internal/directives"
 	"github.com/weeb-vip/anime-api/internal/logger"
+	"github.com/weeb-vip/anime-api/internal/pubsub"
+	"github.com/weeb-vip/anime-api/internal/ratelimit"
+	"github.com/weeb-vip/anime-api/internal/search"
+	"github.com/weeb-vip/anime-api/internal/services/airing"
 	"github.com/weeb-vip/anime-api/internal/services/anime"
+	"github.com/weeb-vip/anime-api/internal/services/anime_calendar"
 	anime_character2 "github.com/weeb-vip/anime-api/internal/services/anime_character"
 	anime_character_staff_link2 "github.com/weeb-vip/anime-api/internal/services/anime_character_staff_link"
+	anime_relation_service "github.com/weeb-vip/anime-api/internal/services/anime_relation"
+	"github.com/weeb-vip/anime-api/internal/services/anime_search"
 	anime_season_service "github.com/weeb-vip/anime-api/internal/services/anime_season"
+	anime_staff_service "github.com/weeb-vip/anime-api/internal/services/anime_staff"
 	"github.com/weeb-vip/anime-api/internal/services/episodes"
 )
 
-func BuildRootHandler(conf config.Config) http.Handler {
-	database := db.NewDatabase(conf.DBConfig)
-
-	// Initialize cache if enabled
-	log := logger.FromCtx(context.Background())
-	log.Info().
-		Bool("redis_enabled", conf.RedisConfig.Enabled).
-		Str("redis_host", conf.RedisConfig.Host).
-		Str("redis_port", conf.RedisConfig.Port).
-		Int("redis_db", conf.RedisConfig.DB).
-		Msg("Cache configuration")
-
-	cacheInstance, err := cache.NewCache(conf)
-	if err != nil {
-		log.Error().Err(err).Msg("Failed to initialize cache, continuing without caching")
-		cacheInstance = cache.NewNoOpCache()
-	} else if conf.RedisConfig.Enabled {
-		log.Info().Msg("Redis cache successfully initialized")
-	} else {
-		log.Info().Msg("Cache disabled by configuration")
-	}
-	cacheService := cache.NewUltraOptimizedCacheService(cacheInstance, conf.RedisConfig)
-
-	// Initialize repositories
-	var animeRepository anime2.AnimeRepositoryImpl
-	var episodeRepository anime3.AnimeEpisodeRepositoryImpl
-
-	log.Info().Bool("cache_enabled", conf.RedisConfig.Enabled).Msg("Cache configuration status")
-
-	if conf.RedisConfig.Enabled {
-		log.Info().Msg("Cache enabled, using repositories with caching")
-
-		// Use repositories with caching when enabled
-		animeRepository = anime2.NewAnimeRepositoryWithCache(database, cacheService.CompressedCacheService.CacheService)
-		episodeRepository = anime3.NewAnimeEpisodeRepositoryWithCache(database, cacheService.CompressedCacheService.CacheService)
-	} else {
-		log.Info().Msg("Cache disabled, using direct database repositories")
-
-		// Use direct repositories when caching is disabled
-		animeRepository = anime2.NewAnimeRepository(database)
-		episodeRepository = anime3.NewAnimeEpisodeRepository(database)
-	}
-
-	animeService := anime.NewAnimeService(animeRepository)
-	animeEpisodeService := episodes.NewAnimeEpisodeService(episodeRepository)
-	animeCharacterRepository := anime_character.NewAnimeCharacterRepository(database)
-	animeCharacterService := anime_character2.NewAnimeCharacterService(animeCharacterRepository)
-	animeCharacterWithStaffLinkRepository := anime_character_staff_link.NewAnimeCharacterStaffLinkRepository(database)
-	animeCharacterWithStaffLinkService := anime_character_staff_link2.NewAnimeCharacterStaffLinkService(animeCharacterWithStaffLinkRepository)
-	animeSeasonRepository := anime_season.NewAnimeSeasonRepository(database)
-	animeSeasonService := anime_season_service.NewAnimeSeasonService(animeSeasonRepository)
-	animeTagRepository := anime_tag.NewAnimeTagRepository(database)
-	animeScheduleRepository := anime_schedule.NewAnimeScheduleRepository(database)
-	animeStreamingPlatformRepository := anime_streaming_platform.NewAnimeStreamingPlatformRepository(database)
-	animeFanartRepository := anime_fanart.NewAnimeFanartRepository(database)
-	episodeAirTimeRepository := episode_air_time.NewEpisodeAirTimeRepository(database)
-	resolvers := &graph.Resolver{
-		Config:                             conf,
-		AnimeService:                       animeService,
-		AnimeEpisodeService:                animeEpisodeService,
-		AnimeCharacterService:              animeCharacterService,
-		AnimeCharacterWithStaffLinkService: animeCharacterWithStaffLinkService,
-		AnimeSeasonService:                 animeSeasonService,
-		AnimeTagRepository:                 animeTagRepository,
-		AnimeScheduleRepository:            animeScheduleRepository,
-		AnimeStreamingPlatformRepository:   animeStreamingPlatformRepository,
-		AnimeFanartRepository:              animeFanartRepository,
-		EpisodeAirTimeRepository:           episodeAirTimeRepository,
-	}
+// RootHandlers are the HTTP handlers served from one set of repositories and services
+type RootHandlers struct {
+	GraphQL  http.Handler
+	Calendar http.Handler
+	Health   *Health
 
-	cfg := generated.Config{Resolvers: resolvers, Directives: directives.GetDirectives()}
-
-	srv := handler.NewDefaultServer(generated.NewExecutableSchema(cfg))
-
-	// Add GraphQL tracing extension
-	srv.Use(&middleware.GraphQLTracingExtension{})
+	database      *db.DB
+	cacheInstance cache.Cache
+}
 
-	return srv
+// Close releases the cache and database connections, once requests have drained
+func (h RootHandlers) Close() error {
+	return errors.Join(h.cacheInstance.Close(), h.database.Close())
 }
 
-func BuildRootHandlerWithContext(ctx context.Context, conf config.Config) http.Handler {
+// BuildRootHandlersWithContext wires the repositories and services into the HTTP
+// handlers. It fails, with the connections closed, when the GraphQL server cannot
+// be set up from the configuration.
+func BuildRootHandlersWithContext(ctx context.Context, conf config.Config) (RootHandlers, error) {
 	database := db.NewDatabase(conf.DBConfig)
 
 	// Initialize cache if enabled
@@ -143,41 +96,105 @@ func BuildRootHandlerWithContext(ctx context.Context, conf config.Config) http.H
 		episodeRepository = anime3.NewAnimeEpisodeRepository(database)
 	}
 
-	animeService := anime.NewAnimeService(animeRepository)
-	animeEpisodeService := episodes.NewAnimeEpisodeService(episodeRepository)
+	episodeAirTimeRepository := episode_air_time.NewEpisodeAirTimeRepository(database)
+	airingEvents := buildAiringEvents(ctx, conf, cacheInstance, episodeRepository, episodeAirTimeRepository)
+
+	searchIndex := search.NewIndex()
+	suggestionIndex := search.NewPrefixIndex()
+	go anime_search.NewAnimeSearchIndexer(animeRepository, searchIndex, suggestionIndex).Run(ctx, time.Duration(conf.SearchConfig.RefreshIntervalSeconds)*time.Second)
+
+	animeService := anime.NewAnimeServiceWithSearch(animeRepository, searchIndex, suggestionIndex)
+	animeEpisodeService := episodes.NewAnimeEpisodeServiceWithEvents(episodeRepository, airingEvents)
 	animeCharacterRepository := anime_character.NewAnimeCharacterRepository(database)
 	animeCharacterService := anime_character2.NewAnimeCharacterService(animeCharacterRepository)
 	animeCharacterWithStaffLinkRepository := anime_character_staff_link.NewAnimeCharacterStaffLinkRepository(database)
 	animeCharacterWithStaffLinkService := anime_character_staff_link2.NewAnimeCharacterStaffLinkService(animeCharacterWithStaffLinkRepository)
+	animeStaffService := anime_staff_service.NewAnimeStaffService(anime_staff.NewAnimeStaffRepository(database))
 	animeSeasonRepository := anime_season.NewAnimeSeasonRepository(database)
 	animeSeasonService := anime_season_service.NewAnimeSeasonService(animeSeasonRepository)
+	animeRelationRepository := anime_relation.NewAnimeRelationRepository(database)
+	animeRelationService := anime_relation_service.NewAnimeRelationService(animeRelationRepository)
 	animeTagRepository := anime_tag.NewAnimeTagRepository(database)
+	tagRepository := tag.NewTagRepository(database)
 	animeScheduleRepository := anime_schedule.NewAnimeScheduleRepository(database)
 	animeStreamingPlatformRepository := anime_streaming_platform.NewAnimeStreamingPlatformRepository(database)
 	animeFanartRepository := anime_fanart.NewAnimeFanartRepository(database)
-	episodeAirTimeRepository := episode_air_time.NewEpisodeAirTimeRepository(database)
+	episodeDelayRepository := episode_delay.NewEpisodeDelayRepository(database)
 	resolvers := &graph.Resolver{
 		Config:                             conf,
 		AnimeService:                       animeService,
 		AnimeEpisodeService:                animeEpisodeService,
 		AnimeCharacterService:              animeCharacterService,
 		AnimeCharacterWithStaffLinkService: animeCharacterWithStaffLinkService,
+		AnimeStaffService:                  animeStaffService,
 		AnimeSeasonService:                 animeSeasonService,
+		AnimeRelationService:               animeRelationService,
 		AnimeTagRepository:                 animeTagRepository,
+		TagRepository:                      tagRepository,
 		AnimeScheduleRepository:            animeScheduleRepository,
 		AnimeStreamingPlatformRepository:   animeStreamingPlatformRepository,
 		AnimeFanartRepository:              animeFanartRepository,
 		EpisodeAirTimeRepository:           episodeAirTimeRepository,
+		EpisodeDelayRepository:             episodeDelayRepository,
 		CacheService:                       cacheService,
+		AiringEvents:                       airingEvents,
 		Context:                            ctx,
 	}
 
-	cfg := generated.Config{Resolvers: resolvers, Directives: directives.GetDirectives()}
+	cfg := generated.Config{Resolvers: resolvers, Directives: directives.GetDirectives(), Complexity: graph.NewComplexityRoot()}
 
-	srv := handler.NewDefaultServer(generated.NewExecutableSchema(cfg))
+	srv, err := newGraphQLServer(generated.NewExecutableSchema(cfg), conf, cacheInstance)
+	if err != nil {
+		return RootHandlers{}, errors.Join(err, cacheInstance.Close(), database.Close())
+	}
 
 	// Add GraphQL tracing extension
 	srv.Use(&middleware.GraphQLTracingExtension{})
 
-	return srv
+	// Batch per-anime field lookups with loaders scoped to each request
+	loaderMiddleware := dataloaders.Middleware(dataloaders.Sources{
+		AnimeTagRepository:               animeTagRepository,
+		TagRepository:                    tagRepository,
+		AnimeScheduleRepository:          animeScheduleRepository,
+		AnimeStreamingPlatformRepository: animeStreamingPlatformRepository,
+		AnimeFanartRepository:            animeFanartRepository,
+		EpisodeAirTimeRepository:         episodeAirTimeRepository,
+		EpisodeDelayRepository:           episodeDelayRepository,
+		AnimeSeasonService:               animeSeasonService,
+		AnimeRelationService:             animeRelationService,
+		AnimeCharacterStaffLinkService:   animeCharacterWithStaffLinkService,
+		AnimeService:                     animeService,
+	})
+
+	// Buckets live in Redis when it is enabled, so the limit holds across replicas
+	rateLimit := middleware.RateLimitMiddleware(ratelimit.NewBuckets(cacheInstance), conf.RateLimitConfig)
+
+	latestMigration, err := migrations.LatestMigrationVersion()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to read embedded migrations, readiness will not report pending ones")
+	}
+
+	return RootHandlers{
+		GraphQL:       rateLimit(loaderMiddleware(srv)),
+		Calendar:      rateLimit(CalendarHandler(anime_calendar.NewAnimeCalendarService(animeService, episodeAirTimeRepository))),
+		Health:        NewHealth(database, cacheInstance, latestMigration),
+		database:      database,
+		cacheInstance: cacheInstance,
+	}, nil
+}
+
+// buildAiringEvents returns the bus for subscription events and starts the scheduler
+// feeding it. With Redis the events are relayed through it so subscribers on every
+// replica receive them, and replicas claim events so each is sent once.
+func buildAiringEvents(ctx context.Context, conf config.Config, cacheInstance cache.Cache, episodeRepository anime3.AnimeEpisodeRepositoryImpl, episodeAirTimeRepository episode_air_time.EpisodeAirTimeRepositoryImpl) *airing.Events {
+	var bus pubsub.Bus = pubsub.NewLocalBus()
+	var claims cache.Cache
+	if redisCache, ok := cacheInstance.(*cache.RedisCache); ok {
+		bus = pubsub.NewRedisBus(ctx, redisCache.Client())
+		claims = redisCache
+	}
+
+	events := airing.NewEvents(bus)
+	go airing.NewScheduler(episodeRepository, episodeAirTimeRepository, events, claims).Run(ctx, time.Duration(conf.AiringConfig.PollIntervalSeconds)*time.Second)
+	return events
 }
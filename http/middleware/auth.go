@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/weeb-vip/anime-api/internal/auth"
+	"github.com/weeb-vip/anime-api/internal/logger"
+)
+
+// AuthMiddleware verifies the bearer token of a request, if any, and puts its
+// claims on the context for the @scoped directive. Requests without a valid token
+// pass through anonymously, so public fields still resolve and @scoped rejects
+// the protected ones. With a nil verifier every request is treated as anonymous.
+func AuthMiddleware(verifier *auth.Verifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			if header == "" || verifier == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			log := logger.FromCtx(r.Context())
+			token, ok := bearerToken(header)
+			if !ok {
+				log.Debug().Msg("Ignoring non-bearer authorization header")
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			claims, err := verifier.Verify(token)
+			if err != nil {
+				log.Debug().Err(err).Msg("Ignoring invalid bearer token")
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithClaims(r.Context(), claims)))
+		})
+	}
+}
+
+func bearerToken(header string) (string, bool) {
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
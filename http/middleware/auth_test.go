@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/weeb-vip/anime-api/config"
+	"github.com/weeb-vip/anime-api/internal/auth"
+)
+
+func hs256Token(secret, claimsJSON string) string {
+	signed := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte(claimsJSON))
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	verifier, err := auth.NewVerifier(config.AuthConfig{JWTSecret: "secret"})
+	if err != nil {
+		t.Fatalf("NewVerifier returned error: %v", err)
+	}
+
+	var seen *auth.Claims
+	handler := AuthMiddleware(verifier)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = auth.ClaimsFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name       string
+		header     string
+		wantStatus int
+		wantClaims bool
+	}{
+		{name: "anonymous request passes through", wantStatus: http.StatusOK},
+		{name: "valid token puts claims on context", header: "Bearer " + hs256Token("secret", `{"sub":"user-1","scope":"admin","exp":4102444800}`), wantStatus: http.StatusOK, wantClaims: true},
+		{name: "invalid signature is anonymous", header: "Bearer " + hs256Token("wrong", `{"sub":"user-1","exp":4102444800}`), wantStatus: http.StatusOK},
+		{name: "expired token is anonymous", header: "Bearer " + hs256Token("secret", `{"sub":"user-1","exp":1}`), wantStatus: http.StatusOK},
+		{name: "non-bearer scheme is anonymous", header: "Basic dXNlcjpwYXNz", wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen = nil
+			req := httptest.NewRequest("POST", "/graphql", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, recorder.Code)
+			}
+			if tt.wantClaims && (seen == nil || seen.Subject != "user-1" || !seen.HasScope("admin")) {
+				t.Errorf("Expected claims for user-1 with admin scope, got %+v", seen)
+			}
+			if !tt.wantClaims && seen != nil {
+				t.Errorf("Expected no claims, got %+v", seen)
+			}
+		})
+	}
+}
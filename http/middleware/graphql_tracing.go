@@ -50,6 +50,10 @@ func (e GraphQLTracingExtension) InterceptOperation(ctx context.Context, next gr
 
 	return func(ctx context.Context) *graphql.Response {
 		response := responseHandler(ctx)
+		// Subscriptions end with a nil response
+		if response == nil {
+			return nil
+		}
 
 		// Log any GraphQL errors
 		if response.Errors != nil && len(response.Errors) > 0 {
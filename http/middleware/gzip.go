@@ -17,8 +17,9 @@ import (
 func GzipMiddleware() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Skip compression for metrics endpoint and other endpoints that shouldn't be compressed
-			if shouldSkipCompression(r.URL.Path) {
+			// Skip compression for metrics endpoint and other endpoints that shouldn't be compressed,
+			// and for WebSocket upgrades, which need the raw connection
+			if shouldSkipCompression(r.URL.Path) || isWebSocketUpgrade(r) {
 				next.ServeHTTP(w, r)
 				return
 			}
@@ -113,6 +114,11 @@ func shouldSkipCompression(path string) bool {
 	return false
 }
 
+// isWebSocketUpgrade reports whether the request opens a WebSocket, such as a GraphQL subscription
+func isWebSocketUpgrade(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket")
+}
+
 // gzipResponseWriter wraps http.ResponseWriter to provide gzip compression
 type gzipResponseWriter struct {
 	http.ResponseWriter
@@ -1,9 +1,11 @@
 package middleware
 
 import (
+	"bufio"
 	"bytes"
 	"compress/gzip"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -197,4 +199,37 @@ func TestGzipMiddleware(t *testing.T) {
 			t.Error("/graphql endpoint should be compressed")
 		}
 	})
-}
\ No newline at end of file
+
+	t.Run("WebSocket upgrades should not be compressed", func(t *testing.T) {
+		var hijackable bool
+		testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, hijackable = w.(http.Hijacker)
+		})
+
+		handler := GzipMiddleware()(testHandler)
+
+		req := httptest.NewRequest("GET", "/graphql", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		req.Header.Set("Connection", "Upgrade")
+		req.Header.Set("Upgrade", "websocket")
+
+		recorder := &hijackableRecorder{ResponseRecorder: httptest.NewRecorder()}
+		handler.ServeHTTP(recorder, req)
+
+		if recorder.Header().Get("Content-Encoding") != "" {
+			t.Error("WebSocket upgrade should not be compressed")
+		}
+		if !hijackable {
+			t.Error("WebSocket upgrade should get the original, hijackable response writer")
+		}
+	})
+}
+
+// hijackableRecorder stands in for the server's response writer, which supports hijacking
+type hijackableRecorder struct {
+	*httptest.ResponseRecorder
+}
+
+func (r *hijackableRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return nil, nil, nil
+}
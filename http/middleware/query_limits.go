@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/99designs/gqlgen/complexity"
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/weeb-vip/anime-api/internal/logger"
+	"github.com/weeb-vip/anime-api/metrics"
+)
+
+const (
+	errComplexityLimitCode = "COMPLEXITY_LIMIT_EXCEEDED"
+	errDepthLimitCode      = "DEPTH_LIMIT_EXCEEDED"
+)
+
+// QueryLimitsExtension rejects operations nested deeper than MaxDepth or costing more
+// than MaxComplexity, as worked out from the schema's complexity functions, before
+// any resolver runs. A limit of 0 is not enforced.
+type QueryLimitsExtension struct {
+	MaxComplexity int
+	MaxDepth      int
+
+	schema graphql.ExecutableSchema
+}
+
+var _ interface {
+	graphql.OperationContextMutator
+	graphql.HandlerExtension
+} = &QueryLimitsExtension{}
+
+// ExtensionName returns the name of the extension
+func (e *QueryLimitsExtension) ExtensionName() string {
+	return "QueryLimits"
+}
+
+// Validate keeps the schema the complexity is calculated against
+func (e *QueryLimitsExtension) Validate(schema graphql.ExecutableSchema) error {
+	if e.MaxComplexity < 0 || e.MaxDepth < 0 {
+		return fmt.Errorf("QueryLimits limits can not be negative")
+	}
+	e.schema = schema
+	return nil
+}
+
+// MutateOperationContext checks the operation against the limits
+func (e *QueryLimitsExtension) MutateOperationContext(ctx context.Context, rc *graphql.OperationContext) *gqlerror.Error {
+	op := rc.Doc.Operations.ForName(rc.OperationName)
+	if op == nil {
+		return nil
+	}
+
+	if e.MaxDepth > 0 {
+		if depth := selectionDepth(op.SelectionSet); depth > e.MaxDepth {
+			return e.reject(ctx, rc, "depth", errDepthLimitCode, "operation has depth %d, which exceeds the limit of %d", depth, e.MaxDepth)
+		}
+	}
+
+	if e.MaxComplexity > 0 {
+		if cost := complexity.Calculate(e.schema, op, rc.Variables); cost > e.MaxComplexity {
+			return e.reject(ctx, rc, "complexity", errComplexityLimitCode, "operation has complexity %d, which exceeds the limit of %d; lower limit, perPage or first, or select fewer nested lists such as episodes", cost, e.MaxComplexity)
+		}
+	}
+
+	return nil
+}
+
+func (e *QueryLimitsExtension) reject(ctx context.Context, rc *graphql.OperationContext, reason string, code string, format string, args ...interface{}) *gqlerror.Error {
+	metrics.GetAppMetrics().QueryRejectedMetric(reason)
+
+	log := logger.FromCtx(ctx)
+	log.Warn().
+		Str("operation_name", rc.OperationName).
+		Str("reason", reason).
+		Msg("GraphQL operation rejected")
+
+	err := gqlerror.Errorf(format, args...)
+	errcode.Set(err, code)
+	return err
+}
+
+// selectionDepth counts the fields nested in a selection set. Fragments add no depth
+// of their own, and introspection fields such as __schema are not counted so that
+// schema tooling keeps working.
+func selectionDepth(selectionSet ast.SelectionSet) int {
+	depth := 0
+	for _, selection := range selectionSet {
+		var nested int
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(selection.Name, "__") {
+				continue
+			}
+			nested = 1 + selectionDepth(selection.SelectionSet)
+		case *ast.InlineFragment:
+			nested = selectionDepth(selection.SelectionSet)
+		case *ast.FragmentSpread:
+			if selection.Definition != nil {
+				nested = selectionDepth(selection.Definition.SelectionSet)
+			}
+		}
+		if nested > depth {
+			depth = nested
+		}
+	}
+	return depth
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/weeb-vip/anime-api/graph"
+	"github.com/weeb-vip/anime-api/graph/generated"
+)
+
+func TestQueryLimitsExtension(t *testing.T) {
+	schema := generated.NewExecutableSchema(generated.Config{
+		Resolvers:  &graph.Resolver{},
+		Complexity: graph.NewComplexityRoot(),
+	})
+	srv := handler.New(schema)
+	srv.AddTransport(transport.POST{})
+	srv.Use(extension.Introspection{})
+	srv.Use(&QueryLimitsExtension{MaxComplexity: 20000, MaxDepth: 10})
+
+	tests := []struct {
+		name     string
+		query    string
+		wantCode string
+	}{
+		{"within limits", `{ __typename }`, ""},
+		{"introspection is not counted", `{ __schema { types { fields { type { ofType { ofType { ofType { ofType { ofType { name } } } } } } } } } }`, ""},
+		{
+			"episodes under a large limit",
+			`{ currentlyAiring(limit: 1000) { id episodes { airTimes { streams { url } } } seasons { id } fanart { id } } }`,
+			errComplexityLimitCode,
+		},
+		{
+			"relations nested past the depth",
+			`query { anime(id: "1") { ...Related } } fragment Related on Anime { relations { anime { relations { anime { relations { anime { episodes { airTimes { streams { url } } } } } } } } } }`,
+			errDepthLimitCode,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(map[string]string{"query": tt.query})
+			req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			srv.ServeHTTP(rec, req)
+
+			var response struct {
+				Errors []struct {
+					Message    string                 `json:"message"`
+					Extensions map[string]interface{} `json:"extensions"`
+				} `json:"errors"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("unexpected response %s: %v", rec.Body.String(), err)
+			}
+
+			if tt.wantCode == "" {
+				if len(response.Errors) != 0 {
+					t.Errorf("expected no errors, got %+v", response.Errors)
+				}
+				return
+			}
+			if len(response.Errors) != 1 || response.Errors[0].Extensions["code"] != tt.wantCode {
+				t.Fatalf("expected %s, got %s", tt.wantCode, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/weeb-vip/anime-api/config"
+	"github.com/weeb-vip/anime-api/internal/auth"
+	"github.com/weeb-vip/anime-api/internal/ratelimit"
+)
+
+// recordingBuckets allows the first request of each client and records the clients seen
+type recordingBuckets struct {
+	seen []string
+}
+
+func (b *recordingBuckets) Take(ctx context.Context, client string, limit ratelimit.Limit) (ratelimit.Decision, error) {
+	for _, seen := range b.seen {
+		if seen == client {
+			b.seen = append(b.seen, client)
+			return ratelimit.Decision{RetryAfter: 1500 * time.Millisecond}, nil
+		}
+	}
+	b.seen = append(b.seen, client)
+	return ratelimit.Decision{Allowed: true}, nil
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	buckets := &recordingBuckets{}
+	conf := config.RateLimitConfig{Enabled: true, RequestsPerSecond: 1, Burst: 1, APIKeys: "mobile-key, web-key"}
+	handler := RateLimitMiddleware(buckets, conf)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	request := func(apiKey string, claims *auth.Claims) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+		req.RemoteAddr = "203.0.113.7:52100"
+		req.Header.Set("X-Forwarded-For", "198.51.100.1")
+		if apiKey != "" {
+			req.Header.Set("X-API-Key", apiKey)
+		}
+		if claims != nil {
+			req = req.WithContext(auth.WithClaims(req.Context(), claims))
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := request("", nil); rec.Code != http.StatusOK {
+		t.Fatalf("first request: status %d", rec.Code)
+	}
+	rec := request("", nil)
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != "2" {
+		t.Errorf("second request: status %d, Retry-After %q", rec.Code, rec.Header().Get("Retry-After"))
+	}
+
+	// a known API key and a token subject get their own buckets; an unknown key does not
+	if rec := request("mobile-key", nil); rec.Code != http.StatusOK {
+		t.Errorf("API key request: status %d", rec.Code)
+	}
+	if rec := request("", &auth.Claims{Subject: "user-1"}); rec.Code != http.StatusOK {
+		t.Errorf("subject request: status %d", rec.Code)
+	}
+	if rec := request("made-up-key", nil); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("unknown API key request: status %d", rec.Code)
+	}
+
+	if buckets.seen[0] != "ip:203.0.113.7" || buckets.seen[3] != "sub:user-1" {
+		t.Errorf("unexpected clients: %v", buckets.seen)
+	}
+	if key := buckets.seen[2]; key == "key:mobile-key" || len(key) != len("key:")+64 {
+		t.Errorf("expected the API key to be hashed, got %s", key)
+	}
+}
+
+func TestRateLimitClientIP(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/calendar.ics", nil)
+	req.RemoteAddr = "10.0.0.5:443"
+	req.Header.Set("X-Forwarded-For", "198.51.100.1, 10.0.0.2")
+
+	if ip := clientIP(req, false); ip != "10.0.0.5" {
+		t.Errorf("untrusted: got %s", ip)
+	}
+	if ip := clientIP(req, true); ip != "198.51.100.1" {
+		t.Errorf("trusted: got %s", ip)
+	}
+}
@@ -1,6 +1,9 @@
 package middleware
 
 import (
+	"bufio"
+	"fmt"
+	"net"
 	"net/http"
 
 	"github.com/weeb-vip/anime-api/internal/logger"
@@ -86,4 +89,14 @@ func (rw *responseWrapper) WriteHeader(code int) {
 
 func (rw *responseWrapper) Write(b []byte) (int, error) {
 	return rw.ResponseWriter.Write(b)
+}
+
+// Hijack hands the connection over for WebSocket upgrades
+func (rw *responseWrapper) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := rw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	rw.statusCode = http.StatusSwitchingProtocols
+	return hijacker.Hijack()
 }
\ No newline at end of file
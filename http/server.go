@@ -2,53 +2,86 @@ package http
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/weeb-vip/anime-api/config"
 	"github.com/weeb-vip/anime-api/http/handlers"
 	"github.com/weeb-vip/anime-api/http/middleware"
+	"github.com/weeb-vip/anime-api/internal/auth"
 	"github.com/weeb-vip/anime-api/internal/logger"
 	"github.com/weeb-vip/anime-api/metrics"
+	"github.com/weeb-vip/anime-api/tracing"
 	muxtrace "gopkg.in/DataDog/dd-trace-go.v1/contrib/gorilla/mux"
-	"net/http"
 )
 
-func SetupServer(cfg config.Config) *muxtrace.Router {
-
-	router := muxtrace.NewRouter()
-
-	// Add gzip compression middleware
-	router.Use(middleware.GzipMiddleware())
-
-	router.Handle("/ui/playground", playground.Handler("GraphQL playground", "/graphql")).Methods("GET")
-	router.Handle("/graphql", handlers.BuildRootHandler(cfg)).Methods("POST")
-	router.Handle("/healthcheck", handlers.HealthCheckHandler()).Methods("GET")
-	router.Handle("/metrics", metrics.NewPrometheusInstance().Handler()).Methods("GET")
+// SetupServer registers the same routes as SetupServerWithContext, with background
+// work running for the life of the process
+func SetupServer(cfg config.Config) (*muxtrace.Router, error) {
+	return SetupServerWithContext(context.Background(), cfg)
+}
 
-	return router
+func SetupServerWithContext(ctx context.Context, cfg config.Config) (*muxtrace.Router, error) {
+	router, _, err := setupServer(ctx, cfg)
+	return router, err
 }
 
-func SetupServerWithContext(ctx context.Context, cfg config.Config) *muxtrace.Router {
+// setupServer also returns the root handlers, whose connections are closed on shutdown
+func setupServer(ctx context.Context, cfg config.Config) (*muxtrace.Router, handlers.RootHandlers, error) {
 
 	router := muxtrace.NewRouter(muxtrace.WithServiceName(cfg.AppConfig.APPName))
 
 	// Add middleware to all routes
 	router.Use(middleware.GzipMiddleware())
 	router.Use(middleware.TracingMiddleware())
+	router.Use(middleware.AuthMiddleware(buildVerifier(ctx, cfg)))
 
 	router.Handle("/ui/playground", playground.Handler("GraphQL playground", "/graphql")).Methods("GET")
-	router.Handle("/graphql", handlers.BuildRootHandlerWithContext(ctx, cfg)).Methods("POST")
+	rootHandlers, err := handlers.BuildRootHandlersWithContext(ctx, cfg)
+	if err != nil {
+		return nil, handlers.RootHandlers{}, err
+	}
+	// GET upgrades to a graphql-ws WebSocket for subscriptions
+	router.Handle("/graphql", rootHandlers.GraphQL).Methods("POST", "GET")
+	router.Handle("/calendar.ics", rootHandlers.Calendar).Methods("GET")
 	router.Handle("/healthcheck", handlers.HealthCheckHandler()).Methods("GET")
+	router.Handle("/livez", handlers.HealthCheckHandler()).Methods("GET")
+	router.Handle("/readyz", rootHandlers.Health.ReadinessHandler()).Methods("GET")
 	router.Handle("/metrics", metrics.NewPrometheusInstance().Handler()).Methods("GET")
 
-	return router
+	return router, rootHandlers, nil
+}
+
+// buildVerifier loads the token verifier; without one, @scoped fields are rejected for every request
+func buildVerifier(ctx context.Context, cfg config.Config) *auth.Verifier {
+	log := logger.FromCtx(ctx)
+	verifier, err := auth.NewVerifier(cfg.AuthConfig)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to load JWT verification keys, scoped fields will be unavailable")
+		return nil
+	}
+	if verifier == nil {
+		log.Warn().Msg("No JWT secret or JWKS file configured, scoped fields will be unavailable")
+	}
+	return verifier
 }
 
 func StartServer() error {
 	cfg := config.LoadConfigOrPanic()
-	router := SetupServer(cfg)
-
 	log := logger.Get()
+	router, err := SetupServer(cfg)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to set up GraphQL server")
+		return err
+	}
+
 	log.Info().
 		Int("port", cfg.AppConfig.Port).
 		Str("playground_url", fmt.Sprintf("http://localhost:%d/", cfg.AppConfig.Port)).
@@ -57,15 +90,82 @@ func StartServer() error {
 	return http.ListenAndServe(fmt.Sprintf(":%d", cfg.AppConfig.Port), router)
 }
 
+// StartServerWithContext serves until SIGINT or SIGTERM, then shuts down gracefully:
+// readiness fails so load balancers stop sending traffic, in-flight requests are
+// drained, and the cache and database connections and the tracer are closed.
 func StartServerWithContext(ctx context.Context) error {
 	cfg := config.LoadConfigOrPanic()
-	router := SetupServerWithContext(ctx, cfg)
-
 	log := logger.FromCtx(ctx)
-	log.Info().
-		Int("port", cfg.AppConfig.Port).
-		Str("playground_url", fmt.Sprintf("http://localhost:%d/", cfg.AppConfig.Port)).
-		Msg("Starting GraphQL server")
 
-	return http.ListenAndServe(fmt.Sprintf(":%d", cfg.AppConfig.Port), router)
+	// Background work such as the search indexer and airing scheduler keeps running
+	// until requests have drained
+	workerCtx, stopWorkers := context.WithCancel(ctx)
+	defer stopWorkers()
+	router, rootHandlers, err := setupServer(workerCtx, cfg)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to set up GraphQL server")
+		return err
+	}
+
+	// Subscriptions are hijacked connections that Shutdown does not wait for, so
+	// they are ended by cancelling the context of every request once it returns
+	requestCtx, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
+
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.AppConfig.Port),
+		Handler:           router,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext: func(net.Listener) context.Context {
+			return requestCtx
+		},
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		log.Info().
+			Int("port", cfg.AppConfig.Port).
+			Str("playground_url", fmt.Sprintf("http://localhost:%d/", cfg.AppConfig.Port)).
+			Msg("Starting GraphQL server")
+		serverErr <- server.ListenAndServe()
+	}()
+
+	signalCtx, stopSignals := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+
+	select {
+	case err := <-serverErr:
+		if closeErr := rootHandlers.Close(); closeErr != nil {
+			log.Error().Err(closeErr).Msg("Failed to close connections")
+		}
+		return err
+	case <-signalCtx.Done():
+	}
+	// A second signal stops the process without waiting
+	stopSignals()
+
+	log.Info().Msg("Shutting down GraphQL server")
+	rootHandlers.Health.Drain()
+	time.Sleep(time.Duration(cfg.AppConfig.ShutdownDrainSeconds) * time.Second)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.AppConfig.ShutdownTimeoutSeconds)*time.Second)
+	defer cancel()
+
+	var shutdownErr error
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Error().Err(err).Msg("In-flight requests did not finish in time, closing connections")
+		shutdownErr = errors.Join(err, server.Close())
+	}
+	cancelRequests()
+	stopWorkers()
+
+	if err := rootHandlers.Close(); err != nil {
+		log.Error().Err(err).Msg("Failed to close connections")
+	}
+	if err := tracing.Shutdown(shutdownCtx); err != nil {
+		log.Error().Err(err).Msg("Error shutting down tracing")
+	}
+
+	log.Info().Msg("GraphQL server stopped")
+	return shutdownErr
 }
@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+type ctxKey struct{}
+
+// Claims are the verified claims of a bearer token
+type Claims struct {
+	Subject   string
+	Issuer    string
+	Audience  []string
+	ExpiresAt *time.Time
+	NotBefore *time.Time
+	Scopes    []string
+}
+
+// HasScope reports whether the token was granted scope
+func (c *Claims) HasScope(scope string) bool {
+	if c == nil {
+		return false
+	}
+	for _, granted := range c.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// WithClaims stores verified claims on the context
+func WithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, ctxKey{}, claims)
+}
+
+// ClaimsFromContext returns the request's claims, or nil for anonymous requests
+func ClaimsFromContext(ctx context.Context) *Claims {
+	claims, _ := ctx.Value(ctxKey{}).(*Claims)
+	return claims
+}
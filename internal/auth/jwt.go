@@ -0,0 +1,270 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/weeb-vip/anime-api/config"
+)
+
+// clockSkew is how far exp and nbf may be off before a token is rejected
+const clockSkew = 30 * time.Second
+
+var (
+	ErrMalformedToken   = errors.New("malformed token")
+	ErrUnsupportedAlg   = errors.New("unsupported signing algorithm")
+	ErrUnknownKey       = errors.New("unknown signing key")
+	ErrInvalidSignature = errors.New("invalid token signature")
+	ErrTokenExpired     = errors.New("token is expired")
+	ErrMissingExpiry    = errors.New("token has no expiry")
+	ErrTokenNotYetValid = errors.New("token is not valid yet")
+	ErrInvalidIssuer    = errors.New("invalid token issuer")
+	ErrInvalidAudience  = errors.New("invalid token audience")
+)
+
+// Verifier checks HS256 tokens against a shared secret and RS256 tokens against
+// the keys of a JWKS file
+type Verifier struct {
+	secret   []byte
+	keys     map[string]*rsa.PublicKey
+	issuer   string
+	audience string
+	now      func() time.Time
+}
+
+type tokenHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type tokenClaims struct {
+	Subject   string          `json:"sub"`
+	Issuer    string          `json:"iss"`
+	Audience  json.RawMessage `json:"aud"`
+	ExpiresAt *float64        `json:"exp"`
+	NotBefore *float64        `json:"nbf"`
+	Scope     string          `json:"scope"`
+	Scopes    []string        `json:"scopes"`
+}
+
+type jwks struct {
+	Keys []struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Use string `json:"use"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	} `json:"keys"`
+}
+
+// NewVerifier builds a verifier from config. It returns nil without an error when
+// neither a secret nor a JWKS file is configured, meaning tokens cannot be verified.
+func NewVerifier(conf config.AuthConfig) (*Verifier, error) {
+	if conf.JWTSecret == "" && conf.JWKSFile == "" {
+		return nil, nil
+	}
+
+	verifier := &Verifier{
+		issuer:   conf.Issuer,
+		audience: conf.Audience,
+		now:      time.Now,
+	}
+	if conf.JWTSecret != "" {
+		verifier.secret = []byte(conf.JWTSecret)
+	}
+	if conf.JWKSFile != "" {
+		data, err := os.ReadFile(conf.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+		}
+		keys, err := parseJWKS(data)
+		if err != nil {
+			return nil, err
+		}
+		verifier.keys = keys
+	}
+	return verifier, nil
+}
+
+func parseJWKS(data []byte) (map[string]*rsa.PublicKey, error) {
+	var set jwks
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, key := range set.Keys {
+		if key.Kty != "RSA" || (key.Use != "" && key.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(key.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus for key %q: %w", key.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(key.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent for key %q: %w", key.Kid, err)
+		}
+		keys[key.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("JWKS contains no RSA signing keys")
+	}
+	return keys, nil
+}
+
+// Verify checks the token's signature and registered claims and returns its claims
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformedToken
+	}
+
+	var header tokenHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, ErrMalformedToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	signed := []byte(parts[0] + "." + parts[1])
+	if err := v.verifySignature(header, signed, signature); err != nil {
+		return nil, err
+	}
+
+	var raw tokenClaims
+	if err := decodeSegment(parts[1], &raw); err != nil {
+		return nil, ErrMalformedToken
+	}
+	claims, err := raw.toClaims()
+	if err != nil {
+		return nil, err
+	}
+	if err := v.validate(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+func (v *Verifier) verifySignature(header tokenHeader, signed, signature []byte) error {
+	switch header.Alg {
+	case "HS256":
+		if v.secret == nil {
+			return ErrUnsupportedAlg
+		}
+		mac := hmac.New(sha256.New, v.secret)
+		mac.Write(signed)
+		if !hmac.Equal(mac.Sum(nil), signature) {
+			return ErrInvalidSignature
+		}
+		return nil
+	case "RS256":
+		key, err := v.rsaKey(header.Kid)
+		if err != nil {
+			return err
+		}
+		digest := sha256.Sum256(signed)
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return ErrInvalidSignature
+		}
+		return nil
+	default:
+		return ErrUnsupportedAlg
+	}
+}
+
+func (v *Verifier) rsaKey(kid string) (*rsa.PublicKey, error) {
+	if len(v.keys) == 0 {
+		return nil, ErrUnsupportedAlg
+	}
+	if key, ok := v.keys[kid]; ok {
+		return key, nil
+	}
+	// Tokens without a kid are accepted when the JWKS has a single key
+	if kid == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key, nil
+		}
+	}
+	return nil, ErrUnknownKey
+}
+
+func (v *Verifier) validate(claims *Claims) error {
+	now := v.now()
+	// A token without exp would be accepted forever
+	if claims.ExpiresAt == nil {
+		return ErrMissingExpiry
+	}
+	if now.After(claims.ExpiresAt.Add(clockSkew)) {
+		return ErrTokenExpired
+	}
+	if claims.NotBefore != nil && now.Add(clockSkew).Before(*claims.NotBefore) {
+		return ErrTokenNotYetValid
+	}
+	if v.issuer != "" && claims.Issuer != v.issuer {
+		return ErrInvalidIssuer
+	}
+	if v.audience != "" {
+		for _, audience := range claims.Audience {
+			if audience == v.audience {
+				return nil
+			}
+		}
+		return ErrInvalidAudience
+	}
+	return nil
+}
+
+func (c tokenClaims) toClaims() (*Claims, error) {
+	claims := &Claims{
+		Subject: c.Subject,
+		Issuer:  c.Issuer,
+		Scopes:  append(strings.Fields(c.Scope), c.Scopes...),
+	}
+	if c.ExpiresAt != nil {
+		expiresAt := unixTime(*c.ExpiresAt)
+		claims.ExpiresAt = &expiresAt
+	}
+	if c.NotBefore != nil {
+		notBefore := unixTime(*c.NotBefore)
+		claims.NotBefore = &notBefore
+	}
+
+	// aud may be a single string or a list of strings
+	if len(c.Audience) > 0 && string(c.Audience) != "null" {
+		var audience string
+		if err := json.Unmarshal(c.Audience, &audience); err == nil {
+			claims.Audience = []string{audience}
+		} else if err := json.Unmarshal(c.Audience, &claims.Audience); err != nil {
+			return nil, ErrMalformedToken
+		}
+	}
+	return claims, nil
+}
+
+func decodeSegment(segment string, dest interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dest)
+}
+
+func unixTime(seconds float64) time.Time {
+	return time.Unix(int64(seconds), 0).UTC()
+}
@@ -0,0 +1,214 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/weeb-vip/anime-api/config"
+)
+
+var testNow = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func encodeSegment(t *testing.T, v interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to marshal segment: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func signHS256(t *testing.T, secret string, header, claims map[string]interface{}) string {
+	t.Helper()
+	signed := encodeSegment(t, header) + "." + encodeSegment(t, claims)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	t.Helper()
+	signed := encodeSegment(t, map[string]interface{}{"alg": "RS256", "kid": kid}) + "." + encodeSegment(t, claims)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func newTestVerifier(t *testing.T, conf config.AuthConfig) *Verifier {
+	t.Helper()
+	verifier, err := NewVerifier(conf)
+	if err != nil {
+		t.Fatalf("NewVerifier returned error: %v", err)
+	}
+	verifier.now = func() time.Time { return testNow }
+	return verifier
+}
+
+func TestVerifyHS256(t *testing.T) {
+	verifier := newTestVerifier(t, config.AuthConfig{JWTSecret: "secret", Issuer: "weeb-auth", Audience: "anime-api"})
+	header := map[string]interface{}{"alg": "HS256", "typ": "JWT"}
+	validClaims := func() map[string]interface{} {
+		return map[string]interface{}{
+			"sub":   "user-1",
+			"iss":   "weeb-auth",
+			"aud":   []string{"anime-api", "user-api"},
+			"exp":   testNow.Add(time.Hour).Unix(),
+			"scope": "anime:write tags:write",
+		}
+	}
+
+	tests := []struct {
+		name    string
+		token   func() string
+		wantErr error
+	}{
+		{
+			name:  "valid token",
+			token: func() string { return signHS256(t, "secret", header, validClaims()) },
+		},
+		{
+			name:    "wrong secret",
+			token:   func() string { return signHS256(t, "other", header, validClaims()) },
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name: "expired",
+			token: func() string {
+				claims := validClaims()
+				claims["exp"] = testNow.Add(-time.Hour).Unix()
+				return signHS256(t, "secret", header, claims)
+			},
+			wantErr: ErrTokenExpired,
+		},
+		{
+			name: "no expiry",
+			token: func() string {
+				claims := validClaims()
+				delete(claims, "exp")
+				return signHS256(t, "secret", header, claims)
+			},
+			wantErr: ErrMissingExpiry,
+		},
+		{
+			name: "not yet valid",
+			token: func() string {
+				claims := validClaims()
+				claims["nbf"] = testNow.Add(time.Hour).Unix()
+				return signHS256(t, "secret", header, claims)
+			},
+			wantErr: ErrTokenNotYetValid,
+		},
+		{
+			name: "wrong issuer",
+			token: func() string {
+				claims := validClaims()
+				claims["iss"] = "someone-else"
+				return signHS256(t, "secret", header, claims)
+			},
+			wantErr: ErrInvalidIssuer,
+		},
+		{
+			name: "wrong audience",
+			token: func() string {
+				claims := validClaims()
+				claims["aud"] = "user-api"
+				return signHS256(t, "secret", header, claims)
+			},
+			wantErr: ErrInvalidAudience,
+		},
+		{
+			name: "alg none",
+			token: func() string {
+				return encodeSegment(t, map[string]interface{}{"alg": "none"}) + "." + encodeSegment(t, validClaims()) + "."
+			},
+			wantErr: ErrUnsupportedAlg,
+		},
+		{
+			name:    "malformed",
+			token:   func() string { return "not-a-token" },
+			wantErr: ErrMalformedToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := verifier.Verify(tt.token())
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify returned error: %v", err)
+			}
+			if claims.Subject != "user-1" {
+				t.Errorf("Expected subject user-1, got %q", claims.Subject)
+			}
+			if !claims.HasScope("anime:write") || !claims.HasScope("tags:write") || claims.HasScope("admin") {
+				t.Errorf("Unexpected scopes: %v", claims.Scopes)
+			}
+		})
+	}
+}
+
+func TestVerifyRS256WithJWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	jwksJSON, _ := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "key-1",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	})
+	if err := os.WriteFile(jwksFile, jwksJSON, 0o600); err != nil {
+		t.Fatalf("failed to write JWKS: %v", err)
+	}
+
+	verifier := newTestVerifier(t, config.AuthConfig{JWKSFile: jwksFile})
+	claims := map[string]interface{}{"sub": "service", "scopes": []string{"admin"}, "exp": testNow.Add(time.Minute).Unix()}
+
+	verified, err := verifier.Verify(signRS256(t, key, "key-1", claims))
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+	if !verified.HasScope("admin") {
+		t.Errorf("Expected admin scope, got %v", verified.Scopes)
+	}
+
+	if _, err := verifier.Verify(signRS256(t, key, "key-2", claims)); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("Expected ErrUnknownKey for unknown kid, got %v", err)
+	}
+
+	// HS256 is not accepted when only a JWKS is configured
+	if _, err := verifier.Verify(signHS256(t, "secret", map[string]interface{}{"alg": "HS256"}, claims)); !errors.Is(err, ErrUnsupportedAlg) {
+		t.Errorf("Expected ErrUnsupportedAlg for HS256, got %v", err)
+	}
+}
+
+func TestNewVerifierWithoutKeys(t *testing.T) {
+	verifier, err := NewVerifier(config.AuthConfig{})
+	if err != nil || verifier != nil {
+		t.Errorf("Expected nil verifier without error, got %v, %v", verifier, err)
+	}
+}
@@ -0,0 +1,253 @@
+// Package broadcast parses the broadcast column of anime, the weekly slot an anime
+// airs in as catalogues write it: "Saturdays at 01:30 (JST)", "Fridays at 25:30
+// (JST)", "Sundays 17:00 KST", "Unknown".
+package broadcast
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	// ErrNotScheduled is returned for values saying there is no slot, such as
+	// "Unknown" or "Not scheduled once per week"
+	ErrNotScheduled = errors.New("broadcast is not scheduled")
+	// ErrUnknownZone is returned when the zone after the time cannot be mapped to a location
+	ErrUnknownZone = errors.New("unknown broadcast timezone")
+)
+
+const (
+	// maxHour allows the Japanese convention of counting late-night hours on from
+	// the previous day, up to 29:59
+	maxHour = 29
+	// defaultZone is assumed when no zone is given, since catalogue slots are Japanese
+	defaultZone = "Asia/Tokyo"
+)
+
+var (
+	clockPattern  = regexp.MustCompile(`(\d{1,2})\s*[:：時]\s*(\d{2})`)
+	offsetPattern = regexp.MustCompile(`^(?:UTC|GMT)?([+-])(\d{1,2})(?::?(\d{2}))?$`)
+	// abbreviationPattern matches what looks like a zone abbreviation, so unknown
+	// ones are reported rather than read as Japan time
+	abbreviationPattern = regexp.MustCompile(`^[A-Z]{2,5}$`)
+)
+
+// notScheduled are values, lower cased, that mean there is no weekly slot
+var notScheduled = map[string]bool{"": true, "unknown": true, "n/a": true, "none": true, "tba": true, "tbd": true, "-": true, "?": true}
+
+// zoneAbbreviations maps the zone abbreviations catalogues use to IANA locations.
+// Where an abbreviation is ambiguous the East Asian reading wins: CST is China, not
+// US Central, and IST is India.
+var zoneAbbreviations = map[string]string{
+	"JST":  "Asia/Tokyo",
+	"KST":  "Asia/Seoul",
+	"CST":  "Asia/Shanghai",
+	"HKT":  "Asia/Hong_Kong",
+	"TWT":  "Asia/Taipei",
+	"SGT":  "Asia/Singapore",
+	"PHT":  "Asia/Manila",
+	"PHST": "Asia/Manila",
+	"ICT":  "Asia/Bangkok",
+	"WIB":  "Asia/Jakarta",
+	"IST":  "Asia/Kolkata",
+	"UTC":  "UTC",
+	"GMT":  "UTC",
+	"Z":    "UTC",
+	"BST":  "Europe/London",
+	"WET":  "Europe/Lisbon",
+	"CET":  "Europe/Berlin",
+	"CEST": "Europe/Berlin",
+	"EET":  "Europe/Helsinki",
+	"MSK":  "Europe/Moscow",
+	"ET":   "America/New_York",
+	"EST":  "America/New_York",
+	"EDT":  "America/New_York",
+	"CT":   "America/Chicago",
+	"CDT":  "America/Chicago",
+	"MT":   "America/Denver",
+	"MST":  "America/Denver",
+	"MDT":  "America/Denver",
+	"PT":   "America/Los_Angeles",
+	"PST":  "America/Los_Angeles",
+	"PDT":  "America/Los_Angeles",
+	"AEST": "Australia/Sydney",
+	"AEDT": "Australia/Sydney",
+	"NZST": "Pacific/Auckland",
+	"NZDT": "Pacific/Auckland",
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// kanjiWeekdays are the day characters written before 曜 in Japanese
+var kanjiWeekdays = map[rune]time.Weekday{
+	'日': time.Sunday,
+	'月': time.Monday,
+	'火': time.Tuesday,
+	'水': time.Wednesday,
+	'木': time.Thursday,
+	'金': time.Friday,
+	'土': time.Saturday,
+}
+
+// Broadcast is a weekly slot
+type Broadcast struct {
+	// Weekday is the day the slot is listed under, or nil when only a time is given
+	Weekday *time.Weekday
+	// Hour runs past 23 for late-night slots listed under the previous day:
+	// "Fridays at 25:30" airs at 01:30 on Saturday
+	Hour     int
+	Minute   int
+	Location *time.Location
+}
+
+// Parse reads a broadcast value. It returns ErrNotScheduled for values saying there
+// is no slot, ErrUnknownZone when the zone cannot be mapped, and an error naming the
+// problem for anything else it cannot read.
+func Parse(value string) (Broadcast, error) {
+	value = strings.TrimSpace(value)
+	lower := strings.ToLower(value)
+	if notScheduled[lower] || strings.HasPrefix(lower, "not scheduled") {
+		return Broadcast{}, ErrNotScheduled
+	}
+
+	clock := clockPattern.FindStringSubmatchIndex(value)
+	if clock == nil {
+		if strings.Contains(lower, "unknown") {
+			return Broadcast{}, ErrNotScheduled
+		}
+		return Broadcast{}, fmt.Errorf("broadcast %q has no HH:MM time", value)
+	}
+	hour, _ := strconv.Atoi(value[clock[2]:clock[3]])
+	minute, _ := strconv.Atoi(value[clock[4]:clock[5]])
+	if hour > maxHour || minute > 59 {
+		return Broadcast{}, fmt.Errorf("broadcast %q has an invalid time", value)
+	}
+
+	location, err := parseZone(value[clock[1]:])
+	if err != nil {
+		return Broadcast{}, fmt.Errorf("broadcast %q: %w", value, err)
+	}
+
+	return Broadcast{
+		Weekday:  parseWeekday(value[:clock[0]]),
+		Hour:     hour,
+		Minute:   minute,
+		Location: location,
+	}, nil
+}
+
+// On returns when the slot airs on date, the day it is listed under. Only the
+// calendar date of date is used.
+func (b Broadcast) On(date time.Time) time.Time {
+	year, month, day := date.Date()
+	return time.Date(year, month, day, b.Hour, b.Minute, 0, 0, b.Location)
+}
+
+// AirsOn returns the day the slot airs once late-night hours roll over, or nil when
+// no day is given
+func (b Broadcast) AirsOn() *time.Weekday {
+	if b.Weekday == nil {
+		return nil
+	}
+	weekday := (*b.Weekday + time.Weekday(b.Hour/24)) % 7
+	return &weekday
+}
+
+// LocalTime returns the wall clock time the slot airs at, as HH:MM, with
+// late-night hours rolled over
+func (b Broadcast) LocalTime() string {
+	return fmt.Sprintf("%02d:%02d", b.Hour%24, b.Minute)
+}
+
+// parseWeekday finds the day name in the text before the time: "Saturdays at",
+// "Every Sat", "毎週土曜"
+func parseWeekday(text string) *time.Weekday {
+	runes := []rune(text)
+	for i, r := range runes {
+		if r == '曜' && i > 0 {
+			if weekday, ok := kanjiWeekdays[runes[i-1]]; ok {
+				return &weekday
+			}
+		}
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !unicode.IsLetter(r) })
+	for _, word := range words {
+		word = strings.TrimSuffix(word, "s")
+		if len(word) < 3 {
+			continue
+		}
+		for name, weekday := range weekdayNames {
+			if strings.HasPrefix(name, word) {
+				return &weekday
+			}
+		}
+	}
+	return nil
+}
+
+// parseZone reads the zone following the time: "(JST)", "JST", "(UTC+9)",
+// "GMT+09:00" or an IANA name such as "(Asia/Tokyo)". No zone means Japan.
+func parseZone(text string) (*time.Location, error) {
+	text = strings.TrimSpace(strings.TrimPrefix(text, "分"))
+	if strings.HasPrefix(text, "(") {
+		if end := strings.Index(text, ")"); end > 0 {
+			text = text[1:end]
+		}
+	}
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return time.LoadLocation(defaultZone)
+	}
+	zone := strings.Trim(fields[0], "()[],.")
+	if len(fields) > 1 && (fields[1][0] == '+' || fields[1][0] == '-') {
+		// "UTC +9"
+		zone += fields[1]
+	}
+	if zone == "" {
+		return time.LoadLocation(defaultZone)
+	}
+
+	if strings.Contains(zone, "/") {
+		location, err := time.LoadLocation(zone)
+		if err != nil {
+			return nil, fmt.Errorf("%w %s", ErrUnknownZone, zone)
+		}
+		return location, nil
+	}
+
+	upper := strings.ToUpper(zone)
+	if match := offsetPattern.FindStringSubmatch(upper); match != nil {
+		hours, _ := strconv.Atoi(match[2])
+		minutes := 0
+		if match[3] != "" {
+			minutes, _ = strconv.Atoi(match[3])
+		}
+		offset := hours*60*60 + minutes*60
+		if match[1] == "-" {
+			offset = -offset
+		}
+		return time.FixedZone(upper, offset), nil
+	}
+	if name, ok := zoneAbbreviations[upper]; ok {
+		return time.LoadLocation(name)
+	}
+	if abbreviationPattern.MatchString(zone) {
+		return nil, fmt.Errorf("%w %s", ErrUnknownZone, zone)
+	}
+	// words such as "on TV Tokyo" name a channel, not a zone
+	return time.LoadLocation(defaultZone)
+}
@@ -0,0 +1,124 @@
+package broadcast
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func weekdayPtr(weekday time.Weekday) *time.Weekday {
+	return &weekday
+}
+
+// TestParse runs broadcast values as they appear in the catalogue
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value    string
+		weekday  *time.Weekday
+		hour     int
+		minute   int
+		location string
+		airsOn   *time.Weekday
+		local    string
+	}{
+		{"Saturdays at 01:30 (JST)", weekdayPtr(time.Saturday), 1, 30, "Asia/Tokyo", weekdayPtr(time.Saturday), "01:30"},
+		{"Wednesdays at 01:29 (JST)", weekdayPtr(time.Wednesday), 1, 29, "Asia/Tokyo", weekdayPtr(time.Wednesday), "01:29"},
+		{"Fridays at 23:00 (JST)", weekdayPtr(time.Friday), 23, 0, "Asia/Tokyo", weekdayPtr(time.Friday), "23:00"},
+		{"Fridays at 25:30 (JST)", weekdayPtr(time.Friday), 25, 30, "Asia/Tokyo", weekdayPtr(time.Saturday), "01:30"},
+		{"Saturdays at 24:00 (JST)", weekdayPtr(time.Saturday), 24, 0, "Asia/Tokyo", weekdayPtr(time.Sunday), "00:00"},
+		{"Sundays at 17:00 (JST)", weekdayPtr(time.Sunday), 17, 0, "Asia/Tokyo", weekdayPtr(time.Sunday), "17:00"},
+		{"Saturday at 18:00 (JST)", weekdayPtr(time.Saturday), 18, 0, "Asia/Tokyo", weekdayPtr(time.Saturday), "18:00"},
+		{"Thursdays at 9:30 (JST)", weekdayPtr(time.Thursday), 9, 30, "Asia/Tokyo", weekdayPtr(time.Thursday), "09:30"},
+		{"Fridays at 10:30 (UTC)", weekdayPtr(time.Friday), 10, 30, "UTC", weekdayPtr(time.Friday), "10:30"},
+		{"Mondays 00:00 JST", weekdayPtr(time.Monday), 0, 0, "Asia/Tokyo", weekdayPtr(time.Monday), "00:00"},
+		{"Every Sun 18:30 KST", weekdayPtr(time.Sunday), 18, 30, "Asia/Seoul", weekdayPtr(time.Sunday), "18:30"},
+		{"Thurs. at 20:00 (CST)", weekdayPtr(time.Thursday), 20, 0, "Asia/Shanghai", weekdayPtr(time.Thursday), "20:00"},
+		{"Tuesdays at 12:00 (PST)", weekdayPtr(time.Tuesday), 12, 0, "America/Los_Angeles", weekdayPtr(time.Tuesday), "12:00"},
+		{"Fridays at 09:00 (Asia/Tokyo)", weekdayPtr(time.Friday), 9, 0, "Asia/Tokyo", weekdayPtr(time.Friday), "09:00"},
+		{"Saturdays at 16:30 (UTC+9)", weekdayPtr(time.Saturday), 16, 30, "UTC+9", weekdayPtr(time.Saturday), "16:30"},
+		{"Sundays at 08:00 GMT+09:00", weekdayPtr(time.Sunday), 8, 0, "GMT+09:00", weekdayPtr(time.Sunday), "08:00"},
+		{"Saturdays at 17:30 (JST) on MBS", weekdayPtr(time.Saturday), 17, 30, "Asia/Tokyo", weekdayPtr(time.Saturday), "17:30"},
+		{"Saturdays at 17:30 on TV Tokyo", weekdayPtr(time.Saturday), 17, 30, "Asia/Tokyo", weekdayPtr(time.Saturday), "17:30"},
+		{"毎週金曜 25:23", weekdayPtr(time.Friday), 25, 23, "Asia/Tokyo", weekdayPtr(time.Saturday), "01:23"},
+		{"土曜日 23時30分", weekdayPtr(time.Saturday), 23, 30, "Asia/Tokyo", weekdayPtr(time.Saturday), "23:30"},
+		{"22:00", nil, 22, 0, "Asia/Tokyo", nil, "22:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := Parse(tt.value)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if (got.Weekday == nil) != (tt.weekday == nil) || (got.Weekday != nil && *got.Weekday != *tt.weekday) {
+				t.Errorf("weekday = %v, want %v", got.Weekday, tt.weekday)
+			}
+			if got.Hour != tt.hour || got.Minute != tt.minute {
+				t.Errorf("time = %d:%02d, want %d:%02d", got.Hour, got.Minute, tt.hour, tt.minute)
+			}
+			if got.Location.String() != tt.location {
+				t.Errorf("location = %s, want %s", got.Location, tt.location)
+			}
+			if airsOn := got.AirsOn(); (airsOn == nil) != (tt.airsOn == nil) || (airsOn != nil && *airsOn != *tt.airsOn) {
+				t.Errorf("airs on %v, want %v", airsOn, tt.airsOn)
+			}
+			if got.LocalTime() != tt.local {
+				t.Errorf("local time = %s, want %s", got.LocalTime(), tt.local)
+			}
+		})
+	}
+}
+
+func TestParseRejects(t *testing.T) {
+	tests := []struct {
+		value string
+		err   error
+	}{
+		{"Unknown", ErrNotScheduled},
+		{"", ErrNotScheduled},
+		{"Not scheduled once per week", ErrNotScheduled},
+		{"Sundays at Unknown", ErrNotScheduled},
+		{"N/A", ErrNotScheduled},
+		{"Fridays at 23:00 (XYZ)", ErrUnknownZone},
+		{"Fridays at 23:00 (Mars/Olympus)", ErrUnknownZone},
+		{"Fridays at night", nil},
+		{"Fridays at 31:00 (JST)", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			_, err := Parse(tt.value)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Errorf("error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestBroadcastOn(t *testing.T) {
+	airDate := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	late, err := Parse("Fridays at 25:30 (JST)")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := late.On(airDate).UTC(), time.Date(2024, 1, 5, 16, 30, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("late-night slot airs at %v, want %v", got, want)
+	}
+
+	// Los Angeles is on daylight time in July, eight hours behind in January
+	pacific, err := Parse("Fridays at 12:00 (PT)")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := pacific.On(airDate).UTC(), time.Date(2024, 1, 5, 20, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("winter slot airs at %v, want %v", got, want)
+	}
+	summer := time.Date(2024, 7, 5, 0, 0, 0, 0, time.UTC)
+	if got, want := pacific.On(summer).UTC(), time.Date(2024, 7, 5, 19, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("summer slot airs at %v, want %v", got, want)
+	}
+}
@@ -121,6 +121,36 @@ func (c *CacheKeyBuilder) CurrentlyAiringPattern() string {
 	return c.prefix + ":currently-airing*"
 }
 
+// SearchSuggestions builds cache key for title suggestions of a normalized prefix
+func (c *CacheKeyBuilder) SearchSuggestions(prefix string, limit int) string {
+	return c.prefix + ":search-suggestions:limit:" + fmt.Sprintf("%d", limit) + ":" + prefix
+}
+
+// SearchSuggestionsPattern builds pattern for all search suggestion cache keys
+func (c *CacheKeyBuilder) SearchSuggestionsPattern() string {
+	return c.prefix + ":search-suggestions*"
+}
+
+// SharedCast builds cache key for the anime sharing cast with an anime
+func (c *CacheKeyBuilder) SharedCast(animeID string, minShared int, limit int) string {
+	return c.prefix + ":shared-cast:anime:" + animeID + fmt.Sprintf(":min:%d:limit:%d", minShared, limit)
+}
+
+// AiringEventClaim builds the key a replica sets to claim sending an airing event
+func (c *CacheKeyBuilder) AiringEventClaim(event string) string {
+	return c.prefix + ":airing-claim:" + event
+}
+
+// PersistedQuery builds the key a query registered through automatic persisted queries is stored under
+func (c *CacheKeyBuilder) PersistedQuery(hash string) string {
+	return c.prefix + ":persisted-query:" + hash
+}
+
+// RateLimit builds the key of a client's rate limit token bucket
+func (c *CacheKeyBuilder) RateLimit(client string) string {
+	return c.prefix + ":rate-limit:" + client
+}
+
 // TTL helper functions that use configuration values
 func GetAnimeDataTTL(cfg config.RedisConfig) time.Duration {
 	return time.Duration(cfg.AnimeDataTTLMinutes) * time.Minute
@@ -142,4 +172,12 @@ func GetCurrentlyAiringTTL(cfg config.RedisConfig) time.Duration {
 	// Use episode TTL as base since currently airing is episode-based
 	// But shorter since the data changes more frequently
 	return time.Duration(cfg.EpisodeTTLMinutes/2) * time.Minute
+}
+
+func GetSearchSuggestionTTL(cfg config.RedisConfig) time.Duration {
+	return time.Duration(cfg.SearchSuggestionTTLMinutes) * time.Minute
+}
+
+func GetSharedCastTTL(cfg config.RedisConfig) time.Duration {
+	return time.Duration(cfg.SharedCastTTLMinutes) * time.Minute
 }
\ No newline at end of file
@@ -36,6 +36,12 @@ func (c *CacheCoordinator) InvalidateAnimeAndRelated(ctx context.Context, animeI
 	listPattern := c.cache.GetKeyBuilder().AnimePattern()
 	_ = c.cache.DeletePattern(ctx, listPattern)
 
+	// Invalidate currently airing lists (they embed anime data)
+	_ = c.cache.DeletePattern(ctx, c.cache.GetKeyBuilder().CurrentlyAiringPattern())
+
+	// Invalidate search suggestions (they embed titles and images)
+	_ = c.cache.DeletePattern(ctx, c.cache.GetKeyBuilder().SearchSuggestionsPattern())
+
 	return nil
 }
 
@@ -48,6 +54,20 @@ func (c *CacheCoordinator) InvalidateEpisodesOnly(ctx context.Context, animeID s
 	return nil
 }
 
+// InvalidateEpisodeSchedule invalidates episode cache for an anime along with the
+// caches episode writes leak into: the anime itself (its episode count is kept by
+// triggers) and the currently airing lists
+func (c *CacheCoordinator) InvalidateEpisodeSchedule(ctx context.Context, animeID string) error {
+	_ = c.InvalidateEpisodesOnly(ctx, animeID)
+
+	animeKey := c.cache.GetKeyBuilder().AnimeByID(animeID)
+	_ = c.cache.Delete(ctx, animeKey)
+
+	_ = c.cache.DeletePattern(ctx, c.cache.GetKeyBuilder().CurrentlyAiringPattern())
+
+	return nil
+}
+
 // InvalidateSeasonCaches invalidates all season-related caches
 func (c *CacheCoordinator) InvalidateSeasonCaches(ctx context.Context) error {
 	// Invalidate all season caches
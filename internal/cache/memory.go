@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"context"
+	"path"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// MemoryCache implements the Cache interface in process, for when Redis is disabled.
+// It holds at most size entries and evicts the least recently used.
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries *lru.Cache[string, memoryEntry]
+}
+
+type memoryEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+func (e memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// NewMemoryCache creates an in-memory LRU cache holding up to size entries
+func NewMemoryCache(size int) *MemoryCache {
+	if size <= 0 {
+		size = 1
+	}
+	entries, _ := lru.New[string, memoryEntry](size)
+	return &MemoryCache{entries: entries}
+}
+
+// Get retrieves a value from cache
+func (m *MemoryCache) Get(ctx context.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.entries.Get(key)
+	if !ok {
+		return nil, ErrCacheMiss
+	}
+	if entry.expired(time.Now()) {
+		m.entries.Remove(key)
+		return nil, ErrCacheMiss
+	}
+	return entry.value, nil
+}
+
+// Set stores a value in cache with TTL; a TTL of zero keeps it until evicted
+func (m *MemoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries.Add(key, newMemoryEntry(value, ttl))
+	return nil
+}
+
+// Delete removes a value from cache
+func (m *MemoryCache) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries.Remove(key)
+	return nil
+}
+
+// DeletePattern removes all keys matching a glob pattern
+func (m *MemoryCache) DeletePattern(ctx context.Context, pattern string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, key := range m.entries.Keys() {
+		if matched, err := path.Match(pattern, key); err != nil {
+			return err
+		} else if matched {
+			m.entries.Remove(key)
+		}
+	}
+	return nil
+}
+
+// Exists checks if a key exists in cache
+func (m *MemoryCache) Exists(ctx context.Context, key string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.entries.Peek(key)
+	return ok && !entry.expired(time.Now()), nil
+}
+
+// SetNX sets a value only if the key doesn't exist
+func (m *MemoryCache) SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if entry, ok := m.entries.Peek(key); ok && !entry.expired(time.Now()) {
+		return false, nil
+	}
+	m.entries.Add(key, newMemoryEntry(value, ttl))
+	return true, nil
+}
+
+// Close drops every entry
+func (m *MemoryCache) Close() error {
+	m.entries.Purge()
+	return nil
+}
+
+func newMemoryEntry(value []byte, ttl time.Duration) memoryEntry {
+	entry := memoryEntry{value: value}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+	return entry
+}
@@ -0,0 +1,38 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMemoryCache(t *testing.T) {
+	ctx := context.Background()
+	memory := NewMemoryCache(2)
+
+	_ = memory.Set(ctx, "a", []byte("1"), 0)
+	_ = memory.Set(ctx, "b", []byte("2"), time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	if _, err := memory.Get(ctx, "b"); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("expected an expired entry to miss, got %v", err)
+	}
+
+	if ok, _ := memory.SetNX(ctx, "a", []byte("3"), 0); ok {
+		t.Error("expected SetNX to keep an existing key")
+	}
+	if ok, _ := memory.SetNX(ctx, "b", []byte("4"), 0); !ok {
+		t.Error("expected SetNX to replace an expired key")
+	}
+
+	// the least recently used entry is evicted
+	_ = memory.Set(ctx, "c", []byte("5"), 0)
+	if exists, _ := memory.Exists(ctx, "a"); exists {
+		t.Error("expected a to be evicted")
+	}
+
+	_ = memory.DeletePattern(ctx, "*")
+	if exists, _ := memory.Exists(ctx, "c"); exists {
+		t.Error("expected the pattern to delete every key")
+	}
+}
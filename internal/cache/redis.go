@@ -57,6 +57,11 @@ func NewRedisCache(cfg config.RedisConfig) (*RedisCache, error) {
 	}, nil
 }
 
+// Client returns the underlying Redis client, for features beyond caching such as pub/sub
+func (r *RedisCache) Client() *redis.Client {
+	return r.client
+}
+
 // Get retrieves a value from Redis
 func (r *RedisCache) Get(ctx context.Context, key string) ([]byte, error) {
 	tracer := tracing.GetTracer(ctx)
@@ -237,6 +242,11 @@ func (r *RedisCache) SetNX(ctx context.Context, key string, value []byte, ttl ti
 	return result, nil
 }
 
+// Ping checks that Redis can be reached
+func (r *RedisCache) Ping(ctx context.Context) error {
+	return r.client.Ping(ctx).Err()
+}
+
 // Close closes the Redis connection
 func (r *RedisCache) Close() error {
 	return r.client.Close()
@@ -229,4 +229,12 @@ func (c *CacheService) GetLockTTL() time.Duration {
 
 func (c *CacheService) GetCurrentlyAiringTTL() time.Duration {
 	return GetCurrentlyAiringTTL(c.config)
+}
+
+func (c *CacheService) GetSearchSuggestionTTL() time.Duration {
+	return GetSearchSuggestionTTL(c.config)
+}
+
+func (c *CacheService) GetSharedCastTTL() time.Duration {
+	return GetSharedCastTTL(c.config)
 }
\ No newline at end of file
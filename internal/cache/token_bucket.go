@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/weeb-vip/anime-api/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// takeTokenScript refills a token bucket for the time since it was last used and
+// takes one token from it, in one step so replicas sharing a bucket cannot race.
+// It returns whether a token was taken and, if not, the milliseconds until one is.
+var takeTokenScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local ttl = tonumber(ARGV[4])
+
+local bucket = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(bucket[1])
+local ts = tonumber(bucket[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate / 1000)
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	wait = math.ceil((1 - tokens) * 1000 / rate)
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "ts", tostring(now))
+redis.call("PEXPIRE", KEYS[1], ttl)
+return {allowed, wait}
+`)
+
+// TakeToken takes a token from the bucket at key, which holds up to burst tokens and
+// refills at rate tokens a second. When the bucket is empty it reports how long
+// until the next token.
+func (r *RedisCache) TakeToken(ctx context.Context, key string, rate float64, burst int) (bool, time.Duration, error) {
+	tracer := tracing.GetTracer(ctx)
+	ctx, span := tracer.Start(ctx, "Redis.TakeToken",
+		trace.WithAttributes(
+			attribute.String("cache.operation", "take_token"),
+			attribute.String("cache.key", key),
+			attribute.String("cache.backend", "redis"),
+		),
+		tracing.GetEnvironmentAttribute(),
+	)
+	defer span.End()
+
+	// an untouched bucket has refilled completely, so it need not be kept longer
+	ttl := int64(math.Ceil(float64(burst)/rate*1000)) + 1000
+	result, err := takeTokenScript.Run(ctx, r.client, []string{key}, rate, burst, time.Now().UnixMilli(), ttl).Int64Slice()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return false, 0, fmt.Errorf("redis take token error: %w", err)
+	}
+	if len(result) != 2 {
+		err := fmt.Errorf("redis take token error: unexpected reply %v", result)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return false, 0, err
+	}
+
+	allowed := result[0] == 1
+	span.SetAttributes(
+		attribute.Bool("cache.token_taken", allowed),
+		attribute.String("cache.result", "success"),
+	)
+	return allowed, time.Duration(result[1]) * time.Millisecond, nil
+}
@@ -0,0 +1,103 @@
+// Package calendar writes RFC 5545 iCalendar documents. Only the parts needed to
+// publish a feed of timed events are covered.
+package calendar
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	productID = "-//weeb-vip//anime-api//EN"
+	// maxLineOctets is the longest content line RFC 5545 allows before folding
+	maxLineOctets   = 75
+	timestampLayout = "20060102T150405Z"
+)
+
+// Event is one VEVENT. Clients match events across refreshes by UID, so it must
+// stay the same when the event moves.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time
+	End         time.Time
+}
+
+// Calendar is a published VCALENDAR
+type Calendar struct {
+	Name   string
+	Events []Event
+}
+
+// Encode writes the calendar to w, stamping every event with now
+func (c Calendar) Encode(w io.Writer, now time.Time) error {
+	out := bufio.NewWriter(w)
+	line := func(name string, value string) {
+		writeLine(out, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", productID)
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		line("X-WR-CALNAME", escapeText(c.Name))
+	}
+	stamp := formatTime(now)
+	for _, event := range c.Events {
+		line("BEGIN", "VEVENT")
+		line("UID", escapeText(event.UID))
+		line("DTSTAMP", stamp)
+		line("DTSTART", formatTime(event.Start))
+		line("DTEND", formatTime(event.End))
+		line("SUMMARY", escapeText(event.Summary))
+		if event.Description != "" {
+			line("DESCRIPTION", escapeText(event.Description))
+		}
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+
+	return out.Flush()
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timestampLayout)
+}
+
+// escapeText escapes a TEXT value: backslashes, separators and line breaks
+func escapeText(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", "",
+	).Replace(value)
+}
+
+// writeLine writes a content line ending in CRLF, folded so no line exceeds
+// maxLineOctets. Continuation lines start with a space and multi-byte characters
+// are never split.
+func writeLine(out *bufio.Writer, content string) {
+	limit := maxLineOctets
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		out.WriteString(content[:cut])
+		out.WriteString("\r\n ")
+		content = content[cut:]
+		// the leading space counts towards the next line
+		limit = maxLineOctets - 1
+	}
+	out.WriteString(content)
+	out.WriteString("\r\n")
+}
@@ -0,0 +1,67 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendar_Encode(t *testing.T) {
+	start := time.Date(2026, 10, 17, 1, 30, 0, 0, time.FixedZone("JST", 9*60*60))
+	cal := Calendar{
+		Name: "Fall 2026",
+		Events: []Event{{
+			UID:         "anime-1-ep-3-raw@anime-api",
+			Summary:     "Frieren; Beyond, the End",
+			Description: "line one\nline two",
+			Start:       start,
+			End:         start.Add(24 * time.Minute),
+		}},
+	}
+
+	var out strings.Builder
+	require.NoError(t, cal.Encode(&out, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)))
+
+	assert.Equal(t, strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//weeb-vip//anime-api//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:Fall 2026",
+		"BEGIN:VEVENT",
+		"UID:anime-1-ep-3-raw@anime-api",
+		"DTSTAMP:20261001T000000Z",
+		"DTSTART:20261016T163000Z",
+		"DTEND:20261016T165400Z",
+		`SUMMARY:Frieren\; Beyond\, the End`,
+		`DESCRIPTION:line one\nline two`,
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n"), out.String())
+}
+
+func TestCalendar_EncodeFoldsLongLines(t *testing.T) {
+	summary := strings.Repeat("葬送のフリーレン ", 12)
+	cal := Calendar{Events: []Event{{UID: "uid", Summary: summary}}}
+
+	var out strings.Builder
+	require.NoError(t, cal.Encode(&out, time.Now()))
+
+	var unfolded strings.Builder
+	for _, line := range strings.Split(strings.TrimSuffix(out.String(), "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), maxLineOctets)
+		assert.True(t, utf8.ValidString(line), "folding split a character: %q", line)
+		if strings.HasPrefix(line, " ") {
+			unfolded.WriteString(line[1:])
+			continue
+		}
+		unfolded.WriteString("\n" + line)
+	}
+	assert.Contains(t, unfolded.String(), "\nSUMMARY:"+summary+"\n")
+}
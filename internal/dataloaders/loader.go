@@ -0,0 +1,149 @@
+package dataloaders
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BatchFunc loads values for many keys at once. Keys missing from the returned
+// map resolve to the zero value without an error.
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader collects the keys requested within a short window and resolves them
+// with a single BatchFunc call. Results are memoised for the lifetime of the
+// loader, which is one request.
+type Loader[K comparable, V any] struct {
+	fetch    BatchFunc[K, V]
+	wait     time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	results map[K]*result[V]
+	batch   *batch[K, V]
+}
+
+type result[V any] struct {
+	value V
+	err   error
+	done  chan struct{}
+}
+
+type batch[K comparable, V any] struct {
+	ctx     context.Context
+	keys    []K
+	results []*result[V]
+}
+
+// NewLoader creates a loader that dispatches after wait or once maxBatch keys are queued
+func NewLoader[K comparable, V any](fetch BatchFunc[K, V], wait time.Duration, maxBatch int) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch:    fetch,
+		wait:     wait,
+		maxBatch: maxBatch,
+		results:  make(map[K]*result[V]),
+	}
+}
+
+// Load returns the value for key, waiting for the batch it joins to be fetched
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	res := l.enqueue(ctx, key)
+	l.mu.Unlock()
+
+	return res.wait(ctx)
+}
+
+// LoadMany returns the values for keys in order. The keys join one batch, so
+// fetching them costs no more than a single Load.
+func (l *Loader[K, V]) LoadMany(ctx context.Context, keys []K) ([]V, error) {
+	l.mu.Lock()
+	results := make([]*result[V], len(keys))
+	for i, key := range keys {
+		results[i] = l.enqueue(ctx, key)
+	}
+	l.mu.Unlock()
+
+	values := make([]V, len(keys))
+	for i, res := range results {
+		value, err := res.wait(ctx)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// enqueue returns the memoised result for key, queueing it on the open batch
+// when it has not been requested yet. l.mu must be held.
+func (l *Loader[K, V]) enqueue(ctx context.Context, key K) *result[V] {
+	if res, ok := l.results[key]; ok {
+		return res
+	}
+	res := &result[V]{done: make(chan struct{})}
+	l.results[key] = res
+
+	if l.batch == nil {
+		// The batch outlives the caller that opened it, so keep its values but not its cancellation
+		b := &batch[K, V]{ctx: context.WithoutCancel(ctx)}
+		l.batch = b
+		time.AfterFunc(l.wait, func() { l.dispatch(b) })
+	}
+	l.batch.keys = append(l.batch.keys, key)
+	l.batch.results = append(l.batch.results, res)
+
+	if l.maxBatch > 0 && len(l.batch.keys) >= l.maxBatch {
+		b := l.batch
+		l.batch = nil
+		go l.run(b)
+	}
+	return res
+}
+
+func (r *result[V]) wait(ctx context.Context) (V, error) {
+	select {
+	case <-r.done:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+func (l *Loader[K, V]) dispatch(b *batch[K, V]) {
+	l.mu.Lock()
+	if l.batch != b {
+		// Already dispatched because it reached maxBatch
+		l.mu.Unlock()
+		return
+	}
+	l.batch = nil
+	l.mu.Unlock()
+
+	l.run(b)
+}
+
+func (l *Loader[K, V]) run(b *batch[K, V]) {
+	values, err := l.fetch(b.ctx, b.keys)
+	for i, key := range b.keys {
+		res := b.results[i]
+		if err != nil {
+			res.err = err
+		} else {
+			res.value = values[key]
+		}
+		close(res.done)
+	}
+
+	if err != nil {
+		// Do not memoise failures so a later field can retry
+		l.mu.Lock()
+		for i, key := range b.keys {
+			if l.results[key] == b.results[i] {
+				delete(l.results, key)
+			}
+		}
+		l.mu.Unlock()
+	}
+}
@@ -0,0 +1,147 @@
+package dataloaders
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLoaderBatchesConcurrentLoads(t *testing.T) {
+	var calls int32
+	var gotKeys []string
+	loader := NewLoader(func(ctx context.Context, keys []string) (map[string]int, error) {
+		atomic.AddInt32(&calls, 1)
+		gotKeys = append([]string(nil), keys...)
+		result := make(map[string]int, len(keys))
+		for _, key := range keys {
+			if key != "missing" {
+				result[key] = len(key)
+			}
+		}
+		return result, nil
+	}, 10*time.Millisecond, 100)
+
+	keys := []string{"a", "bb", "ccc", "missing"}
+	values := make([]int, len(keys))
+	var wg sync.WaitGroup
+	for i, key := range keys {
+		wg.Add(1)
+		go func(i int, key string) {
+			defer wg.Done()
+			value, err := loader.Load(context.Background(), key)
+			if err != nil {
+				t.Errorf("Load(%q) returned error: %v", key, err)
+			}
+			values[i] = value
+		}(i, key)
+	}
+	wg.Wait()
+
+	if calls != 1 {
+		t.Fatalf("Expected 1 batch call, got %d", calls)
+	}
+	sort.Strings(gotKeys)
+	if len(gotKeys) != len(keys) {
+		t.Errorf("Expected batch of %d keys, got %v", len(keys), gotKeys)
+	}
+	expected := []int{1, 2, 3, 0}
+	for i := range keys {
+		if values[i] != expected[i] {
+			t.Errorf("Load(%q) = %d, expected %d", keys[i], values[i], expected[i])
+		}
+	}
+
+	// A repeated key is served from memo without another fetch
+	if value, _ := loader.Load(context.Background(), "bb"); value != 2 || calls != 1 {
+		t.Errorf("Expected memoised value 2 with 1 call, got %d with %d calls", value, calls)
+	}
+}
+
+func TestLoaderDispatchesAtMaxBatch(t *testing.T) {
+	var calls int32
+	loader := NewLoader(func(ctx context.Context, keys []int) (map[int]int, error) {
+		atomic.AddInt32(&calls, 1)
+		result := make(map[int]int, len(keys))
+		for _, key := range keys {
+			result[key] = key * 2
+		}
+		return result, nil
+	}, time.Hour, 2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(key int) {
+			defer wg.Done()
+			if value, err := loader.Load(context.Background(), key); err != nil || value != key*2 {
+				t.Errorf("Load(%d) = %d, %v", key, value, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if calls != 2 {
+		t.Errorf("Expected 2 batch calls, got %d", calls)
+	}
+}
+
+func TestLoaderDoesNotMemoiseErrors(t *testing.T) {
+	fetchErr := errors.New("db down")
+	var calls int32
+	loader := NewLoader(func(ctx context.Context, keys []string) (map[string]string, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return nil, fetchErr
+		}
+		return map[string]string{"a": "ok"}, nil
+	}, time.Millisecond, 100)
+
+	if _, err := loader.Load(context.Background(), "a"); !errors.Is(err, fetchErr) {
+		t.Fatalf("Expected fetch error, got %v", err)
+	}
+	value, err := loader.Load(context.Background(), "a")
+	if err != nil || value != "ok" {
+		t.Errorf("Expected retry to return ok, got %q, %v", value, err)
+	}
+}
+
+func TestLoaderLoadManyJoinsOneBatch(t *testing.T) {
+	var calls int32
+	loader := NewLoader(func(ctx context.Context, keys []string) (map[string]int, error) {
+		atomic.AddInt32(&calls, 1)
+		result := make(map[string]int, len(keys))
+		for _, key := range keys {
+			if key != "missing" {
+				result[key] = len(key)
+			}
+		}
+		return result, nil
+	}, 10*time.Millisecond, 100)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if value, err := loader.Load(context.Background(), "dddd"); err != nil || value != 4 {
+			t.Errorf("Load(dddd) = %d, %v", value, err)
+		}
+	}()
+	values, err := loader.LoadMany(context.Background(), []string{"a", "missing", "ccc"})
+	wg.Wait()
+
+	if err != nil {
+		t.Fatalf("LoadMany returned error: %v", err)
+	}
+	expected := []int{1, 0, 3}
+	for i := range expected {
+		if values[i] != expected[i] {
+			t.Errorf("values[%d] = %d, expected %d", i, values[i], expected[i])
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 batch call, got %d", calls)
+	}
+}
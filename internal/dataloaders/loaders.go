@@ -0,0 +1,158 @@
+package dataloaders
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_character"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_fanart"
+	anime_relation_repo "github.com/weeb-vip/anime-api/internal/db/repositories/anime_relation"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_schedule"
+	anime_season_repo "github.com/weeb-vip/anime-api/internal/db/repositories/anime_season"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_staff"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_streaming_platform"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_tag"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/episode_air_time"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/episode_delay"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/tag"
+	anime_service "github.com/weeb-vip/anime-api/internal/services/anime"
+	"github.com/weeb-vip/anime-api/internal/services/anime_character_staff_link"
+	"github.com/weeb-vip/anime-api/internal/services/anime_relation"
+	"github.com/weeb-vip/anime-api/internal/services/anime_season"
+)
+
+const (
+	defaultWait     = 2 * time.Millisecond
+	defaultMaxBatch = 100
+)
+
+type ctxKey struct{}
+
+// Sources are the batch-capable repositories and services the loaders read from
+type Sources struct {
+	AnimeTagRepository               anime_tag.AnimeTagRepositoryImpl
+	TagRepository                    tag.TagRepositoryImpl
+	AnimeScheduleRepository          anime_schedule.AnimeScheduleRepositoryImpl
+	AnimeStreamingPlatformRepository anime_streaming_platform.AnimeStreamingPlatformRepositoryImpl
+	AnimeFanartRepository            anime_fanart.AnimeFanartRepositoryImpl
+	EpisodeAirTimeRepository         episode_air_time.EpisodeAirTimeRepositoryImpl
+	EpisodeDelayRepository           episode_delay.EpisodeDelayRepositoryImpl
+	AnimeSeasonService               anime_season.AnimeSeasonServiceImpl
+	AnimeRelationService             anime_relation.AnimeRelationServiceImpl
+	AnimeCharacterStaffLinkService   anime_character_staff_link.AnimeCharacterStaffLinkImpl
+	AnimeService                     anime_service.AnimeServiceImpl
+}
+
+// Loaders batches per-anime lookups made by field resolvers within one request.
+// A loader is nil when its source is not configured.
+type Loaders struct {
+	Tags               *Loader[string, []string]
+	TagDetails         *Loader[string, []anime_tag.AnimeTagDetail]
+	TagsByID           *Loader[int64, tag.TagWithCount]
+	TagChildren        *Loader[int64, []tag.TagWithCount]
+	Schedule           *Loader[string, *anime_schedule.AnimeSchedule]
+	StreamingPlatforms *Loader[string, []anime_streaming_platform.AnimeStreamingPlatform]
+	Fanart             *Loader[string, []anime_fanart.Fanart]
+	EpisodeAirTimes    *Loader[string, []episode_air_time.EpisodeAirTime]
+	EpisodeDelays      *Loader[string, []episode_delay.EpisodeDelay]
+	Seasons            *Loader[string, []*anime_season_repo.AnimeSeason]
+	Relations          *Loader[string, []*anime_relation_repo.AnimeRelation]
+	StaffCharacters    *Loader[string, []anime_character.AnimeCharacter]
+	CharacterStaff     *Loader[string, []anime_staff.AnimeStaff]
+	Anime              *Loader[string, *anime.Anime]
+}
+
+// NewLoaders creates a fresh set of loaders; call it once per request
+func NewLoaders(sources Sources) *Loaders {
+	loaders := &Loaders{}
+
+	if sources.AnimeTagRepository != nil {
+		loaders.Tags = NewLoader(func(ctx context.Context, animeIDs []string) (map[string][]string, error) {
+			return sources.AnimeTagRepository.GetTagNamesForAnimeIDs(animeIDs)
+		}, defaultWait, defaultMaxBatch)
+		loaders.TagDetails = NewLoader(func(ctx context.Context, animeIDs []string) (map[string][]anime_tag.AnimeTagDetail, error) {
+			return sources.AnimeTagRepository.GetTagDetailsForAnimeIDs(animeIDs)
+		}, defaultWait, defaultMaxBatch)
+	}
+	if sources.TagRepository != nil {
+		loaders.TagsByID = NewLoader(func(ctx context.Context, ids []int64) (map[int64]tag.TagWithCount, error) {
+			return sources.TagRepository.FindWithCountsByIDs(ids)
+		}, defaultWait, defaultMaxBatch)
+		loaders.TagChildren = NewLoader(func(ctx context.Context, parentIDs []int64) (map[int64][]tag.TagWithCount, error) {
+			return sources.TagRepository.FindChildren(parentIDs)
+		}, defaultWait, defaultMaxBatch)
+	}
+	if sources.AnimeScheduleRepository != nil {
+		loaders.Schedule = NewLoader(func(ctx context.Context, animeIDs []string) (map[string]*anime_schedule.AnimeSchedule, error) {
+			return sources.AnimeScheduleRepository.FindByAnimeIDs(animeIDs)
+		}, defaultWait, defaultMaxBatch)
+	}
+	if sources.AnimeStreamingPlatformRepository != nil {
+		loaders.StreamingPlatforms = NewLoader(func(ctx context.Context, animeIDs []string) (map[string][]anime_streaming_platform.AnimeStreamingPlatform, error) {
+			return sources.AnimeStreamingPlatformRepository.FindByAnimeIDs(animeIDs)
+		}, defaultWait, defaultMaxBatch)
+	}
+	if sources.AnimeFanartRepository != nil {
+		loaders.Fanart = NewLoader(func(ctx context.Context, animeIDs []string) (map[string][]anime_fanart.Fanart, error) {
+			return sources.AnimeFanartRepository.FindByAnimeIDs(animeIDs)
+		}, defaultWait, defaultMaxBatch)
+	}
+	if sources.EpisodeAirTimeRepository != nil {
+		loaders.EpisodeAirTimes = NewLoader(func(ctx context.Context, animeIDs []string) (map[string][]episode_air_time.EpisodeAirTime, error) {
+			return sources.EpisodeAirTimeRepository.FindByAnimeIDs(animeIDs)
+		}, defaultWait, defaultMaxBatch)
+	}
+	if sources.EpisodeDelayRepository != nil {
+		loaders.EpisodeDelays = NewLoader(func(ctx context.Context, animeIDs []string) (map[string][]episode_delay.EpisodeDelay, error) {
+			return sources.EpisodeDelayRepository.FindByAnimeIDs(animeIDs)
+		}, defaultWait, defaultMaxBatch)
+	}
+	if sources.AnimeSeasonService != nil {
+		loaders.Seasons = NewLoader(sources.AnimeSeasonService.FindByAnimeIDs, defaultWait, defaultMaxBatch)
+	}
+	if sources.AnimeRelationService != nil {
+		loaders.Relations = NewLoader(sources.AnimeRelationService.FindByAnimeIDs, defaultWait, defaultMaxBatch)
+	}
+	if sources.AnimeCharacterStaffLinkService != nil {
+		loaders.StaffCharacters = NewLoader(sources.AnimeCharacterStaffLinkService.FindCharactersByStaffIds, defaultWait, defaultMaxBatch)
+		loaders.CharacterStaff = NewLoader(sources.AnimeCharacterStaffLinkService.FindStaffByCharacterIds, defaultWait, defaultMaxBatch)
+	}
+	if sources.AnimeService != nil {
+		loaders.Anime = NewLoader(func(ctx context.Context, animeIDs []string) (map[string]*anime.Anime, error) {
+			found, err := sources.AnimeService.AnimeByIDs(ctx, animeIDs)
+			if err != nil {
+				return nil, err
+			}
+			animeMap := make(map[string]*anime.Anime, len(found))
+			for _, animeEntity := range found {
+				animeMap[animeEntity.ID] = animeEntity
+			}
+			return animeMap, nil
+		}, defaultWait, defaultMaxBatch)
+	}
+
+	return loaders
+}
+
+// WithLoaders stores loaders on the context
+func WithLoaders(ctx context.Context, loaders *Loaders) context.Context {
+	return context.WithValue(ctx, ctxKey{}, loaders)
+}
+
+// For returns the request's loaders, or nil when none were installed
+func For(ctx context.Context) *Loaders {
+	loaders, _ := ctx.Value(ctxKey{}).(*Loaders)
+	return loaders
+}
+
+// Middleware installs a fresh set of loaders on every request
+func Middleware(sources Sources) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := WithLoaders(r.Context(), NewLoaders(sources))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -57,3 +58,35 @@ func NewDatabase(cfg config.DBConfig) *DB {
 
 	return &DB{DB: db}
 }
+
+// Ping checks that the database can be reached
+func (d *DB) Ping(ctx context.Context) error {
+	sqlDB, err := d.DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// MigrationVersion returns the schema version recorded by golang-migrate, and whether
+// the last migration failed part way
+func (d *DB) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	var migration struct {
+		Version uint
+		Dirty   bool
+	}
+	err := d.DB.WithContext(ctx).Raw("SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&migration).Error
+	if err != nil {
+		return 0, false, err
+	}
+	return migration.Version, migration.Dirty, nil
+}
+
+// Close closes the connection pool
+func (d *DB) Close() error {
+	sqlDB, err := d.DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
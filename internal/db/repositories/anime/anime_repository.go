@@ -60,12 +60,21 @@ type AnimeRepositoryImpl interface {
 	AiringAnimeWithEpisodes(ctx context.Context, startDate *time.Time, endDate *time.Time, days *int) ([]*Anime, error)
 	SearchAnime(ctx context.Context, search string, page int, limit int) ([]*Anime, error)
 	SearchAnimeWithEpisodes(ctx context.Context, search string, page int, limit int) ([]*Anime, error)
+	SearchAnimeFilteredWithEpisodes(ctx context.Context, filter SearchFilter, page int, limit int) ([]*Anime, error)
+	SearchAnimePage(ctx context.Context, filter SearchFilter, after *Cursor, limit int, withEpisodes bool) ([]*Anime, error)
+	CountAnime(ctx context.Context, filter SearchFilter) (int64, error)
+	FindSearchTitles(ctx context.Context, since time.Time) ([]*Anime, error)
+	FindAllIDs(ctx context.Context) ([]string, error)
+	FilterAnimeIDs(ctx context.Context, filter SearchFilter) ([]string, error)
 	FindBySeasonWithEpisodes(ctx context.Context, season string) ([]*Anime, error)
 	FindBySeasonWithEpisodesOptimized(ctx context.Context, season string) ([]*Anime, error)
 	FindBySeasonWithIndexHints(ctx context.Context, season string) ([]*Anime, error)
 	FindBySeasonBatched(ctx context.Context, season string) ([]*Anime, error)
 	FindBySeasonAnimeOnlyOptimized(ctx context.Context, season string) ([]*Anime, error)
 	FindBySeasonWithFieldSelection(ctx context.Context, season string, fields *FieldSelection, limit int) ([]*Anime, error)
+	Create(ctx context.Context, anime *Anime, seasons []Season) error
+	Update(ctx context.Context, anime *Anime, columns []string, seasons []Season) error
+	Delete(ctx context.Context, id string) error
 }
 
 type AnimeRepository struct {
@@ -0,0 +1,156 @@
+package anime
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/weeb-vip/anime-api/internal/cache"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_season"
+	"github.com/weeb-vip/anime-api/metrics"
+	"gorm.io/gorm"
+)
+
+// animeChildTables hold rows keyed by anime_id that are removed with the anime.
+// The schema has no foreign keys (Vitess), so nothing cascades on its own.
+var animeChildTables = []string{
+	"episodes",
+	"episode_air_time",
+	"anime_seasons",
+	"anime_tags",
+	"anime_schedule",
+	"anime_streaming_platform",
+	"anime_character",
+	"episode_delay",
+}
+
+// Create inserts an anime and its seasons in one transaction. A new ID is
+// assigned when anime.ID is empty.
+func (a *AnimeRepository) Create(ctx context.Context, anime *Anime, seasons []Season) error {
+	startTime := time.Now()
+
+	if anime.ID == "" {
+		anime.ID = uuid.NewString()
+	}
+
+	err := a.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("AnimeEpisodes").Create(anime).Error; err != nil {
+			return err
+		}
+		return insertSeasons(tx, anime.ID, seasons)
+	})
+	if err != nil {
+		metrics.GetAppMetrics().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), "anime", "insert", metrics.Error)
+		return err
+	}
+
+	metrics.GetAppMetrics().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), "anime", "insert", metrics.Success)
+
+	a.invalidateAnime(ctx, anime.ID)
+	return nil
+}
+
+// Update writes the given columns of anime, so a partial update never overwrites
+// columns it did not set. Seasons are replaced when non-nil (an empty slice clears
+// them) and left untouched when nil. Returns gorm.ErrRecordNotFound when the anime
+// does not exist.
+func (a *AnimeRepository) Update(ctx context.Context, anime *Anime, columns []string, seasons []Season) error {
+	startTime := time.Now()
+
+	err := a.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&Anime{}).Where("id = ?", anime.ID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if len(columns) > 0 {
+			anime.UpdatedAt = time.Now()
+			columns = append(columns, "updated_at")
+			if err := tx.Model(&Anime{}).Where("id = ?", anime.ID).Select(columns).Updates(anime).Error; err != nil {
+				return err
+			}
+		}
+
+		if seasons == nil {
+			return nil
+		}
+		if err := tx.Where("anime_id = ?", anime.ID).Delete(&anime_season.AnimeSeason{}).Error; err != nil {
+			return err
+		}
+		return insertSeasons(tx, anime.ID, seasons)
+	})
+	if err != nil {
+		metrics.GetAppMetrics().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), "anime", "update", metrics.Error)
+		return err
+	}
+
+	metrics.GetAppMetrics().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), "anime", "update", metrics.Success)
+
+	a.invalidateAnime(ctx, anime.ID)
+	return nil
+}
+
+// Delete removes an anime together with its rows in animeChildTables and any
+// relations pointing to or from it. Returns gorm.ErrRecordNotFound when the
+// anime does not exist.
+func (a *AnimeRepository) Delete(ctx context.Context, id string) error {
+	startTime := time.Now()
+
+	err := a.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, table := range animeChildTables {
+			if err := tx.Table(table).Where("anime_id = ?", id).Delete(map[string]interface{}{}).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Table("anime_relations").Where("anime_id = ? OR related_anime_id = ?", id, id).Delete(map[string]interface{}{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Where("id = ?", id).Delete(&Anime{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		metrics.GetAppMetrics().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), "anime", "delete", metrics.Error)
+		return err
+	}
+
+	metrics.GetAppMetrics().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), "anime", "delete", metrics.Success)
+
+	a.invalidateAnime(ctx, id)
+	return nil
+}
+
+func insertSeasons(tx *gorm.DB, animeID string, seasons []Season) error {
+	if len(seasons) == 0 {
+		return nil
+	}
+
+	rows := make([]*anime_season.AnimeSeason, 0, len(seasons))
+	for _, season := range seasons {
+		id := animeID
+		rows = append(rows, &anime_season.AnimeSeason{
+			ID:      uuid.NewString(),
+			Season:  season.String(),
+			Status:  anime_season.StatusConfirmed,
+			AnimeID: &id,
+		})
+	}
+	return tx.Omit("created_at", "updated_at").Create(&rows).Error
+}
+
+func (a *AnimeRepository) invalidateAnime(ctx context.Context, id string) {
+	if a.cache == nil {
+		return
+	}
+	coordinator := cache.NewCacheCoordinator(a.cache)
+	_ = coordinator.InvalidateAnimeAndRelated(ctx, id)
+}
@@ -0,0 +1,81 @@
+package anime_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/weeb-vip/anime-api/config"
+	"github.com/weeb-vip/anime-api/internal/db"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_tag"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/episode_delay"
+)
+
+func setupTestDB(t *testing.T) *db.DB {
+	cfg := config.DBConfig{
+		Host:     "localhost",
+		Port:     3306,
+		User:     "weeb",
+		Password: "mysecretpassword",
+		DataBase: "weeb",
+		SSLMode:  "false",
+	}
+
+	database := db.NewDatabase(cfg)
+	require.NotNil(t, database)
+
+	sqlDB, err := database.DB.DB()
+	require.NoError(t, err)
+	err = sqlDB.Ping()
+	require.NoError(t, err, "Database should be accessible")
+
+	return database
+}
+
+func TestAnimeRepository_Delete(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	ctx := context.Background()
+	database := setupTestDB(t)
+	animeRepo := anime.NewAnimeRepository(database)
+
+	const animeID = "test-anime-delete-001"
+
+	// Clean up test data
+	cleanup := func() {
+		database.DB.Exec("DELETE FROM anime_tags WHERE anime_id = ?", animeID)
+		database.DB.Exec("DELETE FROM episode_delay WHERE anime_id = ?", animeID)
+		database.DB.Where("id = ?", animeID).Delete(&anime.Anime{})
+	}
+	cleanup()
+	defer cleanup()
+
+	title := "Test Anime for Delete"
+	require.NoError(t, animeRepo.Create(ctx, &anime.Anime{ID: animeID, TitleEn: &title}, nil))
+	require.NoError(t, database.DB.Create(&anime_tag.AnimeTag{AnimeID: animeID, TagID: 1}).Error)
+	require.NoError(t, database.DB.Create(&episode_delay.EpisodeDelay{ID: "test-anime-delete-delay", AnimeID: animeID, EpisodeNumber: 3, AirType: "raw"}).Error)
+
+	t.Run("RemovesAnimeAndChildRows", func(t *testing.T) {
+		require.NoError(t, animeRepo.Delete(ctx, animeID))
+
+		var count int64
+		require.NoError(t, database.DB.Model(&anime.Anime{}).Where("id = ?", animeID).Count(&count).Error)
+		assert.Zero(t, count)
+
+		require.NoError(t, database.DB.Model(&anime_tag.AnimeTag{}).Where("anime_id = ?", animeID).Count(&count).Error)
+		assert.Zero(t, count, "anime_tags rows should be removed")
+
+		require.NoError(t, database.DB.Model(&episode_delay.EpisodeDelay{}).Where("anime_id = ?", animeID).Count(&count).Error)
+		assert.Zero(t, count, "episode_delay rows should be removed")
+	})
+
+	t.Run("MissingAnimeIsNotFound", func(t *testing.T) {
+		assert.ErrorIs(t, animeRepo.Delete(ctx, animeID), gorm.ErrRecordNotFound)
+	})
+}
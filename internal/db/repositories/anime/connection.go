@@ -0,0 +1,208 @@
+package anime
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/weeb-vip/anime-api/metrics"
+	"github.com/weeb-vip/anime-api/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// ErrInvalidCursor is returned for cursors that are malformed or were issued for another sort
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// The sorts behind the ranking lists, matching the ORDER BY of TopRatedAnime,
+// MostPopularAnime and NewestAnime
+var (
+	SortTopRated    = SearchSort{column: "rating", direction: "DESC", skipNulls: true}
+	SortMostPopular = SearchSort{column: "ranking", direction: "ASC", skipNulls: true}
+	SortNewest      = SearchSort{column: "created_at", direction: "DESC"}
+	SortByRanking   = SearchSort{column: "ranking", direction: "ASC"}
+)
+
+// cursorTimeFormat keeps sub-second precision so created_at/updated_at ties resolve by id
+const cursorTimeFormat = time.RFC3339Nano
+
+// Cursor is a keyset position: the sort column value and id of the last anime of a
+// page. Value is nil when that anime had no value in the sort column.
+type Cursor struct {
+	Column string  `json:"c,omitempty"`
+	Value  *string `json:"v,omitempty"`
+	ID     string  `json:"id"`
+}
+
+// Encode returns the opaque form of the cursor handed to clients
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a cursor returned by Cursor.Encode
+func DecodeCursor(value string) (Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return cursor, nil
+}
+
+// CursorFor returns the position of anime in a listing ordered by sort
+func (s SearchSort) CursorFor(anime *Anime) Cursor {
+	cursor := Cursor{Column: s.column, ID: anime.ID}
+	var value string
+	switch s.column {
+	case "":
+		return cursor
+	case "title_en":
+		return cursorWith(cursor, anime.TitleEn)
+	case "title_romaji":
+		return cursorWith(cursor, anime.TitleRomaji)
+	case "start_date":
+		return cursorWith(cursor, anime.StartDate)
+	case "end_date":
+		return cursorWith(cursor, anime.EndDate)
+	case "rating":
+		if anime.Rating == nil {
+			return cursor
+		}
+		value = strconv.FormatFloat(*anime.Rating, 'f', -1, 64)
+	case "ranking":
+		if anime.Ranking == nil {
+			return cursor
+		}
+		value = strconv.Itoa(*anime.Ranking)
+	case "episodes":
+		if anime.Episodes == nil {
+			return cursor
+		}
+		value = strconv.Itoa(*anime.Episodes)
+	case "created_at":
+		value = anime.CreatedAt.Format(cursorTimeFormat)
+	case "updated_at":
+		value = anime.UpdatedAt.Format(cursorTimeFormat)
+	}
+	cursor.Value = &value
+	return cursor
+}
+
+func cursorWith(cursor Cursor, value *string) Cursor {
+	cursor.Value = value
+	return cursor
+}
+
+// keysetOrderClause orders like OrderClause but always puts anime without a value
+// last, so the position after a NULL can be expressed in keysetCondition
+func (s SearchSort) keysetOrderClause() string {
+	if s.column == "" {
+		return "anime.id"
+	}
+	return fmt.Sprintf("anime.%s IS NULL, anime.%s %s, anime.id", s.column, s.column, s.direction)
+}
+
+// keysetCondition selects the anime that follow after in keysetOrderClause order
+func (s SearchSort) keysetCondition(after Cursor) (string, []interface{}, error) {
+	if after.Column != s.column {
+		return "", nil, ErrInvalidCursor
+	}
+	if s.column == "" {
+		return "anime.id > ?", []interface{}{after.ID}, nil
+	}
+	if after.Value == nil {
+		return fmt.Sprintf("anime.%s IS NULL AND anime.id > ?", s.column), []interface{}{after.ID}, nil
+	}
+
+	var value interface{} = *after.Value
+	if s.column == "created_at" || s.column == "updated_at" {
+		parsed, err := time.Parse(cursorTimeFormat, *after.Value)
+		if err != nil {
+			return "", nil, ErrInvalidCursor
+		}
+		value = parsed
+	}
+
+	comparison := ">"
+	if s.direction == "DESC" {
+		comparison = "<"
+	}
+	condition := fmt.Sprintf("(anime.%[1]s %[2]s ? OR (anime.%[1]s = ? AND anime.id > ?) OR anime.%[1]s IS NULL)", s.column, comparison)
+	return condition, []interface{}{value, value, after.ID}, nil
+}
+
+// SearchAnimePage returns up to limit anime matching filter that follow after, or
+// the first page when after is nil. Unlike SearchAnimeFilteredWithEpisodes it seeks
+// by keyset, so deep pages cost the same as the first one.
+func (a *AnimeRepository) SearchAnimePage(ctx context.Context, filter SearchFilter, after *Cursor, limit int, withEpisodes bool) ([]*Anime, error) {
+	tracer := tracing.GetTracer(ctx)
+	ctx, span := tracer.Start(ctx, "AnimeRepository.SearchAnimePage",
+		trace.WithAttributes(
+			attribute.String("db.operation", "search_page"),
+			attribute.String("db.table", "anime"),
+			attribute.String("search.query", filter.Query),
+			attribute.String("search.season", filter.Season),
+			attribute.String("search.order", filter.Sort.keysetOrderClause()),
+			attribute.Bool("pagination.after", after != nil),
+			attribute.Int("pagination.limit", limit),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+	defer span.End()
+
+	startTime := time.Now()
+
+	query := a.applySearchFilter(a.db.DB.WithContext(ctx).Model(&Anime{}), filter)
+	if after != nil {
+		condition, args, err := filter.Sort.keysetCondition(*after)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(condition, args...)
+	}
+	if withEpisodes {
+		query = query.Preload("AnimeEpisodes", func(db *gorm.DB) *gorm.DB {
+			return db.Order("episode ASC")
+		})
+	}
+
+	var animes []*Anime
+	err := query.Order(filter.Sort.keysetOrderClause()).Limit(limit).Find(&animes).Error
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		metrics.GetAppMetrics().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), "anime", "select", metrics.Error)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("db.rows_affected", len(animes)))
+	span.SetStatus(codes.Ok, "")
+	metrics.GetAppMetrics().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), "anime", "select", metrics.Success)
+	return animes, nil
+}
+
+// CountAnime returns how many anime match filter across all pages
+func (a *AnimeRepository) CountAnime(ctx context.Context, filter SearchFilter) (int64, error) {
+	startTime := time.Now()
+
+	var count int64
+	err := a.applySearchFilter(a.db.DB.WithContext(ctx).Model(&Anime{}), filter).Count(&count).Error
+	if err != nil {
+		metrics.GetAppMetrics().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), "anime", "select", metrics.Error)
+		return 0, err
+	}
+
+	metrics.GetAppMetrics().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), "anime", "select", metrics.Success)
+	return count, nil
+}
@@ -0,0 +1,107 @@
+package anime
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	rating := 8.75
+	cursor := SortTopRated.CursorFor(&Anime{ID: "anime-1", Rating: &rating})
+
+	decoded, err := DecodeCursor(cursor.Encode())
+	if err != nil {
+		t.Fatalf("DecodeCursor returned error: %v", err)
+	}
+	if decoded.ID != "anime-1" || decoded.Column != "rating" || decoded.Value == nil || *decoded.Value != "8.75" {
+		t.Errorf("unexpected cursor: %+v", decoded)
+	}
+
+	for _, value := range []string{"", "not base64!", "e30"} {
+		if _, err := DecodeCursor(value); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("DecodeCursor(%q) error = %v, want ErrInvalidCursor", value, err)
+		}
+	}
+}
+
+func TestKeysetCondition(t *testing.T) {
+	createdAt := time.Date(2024, 4, 6, 12, 30, 0, 500, time.UTC)
+	ranking := 12
+
+	tests := []struct {
+		name          string
+		sort          SearchSort
+		after         Cursor
+		wantCondition string
+		wantArgs      int
+		wantErr       bool
+	}{
+		{
+			name:          "id only",
+			sort:          SearchSort{},
+			after:         Cursor{ID: "b"},
+			wantCondition: "anime.id > ?",
+			wantArgs:      1,
+		},
+		{
+			name:          "descending value",
+			sort:          SortNewest,
+			after:         SortNewest.CursorFor(&Anime{ID: "b", CreatedAt: createdAt}),
+			wantCondition: "(anime.created_at < ? OR (anime.created_at = ? AND anime.id > ?) OR anime.created_at IS NULL)",
+			wantArgs:      3,
+		},
+		{
+			name:          "ascending value",
+			sort:          SortMostPopular,
+			after:         SortMostPopular.CursorFor(&Anime{ID: "b", Ranking: &ranking}),
+			wantCondition: "(anime.ranking > ? OR (anime.ranking = ? AND anime.id > ?) OR anime.ranking IS NULL)",
+			wantArgs:      3,
+		},
+		{
+			name:          "after an anime without a value",
+			sort:          SortByRanking,
+			after:         SortByRanking.CursorFor(&Anime{ID: "b"}),
+			wantCondition: "anime.ranking IS NULL AND anime.id > ?",
+			wantArgs:      1,
+		},
+		{
+			name:    "cursor of another sort",
+			sort:    SortTopRated,
+			after:   SortNewest.CursorFor(&Anime{ID: "b", CreatedAt: createdAt}),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, args, err := tt.sort.keysetCondition(tt.after)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidCursor) {
+					t.Fatalf("error = %v, want ErrInvalidCursor", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if condition != tt.wantCondition {
+				t.Errorf("condition = %q, want %q", condition, tt.wantCondition)
+			}
+			if len(args) != tt.wantArgs {
+				t.Errorf("got %d args, want %d", len(args), tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestKeysetConditionKeepsTimePrecision(t *testing.T) {
+	createdAt := time.Date(2024, 4, 6, 12, 30, 0, 500, time.UTC)
+	_, args, err := SortNewest.keysetCondition(SortNewest.CursorFor(&Anime{ID: "b", CreatedAt: createdAt}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value, ok := args[0].(time.Time); !ok || !value.Equal(createdAt) {
+		t.Errorf("args[0] = %v, want %v", args[0], createdAt)
+	}
+}
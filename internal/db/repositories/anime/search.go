@@ -0,0 +1,225 @@
+package anime
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/weeb-vip/anime-api/metrics"
+	"github.com/weeb-vip/anime-api/tracing"
+	metrics_lib "github.com/weeb-vip/go-metrics-lib"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// searchSortColumns maps the sort keys accepted by dbSearch to anime columns.
+// Only keys in this map can reach the ORDER BY clause.
+var searchSortColumns = map[string]string{
+	"titleEn":      "title_en",
+	"titleRomaji":  "title_romaji",
+	"rating":       "rating",
+	"ranking":      "ranking",
+	"episodeCount": "episodes",
+	"startDate":    "start_date",
+	"endDate":      "end_date",
+	"createdAt":    "created_at",
+	"updatedAt":    "updated_at",
+}
+
+// SearchSort is a validated ORDER BY for SearchAnimeFilteredWithEpisodes.
+// The zero value orders by id only.
+type SearchSort struct {
+	column    string
+	direction string
+	skipNulls bool // leave out anime without a value in column
+}
+
+// ParseSearchSort validates a sort key and direction. An empty sort key keeps the
+// default ordering; an empty direction defaults to ascending.
+func ParseSearchSort(sortBy string, sortDirection string) (SearchSort, error) {
+	direction := "ASC"
+	switch strings.ToUpper(strings.TrimSpace(sortDirection)) {
+	case "", "ASC":
+	case "DESC":
+		direction = "DESC"
+	default:
+		return SearchSort{}, fmt.Errorf("invalid sort direction: %s (expected ASC or DESC)", sortDirection)
+	}
+
+	sortBy = strings.TrimSpace(sortBy)
+	if sortBy == "" {
+		return SearchSort{}, nil
+	}
+
+	column, ok := searchSortColumns[sortBy]
+	if !ok {
+		return SearchSort{}, fmt.Errorf("invalid sort field: %s (expected one of: %s)", sortBy, strings.Join(SearchSortFields(), ", "))
+	}
+
+	return SearchSort{column: column, direction: direction}, nil
+}
+
+// SearchSortFields returns the accepted sort keys in alphabetical order
+func SearchSortFields() []string {
+	fields := make([]string, 0, len(searchSortColumns))
+	for field := range searchSortColumns {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// IsZero reports whether the sort is the default id ordering
+func (s SearchSort) IsZero() bool {
+	return s.column == ""
+}
+
+// OrderClause returns the ORDER BY clause, always ending with id so pages are stable
+func (s SearchSort) OrderClause() string {
+	if s.column == "" {
+		return "anime.id"
+	}
+	return fmt.Sprintf("anime.%s %s, anime.id", s.column, s.direction)
+}
+
+// SearchFilter holds the facets applied by SearchAnimeFilteredWithEpisodes.
+// Empty fields are not applied, except IDs: a non-nil empty IDs matches nothing.
+type SearchFilter struct {
+	Query    string
+	Tags     []string // anime must have every tag
+	AnyTags  []string // anime must have at least one of these tags
+	Studios  []string // anime must list at least one studio
+	Statuses []string // anime status must be one of these
+	Season   string   // anime must be listed in this season, e.g. SPRING_2024
+	IDs      []string // anime must be one of these
+	Sort     SearchSort
+}
+
+func (a *AnimeRepository) SearchAnimeFilteredWithEpisodes(ctx context.Context, filter SearchFilter, page int, limit int) ([]*Anime, error) {
+	tracer := tracing.GetTracer(ctx)
+	ctx, span := tracer.Start(ctx, "AnimeRepository.SearchAnimeFilteredWithEpisodes",
+		trace.WithAttributes(
+			attribute.String("db.operation", "search_filtered"),
+			attribute.String("db.table", "anime"),
+			attribute.String("search.query", filter.Query),
+			attribute.Int("search.tags_count", len(filter.Tags)),
+			attribute.Int("search.any_tags_count", len(filter.AnyTags)),
+			attribute.Int("search.studios_count", len(filter.Studios)),
+			attribute.Int("search.statuses_count", len(filter.Statuses)),
+			attribute.String("search.order", filter.Sort.OrderClause()),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+	defer span.End()
+
+	startTime := time.Now()
+
+	if page < 1 {
+		page = 1
+	}
+
+	query := a.applySearchFilter(a.db.DB.WithContext(ctx).Model(&Anime{}), filter)
+
+	var animes []*Anime
+	err := query.Preload("AnimeEpisodes", func(db *gorm.DB) *gorm.DB {
+		return db.Order("episode ASC")
+	}).Order(filter.Sort.OrderClause()).Limit(limit).Offset((page - 1) * limit).Find(&animes).Error
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		_ = metrics.NewMetricsInstance().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), metrics_lib.DatabaseMetricLabels{
+			Service: "anime-api",
+			Table:   "anime",
+			Method:  metrics_lib.DatabaseMetricMethodSelect,
+			Result:  metrics_lib.Error,
+			Env:     metrics.GetCurrentEnv(),
+		})
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("db.rows_affected", len(animes)))
+	span.SetStatus(codes.Ok, "")
+	_ = metrics.NewMetricsInstance().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), metrics_lib.DatabaseMetricLabels{
+		Service: "anime-api",
+		Table:   "anime",
+		Method:  metrics_lib.DatabaseMetricMethodSelect,
+		Result:  metrics_lib.Success,
+		Env:     metrics.GetCurrentEnv(),
+	})
+	return animes, nil
+}
+
+// applySearchFilter adds the WHERE clauses of filter to query
+func (a *AnimeRepository) applySearchFilter(query *gorm.DB, filter SearchFilter) *gorm.DB {
+	if filter.Query != "" {
+		like := "%" + filter.Query + "%"
+		query = query.Where("anime.title_en LIKE ? OR anime.title_jp LIKE ? OR anime.title_synonyms LIKE ? OR anime.title_romaji LIKE ? OR anime.title_kanji LIKE ?", like, like, like, like, like)
+	}
+
+	if len(filter.Tags) > 0 {
+		tags := uniqueStrings(filter.Tags)
+		tagged := a.db.DB.Table("anime_tags").
+			Select("anime_tags.anime_id").
+			Joins("JOIN tags ON tags.id = anime_tags.tag_id").
+			Where("tags.name IN ?", tags).
+			Group("anime_tags.anime_id").
+			Having("COUNT(DISTINCT tags.id) = ?", len(tags))
+		query = query.Where("anime.id IN (?)", tagged)
+	}
+
+	if len(filter.AnyTags) > 0 {
+		tagged := a.db.DB.Table("anime_tags").
+			Select("anime_tags.anime_id").
+			Joins("JOIN tags ON tags.id = anime_tags.tag_id").
+			Where("tags.name IN ?", uniqueStrings(filter.AnyTags))
+		query = query.Where("anime.id IN (?)", tagged)
+	}
+
+	if len(filter.Studios) > 0 {
+		// studios is a JSON array stored in a text column; guard with JSON_VALID so
+		// malformed rows are skipped instead of failing the whole query
+		conditions := make([]string, 0, len(filter.Studios))
+		args := make([]interface{}, 0, len(filter.Studios))
+		for _, studio := range filter.Studios {
+			conditions = append(conditions, "JSON_CONTAINS(anime.studios, JSON_QUOTE(?))")
+			args = append(args, studio)
+		}
+		query = query.Where("CASE WHEN JSON_VALID(anime.studios) THEN ("+strings.Join(conditions, " OR ")+") ELSE 0 END", args...)
+	}
+
+	if len(filter.Statuses) > 0 {
+		query = query.Where("anime.status IN ?", filter.Statuses)
+	}
+
+	if filter.IDs != nil {
+		query = query.Where("anime.id IN ?", filter.IDs)
+	}
+
+	if filter.Season != "" {
+		query = query.Where("anime.id IN (?)", a.db.DB.Table("anime_seasons").Select("anime_id").Where("season = ?", filter.Season))
+	}
+
+	if filter.Sort.skipNulls {
+		query = query.Where(fmt.Sprintf("anime.%s IS NOT NULL", filter.Sort.column))
+	}
+
+	return query
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		result = append(result, value)
+	}
+	return result
+}
@@ -0,0 +1,89 @@
+package anime
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/weeb-vip/anime-api/metrics"
+)
+
+// searchTitleColumns are the columns the in-process search indexes are built from:
+// the titles, and the image and start date search suggestions show
+var searchTitleColumns = []string{"id", "title_en", "title_romaji", "title_jp", "title_kanji", "title_synonyms", "image_url", "start_date", "updated_at"}
+
+// Titles returns the anime's non-empty titles and its synonyms parsed from the
+// title_synonyms JSON array. Malformed synonyms are ignored.
+func (a *Anime) Titles() (titles []string, synonyms []string) {
+	for _, title := range []*string{a.TitleEn, a.TitleRomaji, a.TitleJp, a.TitleKanji} {
+		if title != nil && *title != "" {
+			titles = append(titles, *title)
+		}
+	}
+	if a.TitleSynonyms != nil {
+		_ = json.Unmarshal([]byte(*a.TitleSynonyms), &synonyms)
+	}
+	return titles, synonyms
+}
+
+// StartYear returns the year of the anime's start date, or nil when it has none
+func (a *Anime) StartYear() *int {
+	if a.StartDate == nil || len(*a.StartDate) < 4 {
+		return nil
+	}
+	year, err := strconv.Atoi((*a.StartDate)[:4])
+	if err != nil {
+		return nil
+	}
+	return &year
+}
+
+// FindSearchTitles loads only the columns the search indexes need, of anime updated
+// at or after since; the zero time loads every anime
+func (a *AnimeRepository) FindSearchTitles(ctx context.Context, since time.Time) ([]*Anime, error) {
+	startTime := time.Now()
+
+	query := a.db.DB.WithContext(ctx).Select(searchTitleColumns)
+	if !since.IsZero() {
+		query = query.Where("updated_at >= ?", since)
+	}
+
+	var animes []*Anime
+	if err := query.Find(&animes).Error; err != nil {
+		metrics.GetAppMetrics().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), "anime", "select", metrics.Error)
+		return nil, err
+	}
+
+	metrics.GetAppMetrics().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), "anime", "select", metrics.Success)
+	return animes, nil
+}
+
+// FindAllIDs returns the ID of every anime
+func (a *AnimeRepository) FindAllIDs(ctx context.Context) ([]string, error) {
+	startTime := time.Now()
+
+	var ids []string
+	if err := a.db.DB.WithContext(ctx).Model(&Anime{}).Pluck("id", &ids).Error; err != nil {
+		metrics.GetAppMetrics().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), "anime", "select", metrics.Error)
+		return nil, err
+	}
+
+	metrics.GetAppMetrics().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), "anime", "select", metrics.Success)
+	return ids, nil
+}
+
+// FilterAnimeIDs returns the IDs of anime matching filter, in no particular order.
+// It is meant for filters with IDs set, to apply facets to ranked search hits.
+func (a *AnimeRepository) FilterAnimeIDs(ctx context.Context, filter SearchFilter) ([]string, error) {
+	startTime := time.Now()
+
+	var ids []string
+	if err := a.applySearchFilter(a.db.DB.WithContext(ctx).Model(&Anime{}), filter).Pluck("anime.id", &ids).Error; err != nil {
+		metrics.GetAppMetrics().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), "anime", "select", metrics.Error)
+		return nil, err
+	}
+
+	metrics.GetAppMetrics().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), "anime", "select", metrics.Success)
+	return ids, nil
+}
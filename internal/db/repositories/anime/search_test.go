@@ -0,0 +1,79 @@
+package anime
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseSearchSort(t *testing.T) {
+	tests := []struct {
+		name          string
+		sortBy        string
+		sortDirection string
+		expectedOrder string
+		expectError   bool
+	}{
+		{
+			name:          "no sort keeps id ordering",
+			expectedOrder: "anime.id",
+		},
+		{
+			name:          "direction defaults to ascending",
+			sortBy:        "rating",
+			expectedOrder: "anime.rating ASC, anime.id",
+		},
+		{
+			name:          "descending is case insensitive",
+			sortBy:        "startDate",
+			sortDirection: "desc",
+			expectedOrder: "anime.start_date DESC, anime.id",
+		},
+		{
+			name:          "graphql field name maps to column",
+			sortBy:        "episodeCount",
+			sortDirection: "ASC",
+			expectedOrder: "anime.episodes ASC, anime.id",
+		},
+		{
+			name:        "unknown sort field is rejected",
+			sortBy:      "title_en; DROP TABLE anime",
+			expectError: true,
+		},
+		{
+			name:          "unknown direction is rejected",
+			sortBy:        "rating",
+			sortDirection: "sideways",
+			expectError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searchSort, err := ParseSearchSort(tt.sortBy, tt.sortDirection)
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("Expected error for sortBy=%q sortDirection=%q, got order %q", tt.sortBy, tt.sortDirection, searchSort.OrderClause())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := searchSort.OrderClause(); got != tt.expectedOrder {
+				t.Errorf("Expected order %q, got %q", tt.expectedOrder, got)
+			}
+		})
+	}
+}
+
+func TestParseSearchSortErrorListsFields(t *testing.T) {
+	_, err := ParseSearchSort("popularity", "")
+	if err == nil {
+		t.Fatal("Expected error for unknown sort field")
+	}
+	for _, field := range SearchSortFields() {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("Expected error to mention %q, got %q", field, err.Error())
+		}
+	}
+}
@@ -9,7 +9,7 @@ import (
 )
 
 type AnimeCharacterRepositoryImpl interface {
-	FindAnimearacterById(ctx context.Context, id string) (*AnimeCharacter, error)
+	FindAnimeCharacterById(ctx context.Context, id string) (*AnimeCharacter, error)
 }
 
 type AnimeCharacterRepository struct {
@@ -20,7 +20,7 @@ func NewAnimeCharacterRepository(db *db.DB) AnimeCharacterRepositoryImpl {
 	return &AnimeCharacterRepository{db: db}
 }
 
-func (a *AnimeCharacterRepository) FindAnimearacterById(ctx context.Context, id string) (*AnimeCharacter, error) {
+func (a *AnimeCharacterRepository) FindAnimeCharacterById(ctx context.Context, id string) (*AnimeCharacter, error) {
 	startTime := time.Now()
 
 	var animeCharacter AnimeCharacter
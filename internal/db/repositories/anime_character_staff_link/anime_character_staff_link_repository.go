@@ -2,6 +2,7 @@ package anime_character_staff_link
 
 import (
 	"context"
+	"strings"
 	"time"
 	"github.com/weeb-vip/anime-api/internal/db"
 	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_character"
@@ -20,8 +21,25 @@ func (AnimeCharacterWithStaff) TableName() string {
 	return table.TableName()
 }
 
+// CharacterStaffFilter narrows the cast of an anime to staff speaking one of Languages
+// and characters with one of Roles, compared case-insensitively. An empty list does not filter.
+type CharacterStaffFilter struct {
+	Languages []string
+	Roles     []string
+}
+
+// SharedCast is another anime and the staff its cast shares with the anime it was compared to
+type SharedCast struct {
+	AnimeID     string
+	SharedCount int
+	Staff       []anime_staff.AnimeStaff
+}
+
 type AnimeCharacterStaffLinkRepositoryImpl interface {
-	FindAnimeCharacterAndStaffByAnimeId(ctx context.Context, animeId string) ([]*AnimeCharacterWithStaff, error)
+	FindAnimeCharacterAndStaffByAnimeId(ctx context.Context, animeId string, filter CharacterStaffFilter) ([]*AnimeCharacterWithStaff, error)
+	FindCharactersByStaffIds(ctx context.Context, staffIds []string) (map[string][]anime_character.AnimeCharacter, error)
+	FindStaffByCharacterIds(ctx context.Context, characterIds []string) (map[string][]anime_staff.AnimeStaff, error)
+	FindSharedCastByAnimeId(ctx context.Context, animeId string, minShared int, limit int) ([]*SharedCast, error)
 }
 
 type AnimeCharacterStaffLinkRepository struct {
@@ -32,7 +50,7 @@ func NewAnimeCharacterStaffLinkRepository(db *db.DB) AnimeCharacterStaffLinkRepo
 	return &AnimeCharacterStaffLinkRepository{db: db}
 }
 
-func (a *AnimeCharacterStaffLinkRepository) FindAnimeCharacterAndStaffByAnimeId(ctx context.Context, animeId string) ([]*AnimeCharacterWithStaff, error) {
+func (a *AnimeCharacterStaffLinkRepository) FindAnimeCharacterAndStaffByAnimeId(ctx context.Context, animeId string, filter CharacterStaffFilter) ([]*AnimeCharacterWithStaff, error) {
 	startTime := time.Now()
 
 	type joinResult struct {
@@ -62,7 +80,15 @@ func (a *AnimeCharacterStaffLinkRepository) FindAnimeCharacterAndStaffByAnimeId(
 
 	var rows []joinResult
 
-	err := a.db.DB.WithContext(ctx).
+	query := a.db.DB.WithContext(ctx)
+	if len(filter.Languages) > 0 {
+		query = query.Where("LOWER(anime_staff.language) IN ?", lowerAll(filter.Languages))
+	}
+	if len(filter.Roles) > 0 {
+		query = query.Where("LOWER(anime_character.role) IN ?", lowerAll(filter.Roles))
+	}
+
+	err := query.
 		Table("anime_character_staff_link").
 		Select(`
 			anime_character.id as character_id,
@@ -155,3 +181,142 @@ func (a *AnimeCharacterStaffLinkRepository) FindAnimeCharacterAndStaffByAnimeId(
 	})
 	return result, nil
 }
+
+// FindCharactersByStaffIds returns a map of staff ID to the characters they are linked to,
+// most recently started anime first
+func (a *AnimeCharacterStaffLinkRepository) FindCharactersByStaffIds(ctx context.Context, staffIds []string) (map[string][]anime_character.AnimeCharacter, error) {
+	if len(staffIds) == 0 {
+		return make(map[string][]anime_character.AnimeCharacter), nil
+	}
+
+	startTime := time.Now()
+
+	var rows []struct {
+		StaffID string
+		anime_character.AnimeCharacter
+	}
+	err := a.db.DB.WithContext(ctx).
+		Table("anime_character_staff_link").
+		Select("anime_character_staff_link.staff_id, anime_character.*").
+		Joins("JOIN anime_character ON anime_character.id = anime_character_staff_link.character_id").
+		Joins("LEFT JOIN anime ON anime.id = anime_character.anime_id").
+		Where("anime_character_staff_link.staff_id IN ?", staffIds).
+		Order("anime.start_date IS NULL, anime.start_date DESC, anime_character.id ASC").
+		Scan(&rows).Error
+	if err != nil {
+		recordSelectMetric(startTime, metrics_lib.Error)
+		return nil, err
+	}
+
+	characterMap := make(map[string][]anime_character.AnimeCharacter)
+	for _, row := range rows {
+		characterMap[row.StaffID] = append(characterMap[row.StaffID], row.AnimeCharacter)
+	}
+
+	recordSelectMetric(startTime, metrics_lib.Success)
+	return characterMap, nil
+}
+
+// FindStaffByCharacterIds returns a map of character ID to the staff linked to them
+func (a *AnimeCharacterStaffLinkRepository) FindStaffByCharacterIds(ctx context.Context, characterIds []string) (map[string][]anime_staff.AnimeStaff, error) {
+	if len(characterIds) == 0 {
+		return make(map[string][]anime_staff.AnimeStaff), nil
+	}
+
+	startTime := time.Now()
+
+	var rows []struct {
+		CharacterID string
+		anime_staff.AnimeStaff
+	}
+	err := a.db.DB.WithContext(ctx).
+		Table("anime_character_staff_link").
+		Select("anime_character_staff_link.character_id, anime_staff.*").
+		Joins("JOIN anime_staff ON anime_staff.id = anime_character_staff_link.staff_id").
+		Where("anime_character_staff_link.character_id IN ?", characterIds).
+		Order("anime_staff.language ASC, anime_staff.family_name ASC, anime_staff.id ASC").
+		Scan(&rows).Error
+	if err != nil {
+		recordSelectMetric(startTime, metrics_lib.Error)
+		return nil, err
+	}
+
+	staffMap := make(map[string][]anime_staff.AnimeStaff)
+	for _, row := range rows {
+		staffMap[row.CharacterID] = append(staffMap[row.CharacterID], row.AnimeStaff)
+	}
+
+	recordSelectMetric(startTime, metrics_lib.Success)
+	return staffMap, nil
+}
+
+// sharedCastQuery ranks the anime whose characters are linked to the same staff as the
+// characters of an anime, and returns a row for each staff member they share
+const sharedCastQuery = `
+WITH shared AS (
+	SELECT DISTINCT other_character.anime_id, other_link.staff_id
+	FROM anime_character AS source_character
+	JOIN anime_character_staff_link AS source_link ON source_link.character_id = source_character.id
+	JOIN anime_character_staff_link AS other_link ON other_link.staff_id = source_link.staff_id
+	JOIN anime_character AS other_character ON other_character.id = other_link.character_id
+	WHERE source_character.anime_id = ? AND other_character.anime_id <> ?
+), ranked AS (
+	SELECT anime_id, COUNT(*) AS shared_count
+	FROM shared
+	GROUP BY anime_id
+	HAVING COUNT(*) >= ?
+	ORDER BY shared_count DESC, anime_id ASC
+	LIMIT ?
+)
+SELECT ranked.anime_id, ranked.shared_count, anime_staff.*
+FROM ranked
+JOIN shared ON shared.anime_id = ranked.anime_id
+JOIN anime_staff ON anime_staff.id = shared.staff_id
+ORDER BY ranked.shared_count DESC, ranked.anime_id ASC, anime_staff.family_name ASC, anime_staff.id ASC`
+
+// FindSharedCastByAnimeId returns up to limit other anime sharing at least minShared
+// staff members with an anime, most shared first, in a single query
+func (a *AnimeCharacterStaffLinkRepository) FindSharedCastByAnimeId(ctx context.Context, animeId string, minShared int, limit int) ([]*SharedCast, error) {
+	startTime := time.Now()
+
+	var rows []struct {
+		AnimeID     string
+		SharedCount int
+		anime_staff.AnimeStaff
+	}
+	err := a.db.DB.WithContext(ctx).Raw(sharedCastQuery, animeId, animeId, minShared, limit).Scan(&rows).Error
+	if err != nil {
+		recordSelectMetric(startTime, metrics_lib.Error)
+		return nil, err
+	}
+
+	var result []*SharedCast
+	for _, row := range rows {
+		if len(result) == 0 || result[len(result)-1].AnimeID != row.AnimeID {
+			result = append(result, &SharedCast{AnimeID: row.AnimeID, SharedCount: row.SharedCount})
+		}
+		current := result[len(result)-1]
+		current.Staff = append(current.Staff, row.AnimeStaff)
+	}
+
+	recordSelectMetric(startTime, metrics_lib.Success)
+	return result, nil
+}
+
+func lowerAll(values []string) []string {
+	lowered := make([]string, 0, len(values))
+	for _, value := range values {
+		lowered = append(lowered, strings.ToLower(strings.TrimSpace(value)))
+	}
+	return lowered
+}
+
+func recordSelectMetric(startTime time.Time, result metrics_lib.Result) {
+	_ = metrics.NewMetricsInstance().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), metrics_lib.DatabaseMetricLabels{
+		Service: "anime-api",
+		Table:   "anime_character_staff_link",
+		Method:  metrics_lib.DatabaseMetricMethodSelect,
+		Result:  result,
+		Env:     metrics.GetCurrentEnv(),
+	})
+}
@@ -0,0 +1,51 @@
+package anime
+
+import (
+	"context"
+	"time"
+
+	"github.com/weeb-vip/anime-api/metrics"
+)
+
+// EpisodeBroadcast is an episode with the broadcast slot of its anime, which places
+// the episode's air date at a time of day
+type EpisodeBroadcast struct {
+	AnimeEpisode
+	Broadcast *string `gorm:"column:broadcast" json:"broadcast"`
+}
+
+// FindAiredBetween returns the episodes with an air date in [from, to) together with
+// their anime's broadcast
+func (a *AnimeEpisodeRepository) FindAiredBetween(ctx context.Context, from time.Time, to time.Time) ([]*EpisodeBroadcast, error) {
+	startTime := time.Now()
+
+	var episodes []*EpisodeBroadcast
+	err := a.db.DB.WithContext(ctx).
+		Table("episodes").
+		Select("episodes.*, anime.broadcast").
+		Joins("JOIN anime ON anime.id = episodes.anime_id").
+		Where("episodes.aired >= ? AND episodes.aired < ?", from, to).
+		Find(&episodes).Error
+	if err != nil {
+		metrics.GetAppMetrics().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), "anime_episodes", "select", metrics.Error)
+		return nil, err
+	}
+
+	metrics.GetAppMetrics().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), "anime_episodes", "select", metrics.Success)
+	return episodes, nil
+}
+
+// FindUpdatedBetween returns the episodes written in [from, to)
+func (a *AnimeEpisodeRepository) FindUpdatedBetween(ctx context.Context, from time.Time, to time.Time) ([]*AnimeEpisode, error) {
+	startTime := time.Now()
+
+	var episodes []*AnimeEpisode
+	err := a.db.DB.WithContext(ctx).Where("updated_at >= ? AND updated_at < ?", from, to).Find(&episodes).Error
+	if err != nil {
+		metrics.GetAppMetrics().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), "anime_episodes", "select", metrics.Error)
+		return nil, err
+	}
+
+	metrics.GetAppMetrics().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), "anime_episodes", "select", metrics.Success)
+	return episodes, nil
+}
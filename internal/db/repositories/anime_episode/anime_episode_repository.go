@@ -2,18 +2,29 @@ package anime
 
 import (
 	"context"
+	"errors"
 	"time"
+
+	"github.com/google/uuid"
 	"github.com/weeb-vip/anime-api/internal/cache"
 	"github.com/weeb-vip/anime-api/internal/db"
 	"github.com/weeb-vip/anime-api/metrics"
+	"gorm.io/gorm"
 )
 
 type RECORD_TYPE string
 
+// ErrEpisodeNotInAnime is returned when an upsert names an episode ID that belongs to another anime
+var ErrEpisodeNotInAnime = errors.New("episode does not belong to anime")
+
 type AnimeEpisodeRepositoryImpl interface {
 	Upsert(ctx context.Context, anime *AnimeEpisode) error
+	UpsertMany(ctx context.Context, animeID string, episodes []*AnimeEpisode) error
 	Delete(ctx context.Context, anime *AnimeEpisode) error
 	FindByAnimeID(ctx context.Context, animeID string) ([]*AnimeEpisode, error)
+	FindByID(ctx context.Context, id string) (*AnimeEpisode, error)
+	FindAiredBetween(ctx context.Context, from time.Time, to time.Time) ([]*EpisodeBroadcast, error)
+	FindUpdatedBetween(ctx context.Context, from time.Time, to time.Time) ([]*AnimeEpisode, error)
 }
 
 type AnimeEpisodeRepository struct {
@@ -51,9 +62,86 @@ func (a *AnimeEpisodeRepository) Upsert(ctx context.Context, episode *AnimeEpiso
 	)
 
 	// Invalidate cache if available
+	if a.cache != nil {
+		_ = a.cache.Delete(ctx, a.cache.GetKeyBuilder().EpisodeByID(episode.ID))
+	}
 	if a.cache != nil && episode.AnimeID != nil {
 		coordinator := cache.NewCacheCoordinator(a.cache)
-		_ = coordinator.InvalidateEpisodesOnly(ctx, *episode.AnimeID)
+		_ = coordinator.InvalidateEpisodeSchedule(ctx, *episode.AnimeID)
+	}
+
+	return nil
+}
+
+// UpsertMany saves the episodes of one anime in a single transaction. Episodes
+// without an ID update the stored episode with the same number, or are inserted
+// with a new ID; the anime's episode count follows through the episodes triggers.
+func (a *AnimeEpisodeRepository) UpsertMany(ctx context.Context, animeID string, episodes []*AnimeEpisode) error {
+	startTime := time.Now()
+
+	err := a.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing []*AnimeEpisode
+		if err := tx.Where("anime_id = ?", animeID).Find(&existing).Error; err != nil {
+			return err
+		}
+		byID := make(map[string]*AnimeEpisode, len(existing))
+		byNumber := make(map[int]*AnimeEpisode, len(existing))
+		for _, episode := range existing {
+			byID[episode.ID] = episode
+			if episode.Episode != nil {
+				byNumber[*episode.Episode] = episode
+			}
+		}
+
+		for _, episode := range episodes {
+			episode.AnimeID = &animeID
+			if episode.ID != "" {
+				stored, ok := byID[episode.ID]
+				if !ok {
+					return ErrEpisodeNotInAnime
+				}
+				episode.CreatedAt = stored.CreatedAt
+			} else if episode.Episode != nil && byNumber[*episode.Episode] != nil {
+				stored := byNumber[*episode.Episode]
+				episode.ID = stored.ID
+				episode.CreatedAt = stored.CreatedAt
+			} else {
+				episode.ID = uuid.NewString()
+				if err := tx.Create(episode).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			if err := tx.Save(episode).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		metrics.GetAppMetrics().DatabaseMetric(
+			float64(time.Since(startTime).Milliseconds()),
+			"anime_episodes",
+			"insert",
+			metrics.Error,
+		)
+		return err
+	}
+
+	metrics.GetAppMetrics().DatabaseMetric(
+		float64(time.Since(startTime).Milliseconds()),
+		"anime_episodes",
+		"insert",
+		metrics.Success,
+	)
+
+	// Invalidate cache if available
+	if a.cache != nil {
+		for _, episode := range episodes {
+			_ = a.cache.Delete(ctx, a.cache.GetKeyBuilder().EpisodeByID(episode.ID))
+		}
+		coordinator := cache.NewCacheCoordinator(a.cache)
+		_ = coordinator.InvalidateEpisodeSchedule(ctx, animeID)
 	}
 
 	return nil
@@ -81,9 +169,12 @@ func (a *AnimeEpisodeRepository) Delete(ctx context.Context, episode *AnimeEpiso
 	)
 
 	// Invalidate cache if available
+	if a.cache != nil {
+		_ = a.cache.Delete(ctx, a.cache.GetKeyBuilder().EpisodeByID(episode.ID))
+	}
 	if a.cache != nil && episode.AnimeID != nil {
 		coordinator := cache.NewCacheCoordinator(a.cache)
-		_ = coordinator.InvalidateEpisodesOnly(ctx, *episode.AnimeID)
+		_ = coordinator.InvalidateEpisodeSchedule(ctx, *episode.AnimeID)
 	}
 
 	return nil
@@ -129,3 +220,44 @@ func (a *AnimeEpisodeRepository) FindByAnimeID(ctx context.Context, animeID stri
 
 	return episodes, nil
 }
+
+func (a *AnimeEpisodeRepository) FindByID(ctx context.Context, id string) (*AnimeEpisode, error) {
+	// Try cache first if available
+	if a.cache != nil {
+		key := a.cache.GetKeyBuilder().EpisodeByID(id)
+		var episode AnimeEpisode
+		err := a.cache.GetJSON(ctx, key, &episode)
+		if err == nil {
+			return &episode, nil
+		}
+		// Continue to database if cache miss or error
+	}
+
+	startTime := time.Now()
+	var episode AnimeEpisode
+	err := a.db.DB.WithContext(ctx).Where("id = ?", id).First(&episode).Error
+	if err != nil {
+		metrics.GetAppMetrics().DatabaseMetric(
+			float64(time.Since(startTime).Milliseconds()),
+			"anime_episodes",
+			"select",
+			metrics.Error,
+		)
+		return nil, err
+	}
+
+	metrics.GetAppMetrics().DatabaseMetric(
+		float64(time.Since(startTime).Milliseconds()),
+		"anime_episodes",
+		"select",
+		metrics.Success,
+	)
+
+	// Store in cache if available
+	if a.cache != nil {
+		key := a.cache.GetKeyBuilder().EpisodeByID(id)
+		_ = a.cache.SetJSON(ctx, key, &episode, 15*time.Minute) // TODO: Make configurable
+	}
+
+	return &episode, nil
+}
@@ -6,6 +6,7 @@ import (
 
 type AnimeFanartRepositoryImpl interface {
 	FindByAnimeID(animeID string) ([]Fanart, error)
+	FindByAnimeIDs(animeIDs []string) (map[string][]Fanart, error)
 }
 
 type AnimeFanartRepository struct {
@@ -21,3 +22,22 @@ func (r *AnimeFanartRepository) FindByAnimeID(animeID string) ([]Fanart, error)
 	err := r.db.DB.Where("anime_id = ?", animeID).Order("created_at DESC").Find(&fanart).Error
 	return fanart, err
 }
+
+// FindByAnimeIDs returns a map of anime ID to fanart for multiple anime, newest first
+func (r *AnimeFanartRepository) FindByAnimeIDs(animeIDs []string) (map[string][]Fanart, error) {
+	if len(animeIDs) == 0 {
+		return make(map[string][]Fanart), nil
+	}
+
+	var fanart []Fanart
+	err := r.db.DB.Where("anime_id IN ?", animeIDs).Order("created_at DESC").Find(&fanart).Error
+	if err != nil {
+		return nil, err
+	}
+
+	fanartMap := make(map[string][]Fanart)
+	for _, f := range fanart {
+		fanartMap[f.AnimeID] = append(fanartMap[f.AnimeID], f)
+	}
+	return fanartMap, nil
+}
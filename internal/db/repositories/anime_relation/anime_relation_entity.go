@@ -0,0 +1,72 @@
+package anime_relation
+
+import (
+	"strings"
+	"time"
+)
+
+// Relation types stored in anime_relations.relation_type, in normalised form
+const (
+	RelationSequel             = "sequel"
+	RelationPrequel            = "prequel"
+	RelationSideStory          = "side_story"
+	RelationParentStory        = "parent_story"
+	RelationAlternativeSetting = "alternative_setting"
+	RelationAlternativeVersion = "alternative_version"
+	RelationSpinOff            = "spin_off"
+	RelationSummary            = "summary"
+	RelationFullStory          = "full_story"
+	RelationCharacter          = "character"
+	RelationOther              = "other"
+)
+
+var relationTypeAliases = map[string]string{
+	RelationSequel:             RelationSequel,
+	RelationPrequel:            RelationPrequel,
+	RelationSideStory:          RelationSideStory,
+	"side":                     RelationSideStory,
+	RelationParentStory:        RelationParentStory,
+	"parent":                   RelationParentStory,
+	RelationAlternativeSetting: RelationAlternativeSetting,
+	RelationAlternativeVersion: RelationAlternativeVersion,
+	"alternative":              RelationAlternativeVersion,
+	RelationSpinOff:            RelationSpinOff,
+	"spinoff":                  RelationSpinOff,
+	RelationSummary:            RelationSummary,
+	RelationFullStory:          RelationFullStory,
+	RelationCharacter:          RelationCharacter,
+	RelationOther:              RelationOther,
+}
+
+type AnimeRelation struct {
+	ID             string    `gorm:"column:id;type:char(36);primaryKey" json:"id"`
+	AnimeID        string    `gorm:"column:anime_id;type:varchar(36);not null" json:"anime_id"`
+	RelatedAnimeID string    `gorm:"column:related_anime_id;type:varchar(36);not null" json:"related_anime_id"`
+	RelationType   *string   `gorm:"column:relation_type;type:varchar(30);null" json:"relation_type"`
+	CreatedAt      time.Time `gorm:"column:created_at;default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+// TableName sets the table name
+func (AnimeRelation) TableName() string {
+	return "anime_relations"
+}
+
+// NormalizedType returns the relation type in normalised form; free-form or
+// missing values from the scrapers ("Side Story", "spin-off", NULL) are mapped
+// onto the known constants and anything unrecognised becomes RelationOther.
+func (r AnimeRelation) NormalizedType() string {
+	return NormalizeRelationType(r.RelationType)
+}
+
+// NormalizeRelationType maps a raw relation_type value onto one of the relation constants
+func NormalizeRelationType(raw *string) string {
+	if raw == nil {
+		return RelationOther
+	}
+	key := strings.ToLower(strings.TrimSpace(*raw))
+	key = strings.NewReplacer(" ", "_", "-", "_").Replace(key)
+	if normalized, ok := relationTypeAliases[key]; ok {
+		return normalized
+	}
+	return RelationOther
+}
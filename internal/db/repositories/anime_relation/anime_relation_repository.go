@@ -0,0 +1,85 @@
+package anime_relation
+
+import (
+	"context"
+	"time"
+
+	"github.com/weeb-vip/anime-api/internal/db"
+	"github.com/weeb-vip/anime-api/metrics"
+)
+
+type AnimeRelationRepositoryImpl interface {
+	FindByAnimeID(ctx context.Context, animeID string) ([]*AnimeRelation, error)
+	FindByAnimeIDs(ctx context.Context, animeIDs []string) (map[string][]*AnimeRelation, error)
+	FindTouching(ctx context.Context, animeIDs []string) ([]*AnimeRelation, error)
+}
+
+type AnimeRelationRepository struct {
+	db *db.DB
+}
+
+func NewAnimeRelationRepository(db *db.DB) AnimeRelationRepositoryImpl {
+	return &AnimeRelationRepository{db: db}
+}
+
+// FindByAnimeID returns the relations listed on an anime
+func (r *AnimeRelationRepository) FindByAnimeID(ctx context.Context, animeID string) ([]*AnimeRelation, error) {
+	startTime := time.Now()
+
+	var relations []*AnimeRelation
+	err := r.db.DB.WithContext(ctx).Where("anime_id = ?", animeID).Order("created_at, id").Find(&relations).Error
+	if err != nil {
+		metrics.GetAppMetrics().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), "anime_relations", "select", metrics.Error)
+		return nil, err
+	}
+
+	metrics.GetAppMetrics().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), "anime_relations", "select", metrics.Success)
+	return relations, nil
+}
+
+// FindByAnimeIDs returns a map of anime ID to the relations listed on it for multiple anime
+func (r *AnimeRelationRepository) FindByAnimeIDs(ctx context.Context, animeIDs []string) (map[string][]*AnimeRelation, error) {
+	if len(animeIDs) == 0 {
+		return make(map[string][]*AnimeRelation), nil
+	}
+
+	startTime := time.Now()
+
+	var relations []*AnimeRelation
+	err := r.db.DB.WithContext(ctx).Where("anime_id IN ?", animeIDs).Order("created_at, id").Find(&relations).Error
+	if err != nil {
+		metrics.GetAppMetrics().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), "anime_relations", "select", metrics.Error)
+		return nil, err
+	}
+
+	metrics.GetAppMetrics().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), "anime_relations", "select", metrics.Success)
+
+	relationMap := make(map[string][]*AnimeRelation)
+	for _, relation := range relations {
+		relationMap[relation.AnimeID] = append(relationMap[relation.AnimeID], relation)
+	}
+	return relationMap, nil
+}
+
+// FindTouching returns every relation with either end in animeIDs. Relations are
+// not always stored in both directions, so graph walks need both sides.
+func (r *AnimeRelationRepository) FindTouching(ctx context.Context, animeIDs []string) ([]*AnimeRelation, error) {
+	if len(animeIDs) == 0 {
+		return nil, nil
+	}
+
+	startTime := time.Now()
+
+	var relations []*AnimeRelation
+	err := r.db.DB.WithContext(ctx).
+		Where("anime_id IN ? OR related_anime_id IN ?", animeIDs, animeIDs).
+		Order("created_at, id").
+		Find(&relations).Error
+	if err != nil {
+		metrics.GetAppMetrics().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), "anime_relations", "select", metrics.Error)
+		return nil, err
+	}
+
+	metrics.GetAppMetrics().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), "anime_relations", "select", metrics.Success)
+	return relations, nil
+}
@@ -6,6 +6,7 @@ import (
 
 type AnimeScheduleRepositoryImpl interface {
 	FindByAnimeID(animeID string) (*AnimeSchedule, error)
+	FindByAnimeIDs(animeIDs []string) (map[string]*AnimeSchedule, error)
 }
 
 type AnimeScheduleRepository struct {
@@ -24,3 +25,22 @@ func (r *AnimeScheduleRepository) FindByAnimeID(animeID string) (*AnimeSchedule,
 	}
 	return &schedule, nil
 }
+
+// FindByAnimeIDs returns a map of anime ID to schedule for multiple anime
+func (r *AnimeScheduleRepository) FindByAnimeIDs(animeIDs []string) (map[string]*AnimeSchedule, error) {
+	if len(animeIDs) == 0 {
+		return make(map[string]*AnimeSchedule), nil
+	}
+
+	var schedules []*AnimeSchedule
+	err := r.db.DB.Where("anime_id IN ?", animeIDs).Find(&schedules).Error
+	if err != nil {
+		return nil, err
+	}
+
+	scheduleMap := make(map[string]*AnimeSchedule, len(schedules))
+	for _, schedule := range schedules {
+		scheduleMap[schedule.AnimeID] = schedule
+	}
+	return scheduleMap, nil
+}
@@ -10,6 +10,7 @@ import (
 
 type AnimeSeasonRepositoryImpl interface {
 	FindByAnimeID(ctx context.Context, animeID string) ([]*AnimeSeason, error)
+	FindByAnimeIDs(ctx context.Context, animeIDs []string) (map[string][]*AnimeSeason, error)
 	FindBySeason(ctx context.Context, season string) ([]*AnimeSeason, error)
 	Create(ctx context.Context, animeSeason *AnimeSeason) error
 	Update(ctx context.Context, animeSeason *AnimeSeason) error
@@ -50,6 +51,44 @@ func (r *AnimeSeasonRepository) FindByAnimeID(ctx context.Context, animeID strin
 	return animeSeasons, nil
 }
 
+// FindByAnimeIDs returns a map of anime ID to seasons for multiple anime
+func (r *AnimeSeasonRepository) FindByAnimeIDs(ctx context.Context, animeIDs []string) (map[string][]*AnimeSeason, error) {
+	if len(animeIDs) == 0 {
+		return make(map[string][]*AnimeSeason), nil
+	}
+
+	startTime := time.Now()
+
+	var animeSeasons []*AnimeSeason
+	err := r.db.DB.WithContext(ctx).Where("anime_id IN ?", animeIDs).Find(&animeSeasons).Error
+	if err != nil {
+		_ = metrics.NewMetricsInstance().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), metrics_lib.DatabaseMetricLabels{
+			Service: "anime-api",
+			Table:   "anime_seasons",
+			Method:  metrics_lib.DatabaseMetricMethodSelect,
+			Result:  metrics_lib.Error,
+			Env:     metrics.GetCurrentEnv(),
+		})
+		return nil, err
+	}
+
+	_ = metrics.NewMetricsInstance().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), metrics_lib.DatabaseMetricLabels{
+		Service: "anime-api",
+		Table:   "anime_seasons",
+		Method:  metrics_lib.DatabaseMetricMethodSelect,
+		Result:  metrics_lib.Success,
+		Env:     metrics.GetCurrentEnv(),
+	})
+
+	seasonMap := make(map[string][]*AnimeSeason)
+	for _, animeSeason := range animeSeasons {
+		if animeSeason.AnimeID != nil {
+			seasonMap[*animeSeason.AnimeID] = append(seasonMap[*animeSeason.AnimeID], animeSeason)
+		}
+	}
+	return seasonMap, nil
+}
+
 func (r *AnimeSeasonRepository) FindBySeason(ctx context.Context, season string) ([]*AnimeSeason, error) {
 	startTime := time.Now()
 
@@ -1,10 +1,18 @@
 package anime_staff
 
 import (
+	"context"
+	"strings"
+	"time"
+
 	"github.com/weeb-vip/anime-api/internal/db"
+	"github.com/weeb-vip/anime-api/metrics"
+	metrics_lib "github.com/weeb-vip/go-metrics-lib"
 )
 
 type AnimeStaffRepositoryImpl interface {
+	FindAnimeStaffById(ctx context.Context, id string) (*AnimeStaff, error)
+	SearchAnimeStaffByName(ctx context.Context, name string, limit int) ([]*AnimeStaff, error)
 }
 
 type AnimeStaffRepository struct {
@@ -14,3 +22,54 @@ type AnimeStaffRepository struct {
 func NewAnimeStaffRepository(db *db.DB) AnimeStaffRepositoryImpl {
 	return &AnimeStaffRepository{db: db}
 }
+
+func (a *AnimeStaffRepository) FindAnimeStaffById(ctx context.Context, id string) (*AnimeStaff, error) {
+	startTime := time.Now()
+
+	var animeStaff AnimeStaff
+	err := a.db.DB.WithContext(ctx).Where("id = ?", id).First(&animeStaff).Error
+	if err != nil {
+		recordSelectMetric(startTime, metrics_lib.Error)
+		return nil, err
+	}
+
+	recordSelectMetric(startTime, metrics_lib.Success)
+	return &animeStaff, nil
+}
+
+// SearchAnimeStaffByName returns staff whose given or family name contains every word
+// of name, in either order, sorted by family then given name
+func (a *AnimeStaffRepository) SearchAnimeStaffByName(ctx context.Context, name string, limit int) ([]*AnimeStaff, error) {
+	startTime := time.Now()
+
+	query := a.db.DB.WithContext(ctx)
+	for _, word := range strings.Fields(name) {
+		pattern := "%" + escapeLike(word) + "%"
+		query = query.Where("(given_name LIKE ? OR family_name LIKE ?)", pattern, pattern)
+	}
+
+	var animeStaff []*AnimeStaff
+	err := query.Order("family_name ASC, given_name ASC, id ASC").Limit(limit).Find(&animeStaff).Error
+	if err != nil {
+		recordSelectMetric(startTime, metrics_lib.Error)
+		return nil, err
+	}
+
+	recordSelectMetric(startTime, metrics_lib.Success)
+	return animeStaff, nil
+}
+
+// escapeLike stops wildcards typed by the user from matching any character
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+func recordSelectMetric(startTime time.Time, result metrics_lib.Result) {
+	_ = metrics.NewMetricsInstance().DatabaseMetric(float64(time.Since(startTime).Milliseconds()), metrics_lib.DatabaseMetricLabels{
+		Service: "anime-api",
+		Table:   "anime_staff",
+		Method:  metrics_lib.DatabaseMetricMethodSelect,
+		Result:  result,
+		Env:     metrics.GetCurrentEnv(),
+	})
+}
@@ -6,6 +6,7 @@ import (
 
 type AnimeStreamingPlatformRepositoryImpl interface {
 	FindByAnimeID(animeID string) ([]AnimeStreamingPlatform, error)
+	FindByAnimeIDs(animeIDs []string) (map[string][]AnimeStreamingPlatform, error)
 }
 
 type AnimeStreamingPlatformRepository struct {
@@ -21,3 +22,22 @@ func (r *AnimeStreamingPlatformRepository) FindByAnimeID(animeID string) ([]Anim
 	err := r.db.DB.Where("anime_id = ?", animeID).Find(&platforms).Error
 	return platforms, err
 }
+
+// FindByAnimeIDs returns a map of anime ID to streaming platforms for multiple anime
+func (r *AnimeStreamingPlatformRepository) FindByAnimeIDs(animeIDs []string) (map[string][]AnimeStreamingPlatform, error) {
+	if len(animeIDs) == 0 {
+		return make(map[string][]AnimeStreamingPlatform), nil
+	}
+
+	var platforms []AnimeStreamingPlatform
+	err := r.db.DB.Where("anime_id IN ?", animeIDs).Find(&platforms).Error
+	if err != nil {
+		return nil, err
+	}
+
+	platformMap := make(map[string][]AnimeStreamingPlatform)
+	for _, platform := range platforms {
+		platformMap[platform.AnimeID] = append(platformMap[platform.AnimeID], platform)
+	}
+	return platformMap, nil
+}
@@ -7,6 +7,7 @@ import (
 type AnimeTag struct {
 	AnimeID   string    `gorm:"column:anime_id;type:varchar(36);primaryKey" json:"anime_id"`
 	TagID     int64     `gorm:"column:tag_id;primaryKey" json:"tag_id"`
+	Weight    int       `gorm:"column:weight;default:100" json:"weight"`
 	CreatedAt time.Time `gorm:"column:created_at" json:"created_at"`
 }
 
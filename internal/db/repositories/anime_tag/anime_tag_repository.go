@@ -1,7 +1,10 @@
 package anime_tag
 
 import (
+	"encoding/json"
+
 	"github.com/weeb-vip/anime-api/internal/db"
+	"gorm.io/gorm"
 )
 
 type AnimeTagRepositoryImpl interface {
@@ -12,6 +15,8 @@ type AnimeTagRepositoryImpl interface {
 	AddTagToAnime(animeID string, tagID int64) error
 	RemoveTagFromAnime(animeID string, tagID int64) error
 	DeleteAllTagsForAnime(animeID string) error
+	GetTagDetailsForAnimeIDs(animeIDs []string) (map[string][]AnimeTagDetail, error)
+	SetTagWeight(animeID string, tagID int64, weight int) error
 }
 
 type AnimeTagRepository struct {
@@ -22,29 +27,91 @@ func NewAnimeTagRepository(db *db.DB) AnimeTagRepositoryImpl {
 	return &AnimeTagRepository{db: db}
 }
 
-// SetTagsForAnime replaces all tags for an anime with the given tag IDs
+// SetTagsForAnime replaces all tags for an anime with the given tag IDs, and rewrites
+// its genres to match, in one transaction. Tags the anime keeps keep their weight.
 func (r *AnimeTagRepository) SetTagsForAnime(animeID string, tagIDs []int64) error {
-	// Delete existing tag associations
-	err := r.db.DB.Where("anime_id = ?", animeID).Delete(&AnimeTag{}).Error
-	if err != nil {
-		return err
-	}
+	return r.db.DB.Transaction(func(tx *gorm.DB) error {
+		var existing []AnimeTag
+		err := tx.Where("anime_id = ?", animeID).Find(&existing).Error
+		if err != nil {
+			return err
+		}
+		weights := make(map[int64]int, len(existing))
+		for _, animeTag := range existing {
+			weights[animeTag.TagID] = animeTag.Weight
+		}
+
+		// Delete existing tag associations
+		err = tx.Where("anime_id = ?", animeID).Delete(&AnimeTag{}).Error
+		if err != nil {
+			return err
+		}
 
-	// Insert new tag associations
-	if len(tagIDs) > 0 {
-		animeTags := make([]AnimeTag, len(tagIDs))
-		for i, tagID := range tagIDs {
-			animeTags[i] = AnimeTag{
-				AnimeID: animeID,
-				TagID:   tagID,
+		// Insert new tag associations
+		if len(tagIDs) > 0 {
+			animeTags := make([]AnimeTag, len(tagIDs))
+			for i, tagID := range tagIDs {
+				animeTags[i] = AnimeTag{
+					AnimeID: animeID,
+					TagID:   tagID,
+					Weight:  weights[tagID],
+				}
+			}
+			err = tx.Create(&animeTags).Error
+			if err != nil {
+				return err
 			}
 		}
-		err = r.db.DB.Create(&animeTags).Error
+
+		return SyncGenres(tx, []string{animeID})
+	})
+}
+
+// genresBatchSize bounds the anime whose tags SyncGenres reads in one query
+const genresBatchSize = 500
+
+// SyncGenres rewrites the legacy anime.genres column, a JSON array of tag names kept
+// for older readers, from anime_tags for the given anime. Anime without tags get NULL.
+// Call it inside the transaction that changed their tags.
+func SyncGenres(tx *gorm.DB, animeIDs []string) error {
+	for start := 0; start < len(animeIDs); start += genresBatchSize {
+		end := start + genresBatchSize
+		if end > len(animeIDs) {
+			end = len(animeIDs)
+		}
+		batch := animeIDs[start:end]
+
+		var results []AnimeTagName
+		err := tx.Table("anime_tags").
+			Select("anime_tags.anime_id, tags.name").
+			Joins("JOIN tags ON tags.id = anime_tags.tag_id").
+			Where("anime_tags.anime_id IN ?", batch).
+			Order("tags.name ASC").
+			Scan(&results).Error
 		if err != nil {
 			return err
 		}
-	}
 
+		tagMap := make(map[string][]string)
+		for _, result := range results {
+			tagMap[result.AnimeID] = append(tagMap[result.AnimeID], result.Name)
+		}
+
+		for _, animeID := range batch {
+			var genres *string
+			if names := tagMap[animeID]; len(names) > 0 {
+				encoded, err := json.Marshal(names)
+				if err != nil {
+					return err
+				}
+				value := string(encoded)
+				genres = &value
+			}
+			if err := tx.Table("anime").Where("id = ?", animeID).Update("genres", genres).Error; err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
@@ -126,3 +193,52 @@ func (r *AnimeTagRepository) GetTagNamesForAnimeIDs(animeIDs []string) (map[stri
 
 	return tagMap, nil
 }
+
+// AnimeTagDetail is a tag of an anime with its taxonomy and how relevant it is to
+// the anime
+type AnimeTagDetail struct {
+	AnimeID   string  `gorm:"column:anime_id"`
+	TagID     int64   `gorm:"column:tag_id"`
+	Name      string  `gorm:"column:name"`
+	Category  *string `gorm:"column:category"`
+	ParentID  *int64  `gorm:"column:parent_id"`
+	IsSpoiler bool    `gorm:"column:is_spoiler"`
+	Weight    int     `gorm:"column:weight"`
+}
+
+// GetTagDetailsForAnimeIDs returns a map of anime ID to its tags, most relevant first
+func (r *AnimeTagRepository) GetTagDetailsForAnimeIDs(animeIDs []string) (map[string][]AnimeTagDetail, error) {
+	if len(animeIDs) == 0 {
+		return make(map[string][]AnimeTagDetail), nil
+	}
+
+	var results []AnimeTagDetail
+	err := r.db.DB.Table("anime_tags").
+		Select("anime_tags.anime_id, anime_tags.tag_id, anime_tags.weight, tags.name, tags.category, tags.parent_id, tags.is_spoiler").
+		Joins("JOIN tags ON tags.id = anime_tags.tag_id").
+		Where("anime_tags.anime_id IN ?", animeIDs).
+		Order("anime_tags.weight DESC, tags.name ASC").
+		Scan(&results).Error
+	if err != nil {
+		return nil, err
+	}
+
+	tagMap := make(map[string][]AnimeTagDetail)
+	for _, result := range results {
+		tagMap[result.AnimeID] = append(tagMap[result.AnimeID], result)
+	}
+	return tagMap, nil
+}
+
+// SetTagWeight sets how relevant a tag is to an anime. It returns
+// gorm.ErrRecordNotFound when the anime does not have the tag.
+func (r *AnimeTagRepository) SetTagWeight(animeID string, tagID int64, weight int) error {
+	return r.db.DB.Transaction(func(tx *gorm.DB) error {
+		var animeTag AnimeTag
+		err := tx.Where("anime_id = ? AND tag_id = ?", animeID, tagID).First(&animeTag).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&AnimeTag{}).Where("anime_id = ? AND tag_id = ?", animeID, tagID).Update("weight", weight).Error
+	})
+}
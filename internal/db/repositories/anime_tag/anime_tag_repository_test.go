@@ -11,6 +11,7 @@ import (
 	"github.com/weeb-vip/anime-api/internal/db/repositories/anime"
 	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_tag"
 	"github.com/weeb-vip/anime-api/internal/db/repositories/tag"
+	"gorm.io/gorm"
 )
 
 func setupTestDB(t *testing.T) *db.DB {
@@ -101,6 +102,31 @@ func TestAnimeTagRepository_SetTagsForAnime(t *testing.T) {
 		assert.Contains(t, savedTagIDs, tag3.ID)
 	})
 
+	t.Run("SetTagsForAnime_SyncsGenres", func(t *testing.T) {
+		var saved anime.Anime
+		require.NoError(t, database.DB.Where("id = ?", "test-anime-tag-001").First(&saved).Error)
+		require.NotNil(t, saved.Genres)
+		assert.JSONEq(t, `["test-tag-comedy","test-tag-drama"]`, *saved.Genres)
+	})
+
+	t.Run("SetTagsForAnime_KeepsWeights", func(t *testing.T) {
+		require.NoError(t, animeTagRepo.SetTagWeight("test-anime-tag-001", tag2.ID, 40))
+		require.NoError(t, animeTagRepo.SetTagsForAnime("test-anime-tag-001", []int64{tag1.ID, tag2.ID}))
+
+		details, err := animeTagRepo.GetTagDetailsForAnimeIDs([]string{"test-anime-tag-001"})
+		require.NoError(t, err)
+		require.Len(t, details["test-anime-tag-001"], 2)
+		// most relevant first: the new tag gets the default weight
+		assert.Equal(t, tag1.ID, details["test-anime-tag-001"][0].TagID)
+		assert.Equal(t, 100, details["test-anime-tag-001"][0].Weight)
+		assert.Equal(t, 40, details["test-anime-tag-001"][1].Weight)
+	})
+
+	t.Run("SetTagWeight_RequiresTag", func(t *testing.T) {
+		err := animeTagRepo.SetTagWeight("test-anime-tag-001", tag3.ID, 50)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+
 	t.Run("SetTagsForAnime_ClearsAllTags", func(t *testing.T) {
 		// Set empty tags
 		err := animeTagRepo.SetTagsForAnime("test-anime-tag-001", []int64{})
@@ -110,6 +136,10 @@ func TestAnimeTagRepository_SetTagsForAnime(t *testing.T) {
 		savedTagIDs, err := animeTagRepo.GetTagIDsForAnime("test-anime-tag-001")
 		require.NoError(t, err)
 		assert.Len(t, savedTagIDs, 0)
+
+		var saved anime.Anime
+		require.NoError(t, database.DB.Where("id = ?", "test-anime-tag-001").First(&saved).Error)
+		assert.Nil(t, saved.Genres)
 	})
 }
 
@@ -1,13 +1,18 @@
 package episode_air_time
 
 import (
+	"time"
+
 	"github.com/weeb-vip/anime-api/internal/db"
 )
 
 type EpisodeAirTimeRepositoryImpl interface {
 	FindByAnimeID(animeID string) ([]EpisodeAirTime, error)
+	FindByAnimeIDs(animeIDs []string) (map[string][]EpisodeAirTime, error)
 	FindByAnimeIDAndEpisode(animeID string, episodeNumber int) ([]EpisodeAirTime, error)
 	FindSubTimeByAnimeIDAndEpisode(animeID string, episodeNumber int) (*EpisodeAirTime, error)
+	FindAiringBetween(from time.Time, to time.Time) ([]EpisodeAirTime, error)
+	FindUpdatedBetween(from time.Time, to time.Time) ([]EpisodeAirTime, error)
 }
 
 type EpisodeAirTimeRepository struct {
@@ -26,6 +31,28 @@ func (r *EpisodeAirTimeRepository) FindByAnimeID(animeID string) ([]EpisodeAirTi
 	return airTimes, err
 }
 
+// FindByAnimeIDs returns a map of anime ID to air times for multiple anime,
+// ordered by episode number and air type
+func (r *EpisodeAirTimeRepository) FindByAnimeIDs(animeIDs []string) (map[string][]EpisodeAirTime, error) {
+	if len(animeIDs) == 0 {
+		return make(map[string][]EpisodeAirTime), nil
+	}
+
+	var airTimes []EpisodeAirTime
+	err := r.db.DB.Where("anime_id IN ?", animeIDs).
+		Order("episode_number ASC, air_type ASC").
+		Find(&airTimes).Error
+	if err != nil {
+		return nil, err
+	}
+
+	airTimeMap := make(map[string][]EpisodeAirTime)
+	for _, airTime := range airTimes {
+		airTimeMap[airTime.AnimeID] = append(airTimeMap[airTime.AnimeID], airTime)
+	}
+	return airTimeMap, nil
+}
+
 func (r *EpisodeAirTimeRepository) FindByAnimeIDAndEpisode(animeID string, episodeNumber int) ([]EpisodeAirTime, error) {
 	var airTimes []EpisodeAirTime
 	err := r.db.DB.Where("anime_id = ? AND episode_number = ?", animeID, episodeNumber).
@@ -43,3 +70,20 @@ func (r *EpisodeAirTimeRepository) FindSubTimeByAnimeIDAndEpisode(animeID string
 	}
 	return &airTime, nil
 }
+
+// FindAiringBetween returns the air times in [from, to), ordered by air datetime
+func (r *EpisodeAirTimeRepository) FindAiringBetween(from time.Time, to time.Time) ([]EpisodeAirTime, error) {
+	var airTimes []EpisodeAirTime
+	err := r.db.DB.Where("air_datetime >= ? AND air_datetime < ?", from, to).
+		Order("air_datetime ASC").
+		Find(&airTimes).Error
+	return airTimes, err
+}
+
+// FindUpdatedBetween returns the air times written in [from, to)
+func (r *EpisodeAirTimeRepository) FindUpdatedBetween(from time.Time, to time.Time) ([]EpisodeAirTime, error) {
+	var airTimes []EpisodeAirTime
+	err := r.db.DB.Where("updated_at >= ? AND updated_at < ?", from, to).
+		Find(&airTimes).Error
+	return airTimes, err
+}
@@ -0,0 +1,22 @@
+package episode_delay
+
+import "time"
+
+// EpisodeDelay postpones one air type of an episode. A delay without a rescheduled
+// time is open-ended: the episode, and those after it, have no known air time.
+type EpisodeDelay struct {
+	ID                     string     `gorm:"column:id;primaryKey" json:"id"`
+	AnimeID                string     `gorm:"column:anime_id" json:"anime_id"`
+	EpisodeNumber          int        `gorm:"column:episode_number" json:"episode_number"`
+	AirType                string     `gorm:"column:air_type" json:"air_type"`
+	OriginalAirDatetime    *time.Time `gorm:"column:original_air_datetime" json:"original_air_datetime"`
+	RescheduledAirDatetime *time.Time `gorm:"column:rescheduled_air_datetime" json:"rescheduled_air_datetime"`
+	Reason                 *string    `gorm:"column:reason" json:"reason"`
+	BreakWeeks             int        `gorm:"column:break_weeks" json:"break_weeks"`
+	CreatedAt              time.Time  `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt              time.Time  `gorm:"column:updated_at" json:"updated_at"`
+}
+
+func (EpisodeDelay) TableName() string {
+	return "episode_delay"
+}
@@ -0,0 +1,48 @@
+package episode_delay
+
+import (
+	"github.com/weeb-vip/anime-api/internal/db"
+)
+
+type EpisodeDelayRepositoryImpl interface {
+	FindByAnimeID(animeID string) ([]EpisodeDelay, error)
+	FindByAnimeIDs(animeIDs []string) (map[string][]EpisodeDelay, error)
+}
+
+type EpisodeDelayRepository struct {
+	db *db.DB
+}
+
+func NewEpisodeDelayRepository(db *db.DB) EpisodeDelayRepositoryImpl {
+	return &EpisodeDelayRepository{db: db}
+}
+
+func (r *EpisodeDelayRepository) FindByAnimeID(animeID string) ([]EpisodeDelay, error) {
+	var delays []EpisodeDelay
+	err := r.db.DB.Where("anime_id = ?", animeID).
+		Order("episode_number ASC, air_type ASC").
+		Find(&delays).Error
+	return delays, err
+}
+
+// FindByAnimeIDs returns a map of anime ID to delays for multiple anime, ordered by
+// episode number and air type
+func (r *EpisodeDelayRepository) FindByAnimeIDs(animeIDs []string) (map[string][]EpisodeDelay, error) {
+	if len(animeIDs) == 0 {
+		return make(map[string][]EpisodeDelay), nil
+	}
+
+	var delays []EpisodeDelay
+	err := r.db.DB.Where("anime_id IN ?", animeIDs).
+		Order("episode_number ASC, air_type ASC").
+		Find(&delays).Error
+	if err != nil {
+		return nil, err
+	}
+
+	delayMap := make(map[string][]EpisodeDelay)
+	for _, delay := range delays {
+		delayMap[delay.AnimeID] = append(delayMap[delay.AnimeID], delay)
+	}
+	return delayMap, nil
+}
@@ -4,9 +4,20 @@ import (
 	"time"
 )
 
+// Tag categories, stored in tags.category
+const (
+	CategoryGenre       = "genre"
+	CategoryTheme       = "theme"
+	CategoryDemographic = "demographic"
+	CategorySetting     = "setting"
+)
+
 type Tag struct {
 	ID        int64     `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
 	Name      string    `gorm:"column:name;type:varchar(100);uniqueIndex;not null" json:"name"`
+	Category  *string   `gorm:"column:category" json:"category"`
+	ParentID  *int64    `gorm:"column:parent_id" json:"parent_id"`
+	IsSpoiler bool      `gorm:"column:is_spoiler;not null;default:false" json:"is_spoiler"`
 	CreatedAt time.Time `gorm:"column:created_at" json:"created_at"`
 	UpdatedAt time.Time `gorm:"column:updated_at" json:"updated_at"`
 }
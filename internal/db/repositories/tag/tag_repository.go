@@ -1,7 +1,19 @@
 package tag
 
 import (
+	"errors"
+	"strings"
+
 	"github.com/weeb-vip/anime-api/internal/db"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_tag"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrTagExists is returned when renaming a tag to the name of another tag
+	ErrTagExists = errors.New("tag already exists")
+	// ErrTagCycle is returned when a tag would become its own ancestor
+	ErrTagCycle = errors.New("tag can not be a descendant of itself")
 )
 
 type TagRepositoryImpl interface {
@@ -10,6 +22,25 @@ type TagRepositoryImpl interface {
 	FindByNames(names []string) ([]Tag, error)
 	FindByIDs(ids []int64) ([]Tag, error)
 	Create(tag *Tag) error
+	ListWithCounts(filter TagFilter, limit int) ([]TagWithCount, error)
+	Rename(from string, to string) (*TagWithCount, error)
+	Merge(sources []string, into string) (*TagWithCount, error)
+	Update(name string, values Tag, columns []string) (*TagWithCount, error)
+	FindWithCountsByIDs(ids []int64) (map[int64]TagWithCount, error)
+	FindChildren(parentIDs []int64) (map[int64][]TagWithCount, error)
+}
+
+// TagFilter narrows the tags ListWithCounts returns
+type TagFilter struct {
+	Prefix          string // name must start with this
+	Category        string // category must be this, when set
+	IncludeSpoilers bool
+}
+
+// TagWithCount is a tag and the number of anime tagged with it
+type TagWithCount struct {
+	Tag
+	AnimeCount int `gorm:"column:anime_count"`
 }
 
 type TagRepository struct {
@@ -62,3 +93,280 @@ func (r *TagRepository) FindByIDs(ids []int64) ([]Tag, error) {
 func (r *TagRepository) Create(tag *Tag) error {
 	return r.db.DB.Create(tag).Error
 }
+
+// ListWithCounts returns the tags matching filter, most used first
+func (r *TagRepository) ListWithCounts(filter TagFilter, limit int) ([]TagWithCount, error) {
+	query := r.withCountsQuery()
+	if filter.Prefix != "" {
+		query = query.Where("tags.name LIKE ?", escapeLike(filter.Prefix)+"%")
+	}
+	if filter.Category != "" {
+		query = query.Where("tags.category = ?", filter.Category)
+	}
+	if !filter.IncludeSpoilers {
+		query = query.Where("tags.is_spoiler = ?", false)
+	}
+
+	var tags []TagWithCount
+	err := query.Order("anime_count DESC, tags.name ASC").Limit(limit).Scan(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// Rename changes the name of a tag and the genres of the anime tagged with it. It
+// returns gorm.ErrRecordNotFound when there is no tag named from, and ErrTagExists
+// when another tag is already named to.
+func (r *TagRepository) Rename(from string, to string) (*TagWithCount, error) {
+	var renamed Tag
+	err := r.db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("name = ?", from).First(&renamed).Error; err != nil {
+			return err
+		}
+
+		var taken int64
+		if err := tx.Model(&Tag{}).Where("name = ? AND id <> ?", to, renamed.ID).Count(&taken).Error; err != nil {
+			return err
+		}
+		if taken > 0 {
+			return ErrTagExists
+		}
+
+		if err := tx.Model(&renamed).Update("name", to).Error; err != nil {
+			return err
+		}
+		renamed.Name = to
+
+		animeIDs, err := taggedAnimeIDs(tx, []int64{renamed.ID})
+		if err != nil {
+			return err
+		}
+		return anime_tag.SyncGenres(tx, animeIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.withCount(renamed)
+}
+
+// Merge moves every anime tagged with one of sources onto the tag named into,
+// creating it if needed, then deletes the source tags. An anime keeps the highest
+// weight it had among the merged tags, and children of a source become children of
+// the target; a target below a source moves up above it so no cycle forms. Sources
+// that do not exist are skipped; when none of them exist, nothing changes and
+// gorm.ErrRecordNotFound is returned.
+func (r *TagRepository) Merge(sources []string, into string) (*TagWithCount, error) {
+	var target Tag
+	err := r.db.DB.Transaction(func(tx *gorm.DB) error {
+		var sourceTags []Tag
+		if err := tx.Where("name IN ? AND name <> ?", sources, into).Find(&sourceTags).Error; err != nil {
+			return err
+		}
+		if len(sourceTags) == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if err := tx.Where("name = ?", into).FirstOrCreate(&target, Tag{Name: into}).Error; err != nil {
+			return err
+		}
+
+		sourceIDs := make([]int64, len(sourceTags))
+		for i, sourceTag := range sourceTags {
+			sourceIDs[i] = sourceTag.ID
+		}
+
+		animeIDs, err := taggedAnimeIDs(tx, sourceIDs)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Exec("INSERT INTO anime_tags (anime_id, tag_id, weight) "+
+			"SELECT anime_id, ?, MAX(weight) FROM anime_tags WHERE tag_id IN ? GROUP BY anime_id "+
+			"ON DUPLICATE KEY UPDATE weight = GREATEST(anime_tags.weight, VALUES(weight))", target.ID, sourceIDs).Error
+		if err != nil {
+			return err
+		}
+		// the children of the sources move under the target, so when the target
+		// descends from a source it moves up to the parent of its highest source
+		// ancestor; anywhere lower it would end up under one of those children
+		ancestors, err := ancestorIDs(tx, target.ParentID)
+		if err != nil {
+			return err
+		}
+		isSource := make(map[int64]bool, len(sourceIDs))
+		for _, sourceID := range sourceIDs {
+			isSource[sourceID] = true
+		}
+		for i := len(ancestors) - 1; i >= 0; i-- {
+			if !isSource[ancestors[i]] {
+				continue
+			}
+			var parentID *int64
+			if i+1 < len(ancestors) {
+				parentID = &ancestors[i+1]
+			}
+			if err := tx.Model(&target).Update("parent_id", parentID).Error; err != nil {
+				return err
+			}
+			target.ParentID = parentID
+			break
+		}
+		err = tx.Model(&Tag{}).Where("parent_id IN ? AND id <> ?", sourceIDs, target.ID).Update("parent_id", target.ID).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("tag_id IN ?", sourceIDs).Delete(&anime_tag.AnimeTag{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("id IN ?", sourceIDs).Delete(&Tag{}).Error; err != nil {
+			return err
+		}
+
+		return anime_tag.SyncGenres(tx, animeIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.withCount(target)
+}
+
+// Update writes the given columns of values, any of category, parent_id and
+// is_spoiler, to the tag with name. It returns gorm.ErrRecordNotFound when there is no
+// such tag, and ErrTagCycle when the new parent is the tag or one of its descendants.
+func (r *TagRepository) Update(name string, values Tag, columns []string) (*TagWithCount, error) {
+	var updated Tag
+	err := r.db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("name = ?", name).First(&updated).Error; err != nil {
+			return err
+		}
+		if len(columns) == 0 {
+			return nil
+		}
+
+		for _, column := range columns {
+			if column == "parent_id" && values.ParentID != nil {
+				if err := checkAncestry(tx, updated.ID, *values.ParentID); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := tx.Model(&updated).Select(columns).Updates(values).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", updated.ID).First(&updated).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.withCount(updated)
+}
+
+// FindWithCountsByIDs returns a map of tag ID to the tag
+func (r *TagRepository) FindWithCountsByIDs(ids []int64) (map[int64]TagWithCount, error) {
+	found := make(map[int64]TagWithCount)
+	if len(ids) == 0 {
+		return found, nil
+	}
+
+	var tags []TagWithCount
+	err := r.withCountsQuery().Where("tags.id IN ?", ids).Scan(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, tagWithCount := range tags {
+		found[tagWithCount.ID] = tagWithCount
+	}
+	return found, nil
+}
+
+// FindChildren returns a map of tag ID to the tags directly under it, by name
+func (r *TagRepository) FindChildren(parentIDs []int64) (map[int64][]TagWithCount, error) {
+	children := make(map[int64][]TagWithCount)
+	if len(parentIDs) == 0 {
+		return children, nil
+	}
+
+	var tags []TagWithCount
+	err := r.withCountsQuery().Where("tags.parent_id IN ?", parentIDs).Order("tags.name ASC").Scan(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, child := range tags {
+		children[*child.ParentID] = append(children[*child.ParentID], child)
+	}
+	return children, nil
+}
+
+// withCountsQuery selects tags with the number of anime tagged with each
+func (r *TagRepository) withCountsQuery() *gorm.DB {
+	return r.db.DB.Table("tags").
+		Select("tags.*, COUNT(anime_tags.anime_id) AS anime_count").
+		Joins("LEFT JOIN anime_tags ON anime_tags.tag_id = tags.id").
+		Group("tags.id")
+}
+
+// checkAncestry walks up from parentID and fails if it reaches tagID
+func checkAncestry(tx *gorm.DB, tagID int64, parentID int64) error {
+	seen := make(map[int64]bool)
+	for current := &parentID; current != nil; {
+		if *current == tagID {
+			return ErrTagCycle
+		}
+		if seen[*current] {
+			// an existing loop above the parent; refuse to hang on it
+			return ErrTagCycle
+		}
+		seen[*current] = true
+
+		var ancestor Tag
+		if err := tx.Select("id", "parent_id").Where("id = ?", *current).First(&ancestor).Error; err != nil {
+			return err
+		}
+		current = ancestor.ParentID
+	}
+	return nil
+}
+
+// ancestorIDs returns the ids above parentID, starting with parentID itself, up to
+// the root or the first tag seen twice
+func ancestorIDs(tx *gorm.DB, parentID *int64) ([]int64, error) {
+	var ids []int64
+	seen := make(map[int64]bool)
+	for current := parentID; current != nil && !seen[*current]; {
+		seen[*current] = true
+		ids = append(ids, *current)
+
+		var ancestor Tag
+		if err := tx.Select("id", "parent_id").Where("id = ?", *current).First(&ancestor).Error; err != nil {
+			return nil, err
+		}
+		current = ancestor.ParentID
+	}
+	return ids, nil
+}
+
+func (r *TagRepository) withCount(tag Tag) (*TagWithCount, error) {
+	var count int64
+	err := r.db.DB.Model(&anime_tag.AnimeTag{}).Where("tag_id = ?", tag.ID).Count(&count).Error
+	if err != nil {
+		return nil, err
+	}
+	return &TagWithCount{Tag: tag, AnimeCount: int(count)}, nil
+}
+
+// taggedAnimeIDs returns the anime tagged with any of tagIDs
+func taggedAnimeIDs(tx *gorm.DB, tagIDs []int64) ([]string, error) {
+	var animeIDs []string
+	err := tx.Model(&anime_tag.AnimeTag{}).Distinct("anime_id").Where("tag_id IN ?", tagIDs).Pluck("anime_id", &animeIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	return animeIDs, nil
+}
+
+// escapeLike stops wildcards typed by the user from matching any character
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/weeb-vip/anime-api/config"
 	"github.com/weeb-vip/anime-api/internal/db"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_tag"
 	"github.com/weeb-vip/anime-api/internal/db/repositories/tag"
 )
 
@@ -268,3 +270,165 @@ func TestTagRepository_FindByIDs(t *testing.T) {
 		assert.Len(t, foundTags, 2) // Only the two existing tags
 	})
 }
+
+func TestTagRepository_RenameAndMerge(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	database := setupTestDB(t)
+	tagRepo := tag.NewTagRepository(database)
+	animeTagRepo := anime_tag.NewAnimeTagRepository(database)
+
+	// Clean up test data
+	cleanup := func() {
+		database.DB.Exec("DELETE FROM anime_tags WHERE anime_id LIKE ?", "test-tag-merge-%")
+		database.DB.Exec("DELETE FROM tags WHERE name LIKE ?", "test-merge-%")
+		database.DB.Where("id LIKE ?", "test-tag-merge-%").Delete(&anime.Anime{})
+	}
+	cleanup()
+	defer cleanup()
+
+	for _, id := range []string{"test-tag-merge-001", "test-tag-merge-002"} {
+		title := "Test Anime " + id
+		require.NoError(t, database.DB.Create(&anime.Anime{ID: id, TitleEn: &title}).Error)
+	}
+
+	scifi, err := tagRepo.FindOrCreate("test-merge-scifi")
+	require.NoError(t, err)
+	spaced, err := tagRepo.FindOrCreate("test-merge-sci fi")
+	require.NoError(t, err)
+	drama, err := tagRepo.FindOrCreate("test-merge-drama")
+	require.NoError(t, err)
+	require.NoError(t, animeTagRepo.SetTagsForAnime("test-tag-merge-001", []int64{scifi.ID, spaced.ID}))
+	require.NoError(t, animeTagRepo.SetTagsForAnime("test-tag-merge-002", []int64{spaced.ID, drama.ID}))
+
+	genres := func(id string) string {
+		var saved anime.Anime
+		require.NoError(t, database.DB.Where("id = ?", id).First(&saved).Error)
+		require.NotNil(t, saved.Genres)
+		return *saved.Genres
+	}
+
+	t.Run("ListWithCountsOrdersByUse", func(t *testing.T) {
+		tags, err := tagRepo.ListWithCounts(tag.TagFilter{Prefix: "test-merge-"}, 10)
+		require.NoError(t, err)
+		require.Len(t, tags, 3)
+		assert.Equal(t, "test-merge-sci fi", tags[0].Name)
+		assert.Equal(t, 2, tags[0].AnimeCount)
+	})
+
+	t.Run("RenameFailsOnTakenName", func(t *testing.T) {
+		_, err := tagRepo.Rename("test-merge-scifi", "test-merge-drama")
+		assert.ErrorIs(t, err, tag.ErrTagExists)
+	})
+
+	t.Run("RenameSyncsGenres", func(t *testing.T) {
+		renamed, err := tagRepo.Rename("test-merge-scifi", "test-merge-sf")
+		require.NoError(t, err)
+		assert.Equal(t, scifi.ID, renamed.ID)
+		assert.Equal(t, 1, renamed.AnimeCount)
+		assert.JSONEq(t, `["test-merge-sci fi","test-merge-sf"]`, genres("test-tag-merge-001"))
+	})
+
+	t.Run("MergeMovesAnimeOntoTarget", func(t *testing.T) {
+		merged, err := tagRepo.Merge([]string{"test-merge-sf", "test-merge-sci fi"}, "test-merge-sci-fi")
+		require.NoError(t, err)
+		assert.Equal(t, 2, merged.AnimeCount)
+		assert.JSONEq(t, `["test-merge-sci-fi"]`, genres("test-tag-merge-001"))
+		assert.JSONEq(t, `["test-merge-drama","test-merge-sci-fi"]`, genres("test-tag-merge-002"))
+
+		_, err = tagRepo.FindByName("test-merge-sf")
+		assert.Error(t, err)
+	})
+}
+
+func TestTagRepository_Taxonomy(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	database := setupTestDB(t)
+	tagRepo := tag.NewTagRepository(database)
+
+	// Clean up test data
+	cleanup := func() {
+		database.DB.Exec("DELETE FROM tags WHERE name LIKE ?", "test-taxonomy-%")
+	}
+	cleanup()
+	defer cleanup()
+
+	fantasy, err := tagRepo.FindOrCreate("test-taxonomy-fantasy")
+	require.NoError(t, err)
+	isekai, err := tagRepo.FindOrCreate("test-taxonomy-isekai")
+	require.NoError(t, err)
+	_, err = tagRepo.FindOrCreate("test-taxonomy-twist")
+	require.NoError(t, err)
+
+	t.Run("UpdateSetsOnlyGivenColumns", func(t *testing.T) {
+		theme := tag.CategoryTheme
+		updated, err := tagRepo.Update("test-taxonomy-isekai", tag.Tag{Category: &theme, ParentID: &fantasy.ID, IsSpoiler: true}, []string{"category", "parent_id"})
+		require.NoError(t, err)
+		require.NotNil(t, updated.Category)
+		assert.Equal(t, tag.CategoryTheme, *updated.Category)
+		require.NotNil(t, updated.ParentID)
+		assert.Equal(t, fantasy.ID, *updated.ParentID)
+		assert.False(t, updated.IsSpoiler)
+
+		children, err := tagRepo.FindChildren([]int64{fantasy.ID})
+		require.NoError(t, err)
+		require.Len(t, children[fantasy.ID], 1)
+		assert.Equal(t, isekai.ID, children[fantasy.ID][0].ID)
+	})
+
+	t.Run("UpdateRejectsCycles", func(t *testing.T) {
+		_, err := tagRepo.Update("test-taxonomy-fantasy", tag.Tag{ParentID: &isekai.ID}, []string{"parent_id"})
+		assert.ErrorIs(t, err, tag.ErrTagCycle)
+	})
+
+	t.Run("ListHidesSpoilers", func(t *testing.T) {
+		_, err := tagRepo.Update("test-taxonomy-twist", tag.Tag{IsSpoiler: true}, []string{"is_spoiler"})
+		require.NoError(t, err)
+
+		tags, err := tagRepo.ListWithCounts(tag.TagFilter{Prefix: "test-taxonomy-"}, 10)
+		require.NoError(t, err)
+		assert.Len(t, tags, 2)
+
+		tags, err = tagRepo.ListWithCounts(tag.TagFilter{Prefix: "test-taxonomy-", IncludeSpoilers: true}, 10)
+		require.NoError(t, err)
+		assert.Len(t, tags, 3)
+
+		tags, err = tagRepo.ListWithCounts(tag.TagFilter{Prefix: "test-taxonomy-", Category: tag.CategoryTheme}, 10)
+		require.NoError(t, err)
+		require.Len(t, tags, 1)
+		assert.Equal(t, isekai.ID, tags[0].ID)
+	})
+
+	t.Run("MergeIntoGrandchildKeepsTreeAcyclic", func(t *testing.T) {
+		// root -> source -> child -> target, then source is merged into target
+		root, err := tagRepo.FindOrCreate("test-taxonomy-merge-root")
+		require.NoError(t, err)
+		source, err := tagRepo.FindOrCreate("test-taxonomy-merge-source")
+		require.NoError(t, err)
+		child, err := tagRepo.FindOrCreate("test-taxonomy-merge-child")
+		require.NoError(t, err)
+		_, err = tagRepo.FindOrCreate("test-taxonomy-merge-target")
+		require.NoError(t, err)
+		_, err = tagRepo.Update("test-taxonomy-merge-source", tag.Tag{ParentID: &root.ID}, []string{"parent_id"})
+		require.NoError(t, err)
+		_, err = tagRepo.Update("test-taxonomy-merge-child", tag.Tag{ParentID: &source.ID}, []string{"parent_id"})
+		require.NoError(t, err)
+		_, err = tagRepo.Update("test-taxonomy-merge-target", tag.Tag{ParentID: &child.ID}, []string{"parent_id"})
+		require.NoError(t, err)
+
+		merged, err := tagRepo.Merge([]string{"test-taxonomy-merge-source"}, "test-taxonomy-merge-target")
+		require.NoError(t, err)
+		require.NotNil(t, merged.ParentID)
+		assert.Equal(t, root.ID, *merged.ParentID)
+
+		movedChild, err := tagRepo.FindByName("test-taxonomy-merge-child")
+		require.NoError(t, err)
+		require.NotNil(t, movedChild.ParentID)
+		assert.Equal(t, merged.ID, *movedChild.ParentID)
+	})
+}
@@ -3,5 +3,7 @@ package directives
 import "github.com/weeb-vip/anime-api/graph/generated"
 
 func GetDirectives() generated.DirectiveRoot {
-	return generated.DirectiveRoot{}
+	return generated.DirectiveRoot{
+		Scoped: Scoped,
+	}
 }
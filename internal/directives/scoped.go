@@ -0,0 +1,29 @@
+package directives
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/weeb-vip/anime-api/internal/auth"
+)
+
+// Scoped only resolves a field when the request's token was granted scope
+func Scoped(ctx context.Context, obj interface{}, next graphql.Resolver, scope string) (interface{}, error) {
+	claims := auth.ClaimsFromContext(ctx)
+	if claims == nil {
+		return nil, &gqlerror.Error{
+			Path:       graphql.GetPath(ctx),
+			Message:    "authentication required",
+			Extensions: map[string]interface{}{"code": "UNAUTHENTICATED"},
+		}
+	}
+	if !claims.HasScope(scope) {
+		return nil, &gqlerror.Error{
+			Path:       graphql.GetPath(ctx),
+			Message:    "missing required scope: " + scope,
+			Extensions: map[string]interface{}{"code": "FORBIDDEN", "scope": scope},
+		}
+	}
+	return next(ctx)
+}
@@ -0,0 +1,54 @@
+package directives
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/weeb-vip/anime-api/internal/auth"
+)
+
+func TestScoped(t *testing.T) {
+	next := func(ctx context.Context) (interface{}, error) {
+		return "resolved", nil
+	}
+
+	tests := []struct {
+		name     string
+		claims   *auth.Claims
+		wantCode string
+	}{
+		{name: "anonymous", claims: nil, wantCode: "UNAUTHENTICATED"},
+		{name: "missing scope", claims: &auth.Claims{Scopes: []string{"anime:read"}}, wantCode: "FORBIDDEN"},
+		{name: "granted scope", claims: &auth.Claims{Scopes: []string{"anime:read", "anime:write"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.claims != nil {
+				ctx = auth.WithClaims(ctx, tt.claims)
+			}
+
+			result, err := Scoped(ctx, nil, next, "anime:write")
+			if tt.wantCode == "" {
+				if err != nil || result != "resolved" {
+					t.Fatalf("Expected field to resolve, got %v, %v", result, err)
+				}
+				return
+			}
+
+			var gqlErr *gqlerror.Error
+			if !errors.As(err, &gqlErr) {
+				t.Fatalf("Expected a GraphQL error, got %v", err)
+			}
+			if gqlErr.Extensions["code"] != tt.wantCode {
+				t.Errorf("Expected code %s, got %v", tt.wantCode, gqlErr.Extensions["code"])
+			}
+			if result != nil {
+				t.Errorf("Expected no result, got %v", result)
+			}
+		})
+	}
+}
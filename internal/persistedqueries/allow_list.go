@@ -0,0 +1,67 @@
+package persistedqueries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const errOperationNotAllowedCode = "OPERATION_NOT_ALLOWED"
+
+// AllowList only runs operations in the manifest. Clients send the hash in the
+// persistedQuery extension, as with automatic persisted queries; any query text they
+// send must be the registered operation.
+type AllowList struct {
+	Manifest Manifest
+}
+
+var _ interface {
+	graphql.OperationParameterMutator
+	graphql.HandlerExtension
+} = AllowList{}
+
+func (a AllowList) ExtensionName() string {
+	return "PersistedQueryAllowList"
+}
+
+func (a AllowList) Validate(schema graphql.ExecutableSchema) error {
+	if len(a.Manifest) == 0 {
+		return fmt.Errorf("PersistedQueryAllowList.Manifest can not be empty")
+	}
+	return nil
+}
+
+func (a AllowList) MutateOperationParameters(ctx context.Context, rawParams *graphql.RawParams) *gqlerror.Error {
+	hash := persistedQueryHash(rawParams.Extensions)
+	if hash == "" {
+		return notAllowedError("operations must be sent as persisted queries")
+	}
+	query, ok := a.Manifest[hash]
+	if !ok {
+		return notAllowedError("persisted query %s is not registered", hash)
+	}
+	if rawParams.Query != "" && rawParams.Query != query {
+		return notAllowedError("query does not match persisted query %s", hash)
+	}
+	rawParams.Query = query
+	return nil
+}
+
+// persistedQueryHash returns the sha256Hash of the persistedQuery extension, or ""
+func persistedQueryHash(extensions map[string]interface{}) string {
+	persistedQuery, ok := extensions["persistedQuery"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	hash, _ := persistedQuery["sha256Hash"].(string)
+	return hash
+}
+
+func notAllowedError(format string, args ...interface{}) *gqlerror.Error {
+	err := gqlerror.Errorf(format, args...)
+	errcode.Set(err, errOperationNotAllowedCode)
+	return err
+}
@@ -0,0 +1,35 @@
+package persistedqueries
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/weeb-vip/anime-api/config"
+	"github.com/weeb-vip/anime-api/internal/cache"
+)
+
+// NewExtension returns the handler extension resolving persisted queries: the allow
+// list when only manifest operations are accepted, and automatic persisted queries
+// stored in cacheInstance otherwise.
+func NewExtension(conf config.GraphQLConfig, cacheInstance cache.Cache) (graphql.HandlerExtension, error) {
+	var manifest Manifest
+	if conf.PersistedQueriesFile != "" {
+		var err error
+		manifest, err = LoadManifest(conf.PersistedQueriesFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if conf.PersistedQueriesOnly {
+		if len(manifest) == 0 {
+			return nil, fmt.Errorf("persisted queries only mode needs a manifest with operations")
+		}
+		return AllowList{Manifest: manifest}, nil
+	}
+
+	ttl := time.Duration(conf.APQTTLMinutes) * time.Minute
+	return extension.AutomaticPersistedQuery{Cache: NewStore(cacheInstance, ttl, manifest)}, nil
+}
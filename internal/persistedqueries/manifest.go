@@ -0,0 +1,52 @@
+// Package persistedqueries serves GraphQL operations by hash: automatic persisted
+// queries registered by clients, and operations pre-registered in a manifest.
+package persistedqueries
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Manifest maps the sha256 hash clients send to the operation it stands for
+type Manifest map[string]string
+
+// apolloManifest is the format written by Apollo's persisted query tooling
+type apolloManifest struct {
+	Format     string `json:"format"`
+	Operations []struct {
+		ID   string `json:"id"`
+		Body string `json:"body"`
+	} `json:"operations"`
+}
+
+// LoadManifest reads a manifest file. Both Apollo's persisted query manifest and a
+// plain JSON object of hash to operation are accepted.
+func LoadManifest(path string) (Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read persisted query manifest: %w", err)
+	}
+	return ParseManifest(data)
+}
+
+// ParseManifest parses the contents of a manifest file
+func ParseManifest(data []byte) (Manifest, error) {
+	var apollo apolloManifest
+	if err := json.Unmarshal(data, &apollo); err == nil && apollo.Format != "" {
+		manifest := make(Manifest, len(apollo.Operations))
+		for _, operation := range apollo.Operations {
+			if operation.ID == "" || operation.Body == "" {
+				return nil, fmt.Errorf("persisted query manifest has an operation without id or body")
+			}
+			manifest[operation.ID] = operation.Body
+		}
+		return manifest, nil
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse persisted query manifest: %w", err)
+	}
+	return manifest, nil
+}
@@ -0,0 +1,89 @@
+package persistedqueries
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/weeb-vip/anime-api/internal/cache"
+)
+
+const (
+	seasonQuery = "query AnimeBySeasons($season: Season!) { animeBySeasons(season: $season) { id } }"
+	seasonHash  = "4c0f5b8a"
+)
+
+func TestParseManifest(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"apollo", `{"format":"apollo-persisted-query-manifest","version":1,"operations":[{"id":"4c0f5b8a","name":"AnimeBySeasons","type":"query","body":"` + seasonQuery + `"}]}`},
+		{"hash to query", `{"4c0f5b8a":"` + seasonQuery + `"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest, err := ParseManifest([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(manifest) != 1 || manifest[seasonHash] != seasonQuery {
+				t.Errorf("unexpected manifest: %v", manifest)
+			}
+		})
+	}
+
+	if _, err := ParseManifest([]byte(`["not", "a", "manifest"]`)); err == nil {
+		t.Error("expected an error for a list")
+	}
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore(cache.NewMemoryCache(10), time.Hour, Manifest{seasonHash: seasonQuery})
+
+	if _, ok := store.Get(ctx, "unknown"); ok {
+		t.Error("expected a miss for an unregistered hash")
+	}
+
+	store.Add(ctx, "abc", "{ apiInfo { name } }")
+	if query, ok := store.Get(ctx, "abc"); !ok || query != "{ apiInfo { name } }" {
+		t.Errorf("expected the registered query, got %v, %v", query, ok)
+	}
+
+	// manifest operations cannot be replaced by clients
+	store.Add(ctx, seasonHash, "{ apiInfo { name } }")
+	if query, _ := store.Get(ctx, seasonHash); query != seasonQuery {
+		t.Errorf("expected the manifest operation, got %v", query)
+	}
+}
+
+func TestAllowList(t *testing.T) {
+	ctx := context.Background()
+	allowList := AllowList{Manifest: Manifest{seasonHash: seasonQuery}}
+	persisted := func(hash string) map[string]interface{} {
+		return map[string]interface{}{"persistedQuery": map[string]interface{}{"version": float64(1), "sha256Hash": hash}}
+	}
+
+	params := &graphql.RawParams{Extensions: persisted(seasonHash)}
+	if err := allowList.MutateOperationParameters(ctx, params); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if params.Query != seasonQuery {
+		t.Errorf("expected the manifest operation, got %q", params.Query)
+	}
+
+	rejected := []*graphql.RawParams{
+		{Query: seasonQuery},
+		{Extensions: persisted("unknown")},
+		{Query: "{ apiInfo { name } }", Extensions: persisted(seasonHash)},
+	}
+	for _, params := range rejected {
+		err := allowList.MutateOperationParameters(ctx, params)
+		if err == nil || err.Extensions["code"] != errOperationNotAllowedCode {
+			t.Errorf("expected %+v to be rejected, got %v", params, err)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package persistedqueries
+
+import (
+	"context"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/weeb-vip/anime-api/internal/cache"
+	"github.com/weeb-vip/anime-api/internal/logger"
+)
+
+// Store keeps queries registered through automatic persisted queries in a cache,
+// shared between replicas when that cache is Redis. Operations in the manifest are
+// always found and never written.
+type Store struct {
+	cache      cache.Cache
+	keyBuilder *cache.CacheKeyBuilder
+	ttl        time.Duration
+	manifest   Manifest
+}
+
+var _ graphql.Cache = &Store{}
+
+// NewStore creates a store over cacheInstance; manifest may be nil
+func NewStore(cacheInstance cache.Cache, ttl time.Duration, manifest Manifest) *Store {
+	return &Store{
+		cache:      cacheInstance,
+		keyBuilder: cache.GetKeyBuilder(),
+		ttl:        ttl,
+		manifest:   manifest,
+	}
+}
+
+// Get looks up the query registered for hash
+func (s *Store) Get(ctx context.Context, hash string) (interface{}, bool) {
+	if query, ok := s.manifest[hash]; ok {
+		return query, true
+	}
+	value, err := s.cache.Get(ctx, s.keyBuilder.PersistedQuery(hash))
+	if err != nil {
+		return nil, false
+	}
+	return string(value), true
+}
+
+// Add registers query under hash. The APQ extension has checked the hash already.
+func (s *Store) Add(ctx context.Context, hash string, query interface{}) {
+	if _, ok := s.manifest[hash]; ok {
+		return
+	}
+	text, ok := query.(string)
+	if !ok {
+		return
+	}
+	if err := s.cache.Set(ctx, s.keyBuilder.PersistedQuery(hash), []byte(text), s.ttl); err != nil {
+		log := logger.FromCtx(ctx)
+		log.Warn().Err(err).Str("hash", hash).Msg("Failed to store persisted query")
+	}
+}
@@ -0,0 +1,70 @@
+// Package pubsub fans messages out to subscribers in this process, and with
+// RedisBus to the subscribers of every replica.
+package pubsub
+
+import (
+	"context"
+	"sync"
+)
+
+// subscriberBuffer is how far a slow subscriber may fall behind before messages to
+// it are dropped, so one stalled client cannot hold up the others
+const subscriberBuffer = 16
+
+// Bus delivers the messages published on a channel to that channel's subscribers
+type Bus interface {
+	Publish(ctx context.Context, channel string, payload []byte) error
+	// Subscribe returns the messages published on channel from now on. The
+	// returned channel is closed once ctx is done.
+	Subscribe(ctx context.Context, channel string) <-chan []byte
+}
+
+// LocalBus is a Bus within a single process
+type LocalBus struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[chan []byte]struct{}
+}
+
+func NewLocalBus() *LocalBus {
+	return &LocalBus{subscribers: make(map[string]map[chan []byte]struct{})}
+}
+
+func (b *LocalBus) Publish(ctx context.Context, channel string, payload []byte) error {
+	b.deliver(channel, payload)
+	return nil
+}
+
+func (b *LocalBus) Subscribe(ctx context.Context, channel string) <-chan []byte {
+	messages := make(chan []byte, subscriberBuffer)
+
+	b.mu.Lock()
+	if b.subscribers[channel] == nil {
+		b.subscribers[channel] = make(map[chan []byte]struct{})
+	}
+	b.subscribers[channel][messages] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subscribers[channel], messages)
+		if len(b.subscribers[channel]) == 0 {
+			delete(b.subscribers, channel)
+		}
+		close(messages)
+	}()
+
+	return messages
+}
+
+func (b *LocalBus) deliver(channel string, payload []byte) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for messages := range b.subscribers[channel] {
+		select {
+		case messages <- payload:
+		default:
+		}
+	}
+}
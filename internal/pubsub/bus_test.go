@@ -0,0 +1,72 @@
+package pubsub
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func receive(t *testing.T, messages <-chan []byte) ([]byte, bool) {
+	t.Helper()
+	select {
+	case message, ok := <-messages:
+		return message, ok
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for a message")
+		return nil, false
+	}
+}
+
+func TestLocalBusDeliversToChannelSubscribers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bus := NewLocalBus()
+	first := bus.Subscribe(ctx, "airing")
+	second := bus.Subscribe(ctx, "airing")
+	other := bus.Subscribe(ctx, "other")
+
+	if err := bus.Publish(ctx, "airing", []byte("hello")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, messages := range []<-chan []byte{first, second} {
+		if message, _ := receive(t, messages); string(message) != "hello" {
+			t.Errorf("got %q, want hello", message)
+		}
+	}
+	select {
+	case message := <-other:
+		t.Errorf("other channel received %q", message)
+	default:
+	}
+}
+
+func TestLocalBusClosesSubscriptionWhenDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	bus := NewLocalBus()
+	messages := bus.Subscribe(ctx, "airing")
+
+	cancel()
+	if _, ok := receive(t, messages); ok {
+		t.Fatal("expected the subscription to be closed")
+	}
+	// Publishing after the subscriber left must not block or panic
+	if err := bus.Publish(context.Background(), "airing", []byte("late")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestLocalBusDropsMessagesForSlowSubscribers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bus := NewLocalBus()
+	messages := bus.Subscribe(ctx, "airing")
+	for i := 0; i < subscriberBuffer+5; i++ {
+		_ = bus.Publish(ctx, "airing", []byte("tick"))
+	}
+	if len(messages) != subscriberBuffer {
+		t.Errorf("buffered %d messages, want %d", len(messages), subscriberBuffer)
+	}
+}
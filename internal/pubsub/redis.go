@@ -0,0 +1,59 @@
+package pubsub
+
+import (
+	"context"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/weeb-vip/anime-api/internal/logger"
+)
+
+// redisChannelPrefix namespaces the Redis pub/sub channels of this service
+const redisChannelPrefix = "anime-api:events:"
+
+// RedisBus publishes through Redis, so a message published on any replica reaches
+// the subscribers of all of them
+type RedisBus struct {
+	client *redis.Client
+	local  *LocalBus
+}
+
+// NewRedisBus returns a bus relaying through client. It listens for messages until
+// ctx is done; go-redis reconnects the subscription when the connection drops.
+func NewRedisBus(ctx context.Context, client *redis.Client) *RedisBus {
+	b := &RedisBus{
+		client: client,
+		local:  NewLocalBus(),
+	}
+	go b.listen(ctx)
+	return b
+}
+
+func (b *RedisBus) Publish(ctx context.Context, channel string, payload []byte) error {
+	return b.client.Publish(ctx, redisChannelPrefix+channel, payload).Err()
+}
+
+func (b *RedisBus) Subscribe(ctx context.Context, channel string) <-chan []byte {
+	return b.local.Subscribe(ctx, channel)
+}
+
+func (b *RedisBus) listen(ctx context.Context) {
+	subscription := b.client.PSubscribe(ctx, redisChannelPrefix+"*")
+	defer subscription.Close()
+
+	log := logger.FromCtx(ctx)
+	log.Info().Str("pattern", redisChannelPrefix+"*").Msg("Listening for events on Redis")
+
+	messages := subscription.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case message, ok := <-messages:
+			if !ok {
+				return
+			}
+			b.local.deliver(strings.TrimPrefix(message.Channel, redisChannelPrefix), []byte(message.Payload))
+		}
+	}
+}
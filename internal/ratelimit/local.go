@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// LocalBuckets keeps buckets in process, for when Redis is disabled. Each replica
+// then limits clients on its own.
+type LocalBuckets struct {
+	mu      sync.Mutex
+	buckets *lru.Cache[string, *bucket]
+	now     func() time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewLocalBuckets keeps buckets for up to clients clients
+func NewLocalBuckets(clients int) *LocalBuckets {
+	if clients <= 0 {
+		clients = defaultLocalClients
+	}
+	buckets, _ := lru.New[string, *bucket](clients)
+	return &LocalBuckets{buckets: buckets, now: time.Now}
+}
+
+// Take takes a token from the client's bucket
+func (b *LocalBuckets) Take(ctx context.Context, client string, limit Limit) (Decision, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	current, ok := b.buckets.Get(client)
+	if !ok {
+		current = &bucket{tokens: float64(limit.Burst), last: now}
+		b.buckets.Add(client, current)
+	}
+
+	elapsed := now.Sub(current.last).Seconds()
+	if elapsed > 0 {
+		current.tokens = math.Min(float64(limit.Burst), current.tokens+elapsed*limit.Rate)
+	}
+	current.last = now
+
+	if current.tokens >= 1 {
+		current.tokens--
+		return Decision{Allowed: true}, nil
+	}
+	wait := time.Duration(math.Ceil((1 - current.tokens) / limit.Rate * float64(time.Second)))
+	return Decision{RetryAfter: wait}, nil
+}
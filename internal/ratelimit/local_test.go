@@ -0,0 +1,41 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLocalBuckets(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	buckets := NewLocalBuckets(10)
+	buckets.now = func() time.Time { return now }
+	limit := Limit{Rate: 2, Burst: 3}
+
+	for i := 0; i < 3; i++ {
+		if decision, _ := buckets.Take(ctx, "ip:1", limit); !decision.Allowed {
+			t.Fatalf("request %d within the burst was rejected", i+1)
+		}
+	}
+
+	decision, _ := buckets.Take(ctx, "ip:1", limit)
+	if decision.Allowed || decision.RetryAfter != 500*time.Millisecond {
+		t.Errorf("expected a rejection for 500ms, got %+v", decision)
+	}
+
+	// other clients have their own bucket
+	if decision, _ := buckets.Take(ctx, "ip:2", limit); !decision.Allowed {
+		t.Error("expected another client to be allowed")
+	}
+
+	now = now.Add(time.Second)
+	for i := 0; i < 2; i++ {
+		if decision, _ := buckets.Take(ctx, "ip:1", limit); !decision.Allowed {
+			t.Fatalf("refilled request %d was rejected", i+1)
+		}
+	}
+	if decision, _ := buckets.Take(ctx, "ip:1", limit); decision.Allowed {
+		t.Error("expected only two tokens after a second")
+	}
+}
@@ -0,0 +1,56 @@
+// Package ratelimit keeps a token bucket per client: each request takes a token,
+// and tokens refill at a steady rate up to a burst.
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/weeb-vip/anime-api/internal/cache"
+)
+
+// defaultLocalClients bounds the buckets kept in memory; the least recently seen
+// clients are dropped first, which at worst hands them a full bucket again
+const defaultLocalClients = 10000
+
+// Limit is the refill rate, in tokens a second, and the most tokens a bucket holds
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Decision is the outcome of taking a token
+type Decision struct {
+	Allowed bool
+	// RetryAfter is how long until a token is available, when not allowed
+	RetryAfter time.Duration
+}
+
+// Buckets takes tokens from per-client buckets
+type Buckets interface {
+	Take(ctx context.Context, client string, limit Limit) (Decision, error)
+}
+
+// NewBuckets keeps buckets in Redis, shared by every replica, when cacheInstance is
+// Redis, and in process otherwise
+func NewBuckets(cacheInstance cache.Cache) Buckets {
+	if redisCache, ok := cacheInstance.(*cache.RedisCache); ok {
+		return &RedisBuckets{cache: redisCache, keyBuilder: cache.GetKeyBuilder()}
+	}
+	return NewLocalBuckets(defaultLocalClients)
+}
+
+// RedisBuckets keeps buckets in Redis
+type RedisBuckets struct {
+	cache      *cache.RedisCache
+	keyBuilder *cache.CacheKeyBuilder
+}
+
+// Take takes a token from the client's bucket
+func (b *RedisBuckets) Take(ctx context.Context, client string, limit Limit) (Decision, error) {
+	allowed, retryAfter, err := b.cache.TakeToken(ctx, b.keyBuilder.RateLimit(client), limit.Rate, limit.Burst)
+	if err != nil {
+		return Decision{}, err
+	}
+	return Decision{Allowed: allowed, RetryAfter: retryAfter}, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/weeb-vip/anime-api/graph/model"
 	"github.com/weeb-vip/anime-api/internal/cache"
 	anime2 "github.com/weeb-vip/anime-api/internal/db/repositories/anime"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/episode_delay"
 	"github.com/weeb-vip/anime-api/internal/services"
 	"github.com/weeb-vip/anime-api/internal/services/anime"
 	"github.com/weeb-vip/anime-api/metrics"
@@ -24,6 +25,8 @@ type CacheServiceInterface interface {
 	SetJSON(ctx context.Context, key string, value interface{}, ttl time.Duration) error
 	GetKeyBuilder() *cache.CacheKeyBuilder
 	GetCurrentlyAiringTTL() time.Duration
+	GetSearchSuggestionTTL() time.Duration
+	GetSharedCastTTL() time.Duration
 }
 
 func transformAnimeToGraphQL(animeEntity anime2.Anime) (*model.Anime, error) {
@@ -452,7 +455,7 @@ func NewestAnime(ctx context.Context, animeService anime.AnimeServiceImpl, limit
 // CurrentlyAiring resolver with caching support
 // Caches results based on input parameters and limit to improve performance
 // Cache TTL is configured as half of episode TTL for optimal freshness
-func CurrentlyAiring(ctx context.Context, animeService anime.AnimeServiceImpl, input *model.CurrentlyAiringInput, limit *int, cacheService CacheServiceInterface) ([]*model.Anime, error) {
+func CurrentlyAiring(ctx context.Context, animeService anime.AnimeServiceImpl, episodeDelayRepository episode_delay.EpisodeDelayRepositoryImpl, input *model.CurrentlyAiringInput, limit *int, cacheService CacheServiceInterface) ([]*model.Anime, error) {
 	startTime := time.Now()
 
 	// Default limit to 10 if not specified
@@ -542,6 +545,24 @@ func CurrentlyAiring(ctx context.Context, animeService anime.AnimeServiceImpl, i
 	transformSpan.SetAttributes(attribute.Int("results.count", len(animes)))
 	transformSpan.SetStatus(codes.Ok, "transformation completed")
 
+	// Delayed episodes air at their rescheduled time, or not at all until one is known
+	if episodeDelayRepository != nil && len(animes) > 0 {
+		animeIDs := make([]string, 0, len(animes))
+		for _, animeModel := range animes {
+			animeIDs = append(animeIDs, animeModel.ID)
+		}
+		delays, err := episodeDelayRepository.FindByAnimeIDs(animeIDs)
+		if err != nil {
+			metrics.GetAppMetrics().ResolverMetric(
+				float64(time.Since(startTime).Milliseconds()),
+				"CurrentlyAiring",
+				metrics.Error,
+			)
+			return nil, err
+		}
+		attachEpisodeDelays(animes, delays)
+	}
+
 	// Determine query date range for episode filtering
 	var queryStartDate, queryEndDate *time.Time
 	if input != nil {
@@ -635,11 +656,21 @@ func parseDurationString(duration string) int {
 	return minutes
 }
 
-func DBSearchAnime(ctx context.Context, animeService anime.AnimeServiceImpl, query string, page int, limit int) ([]*model.Anime, error) {
+func DBSearchAnime(ctx context.Context, animeService anime.AnimeServiceImpl, searchQuery model.AnimeSearchInput) ([]*model.Anime, error) {
 	startTime := time.Now()
 
+	filter, err := searchFilterFromInput(searchQuery)
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(
+			float64(time.Since(startTime).Milliseconds()),
+			"DBSearchAnime",
+			metrics.Error,
+		)
+		return nil, err
+	}
+
 	// Use WithEpisodes version to preload episodes and avoid N+1 queries
-	foundAnime, err := animeService.SearchedAnimeWithEpisodes(ctx, query, page, limit)
+	foundAnime, err := animeService.SearchedAnimeFilteredWithEpisodes(ctx, filter, searchQuery.Page, searchQuery.PerPage)
 	if err != nil {
 		metrics.GetAppMetrics().ResolverMetric(
 			float64(time.Since(startTime).Milliseconds()),
@@ -666,3 +697,28 @@ func DBSearchAnime(ctx context.Context, animeService anime.AnimeServiceImpl, que
 
 	return animes, nil
 }
+
+// searchFilterFromInput converts the dbSearch input into a repository filter,
+// rejecting unknown sort keys and directions
+func searchFilterFromInput(searchQuery model.AnimeSearchInput) (anime2.SearchFilter, error) {
+	var sortBy, sortDirection string
+	if searchQuery.SortBy != nil {
+		sortBy = *searchQuery.SortBy
+	}
+	if searchQuery.SortDirection != nil {
+		sortDirection = *searchQuery.SortDirection
+	}
+
+	searchSort, err := anime2.ParseSearchSort(sortBy, sortDirection)
+	if err != nil {
+		return anime2.SearchFilter{}, err
+	}
+
+	return anime2.SearchFilter{
+		Query:    searchQuery.Query,
+		Tags:     searchQuery.Tags,
+		Studios:  searchQuery.Studios,
+		Statuses: searchQuery.AnimeStatuses,
+		Sort:     searchSort,
+	}, nil
+}
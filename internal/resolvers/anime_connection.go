@@ -0,0 +1,236 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/weeb-vip/anime-api/graph/model"
+	anime2 "github.com/weeb-vip/anime-api/internal/db/repositories/anime"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/episode_delay"
+	"github.com/weeb-vip/anime-api/internal/services/anime"
+	"github.com/weeb-vip/anime-api/metrics"
+)
+
+const (
+	defaultConnectionSize = 20
+	maxConnectionSize     = 100
+	// currentlyAiringConnectionLimit bounds the airing list a connection pages through
+	currentlyAiringConnectionLimit = 500
+)
+
+func NewestAnimeConnection(ctx context.Context, animeService anime.AnimeServiceImpl, first *int, after *string) (*model.AnimeConnection, error) {
+	return animeConnection(ctx, animeService, "NewestAnimeConnection", anime2.SearchFilter{Sort: anime2.SortNewest}, first, after)
+}
+
+func TopRatedAnimeConnection(ctx context.Context, animeService anime.AnimeServiceImpl, first *int, after *string) (*model.AnimeConnection, error) {
+	return animeConnection(ctx, animeService, "TopRatedAnimeConnection", anime2.SearchFilter{Sort: anime2.SortTopRated}, first, after)
+}
+
+func MostPopularAnimeConnection(ctx context.Context, animeService anime.AnimeServiceImpl, first *int, after *string) (*model.AnimeConnection, error) {
+	return animeConnection(ctx, animeService, "MostPopularAnimeConnection", anime2.SearchFilter{Sort: anime2.SortMostPopular}, first, after)
+}
+
+func AnimeBySeasonsConnection(ctx context.Context, animeService anime.AnimeServiceImpl, season string, first *int, after *string) (*model.AnimeConnection, error) {
+	return animeConnection(ctx, animeService, "AnimeBySeasonsConnection", anime2.SearchFilter{Season: season, Sort: anime2.SortByRanking}, first, after)
+}
+
+func DBSearchConnection(ctx context.Context, animeService anime.AnimeServiceImpl, input model.AnimeSearchFilterInput, first *int, after *string) (*model.AnimeConnection, error) {
+	searchQuery := model.AnimeSearchInput{
+		SortBy:        input.SortBy,
+		SortDirection: input.SortDirection,
+		Tags:          input.Tags,
+		Studios:       input.Studios,
+		AnimeStatuses: input.AnimeStatuses,
+	}
+	if input.Query != nil {
+		searchQuery.Query = *input.Query
+	}
+
+	filter, err := searchFilterFromInput(searchQuery)
+	if err != nil {
+		return nil, inputError("filter", "%s", err.Error())
+	}
+	return animeConnection(ctx, animeService, "DBSearchConnection", filter, first, after)
+}
+
+// CurrentlyAiringConnection pages through the cached currently airing list. That list
+// is ordered by next episode in memory, so it is sliced after the cursor's anime
+// instead of being sought in the database.
+func CurrentlyAiringConnection(ctx context.Context, animeService anime.AnimeServiceImpl, episodeDelayRepository episode_delay.EpisodeDelayRepositoryImpl, input *model.CurrentlyAiringInput, first *int, after *string, cacheService CacheServiceInterface) (*model.AnimeConnection, error) {
+	startTime := time.Now()
+
+	size, cursor, err := connectionArgs(first, after)
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "CurrentlyAiringConnection", metrics.Error)
+		return nil, err
+	}
+
+	limit := currentlyAiringConnectionLimit
+	animes, err := CurrentlyAiring(ctx, animeService, episodeDelayRepository, input, &limit, cacheService)
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "CurrentlyAiringConnection", metrics.Error)
+		return nil, err
+	}
+
+	start := 0
+	if cursor != nil {
+		start = -1
+		for i, animeModel := range animes {
+			if animeModel.ID == cursor.ID {
+				start = i + 1
+				break
+			}
+		}
+		if start < 0 {
+			metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "CurrentlyAiringConnection", metrics.Error)
+			return nil, inputError("after", "after no longer points into the currently airing list")
+		}
+	}
+
+	end := start + size
+	if end > len(animes) {
+		end = len(animes)
+	}
+	connection := newAnimeConnection(animes[start:end], end < len(animes), cursor != nil, func(animeModel *model.Anime) anime2.Cursor {
+		return anime2.Cursor{ID: animeModel.ID}
+	})
+	connection.TotalCount = len(animes)
+
+	metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "CurrentlyAiringConnection", metrics.Success)
+
+	return connection, nil
+}
+
+// animeConnection loads the page of filter that follows after, fetching one extra
+// anime to tell whether another page exists
+func animeConnection(ctx context.Context, animeService anime.AnimeServiceImpl, name string, filter anime2.SearchFilter, first *int, after *string) (*model.AnimeConnection, error) {
+	startTime := time.Now()
+
+	connection, err := loadAnimeConnection(ctx, animeService, filter, first, after)
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), name, metrics.Error)
+		return nil, err
+	}
+
+	metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), name, metrics.Success)
+
+	return connection, nil
+}
+
+func loadAnimeConnection(ctx context.Context, animeService anime.AnimeServiceImpl, filter anime2.SearchFilter, first *int, after *string) (*model.AnimeConnection, error) {
+	size, cursor, err := connectionArgs(first, after)
+	if err != nil {
+		return nil, err
+	}
+
+	totalCountRequested, episodesRequested := connectionSelection(ctx)
+
+	foundAnime, err := animeService.AnimePage(ctx, filter, cursor, size+1, episodesRequested)
+	if errors.Is(err, anime2.ErrInvalidCursor) {
+		return nil, inputError("after", "after is not a cursor of this query")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	hasNextPage := len(foundAnime) > size
+	if hasNextPage {
+		foundAnime = foundAnime[:size]
+	}
+
+	animes := make([]*model.Anime, 0, len(foundAnime))
+	cursors := make(map[string]anime2.Cursor, len(foundAnime))
+	for _, animeEntity := range foundAnime {
+		animeModel, err := transformAnimeToGraphQL(*animeEntity)
+		if err != nil {
+			return nil, err
+		}
+		animes = append(animes, animeModel)
+		cursors[animeEntity.ID] = filter.Sort.CursorFor(animeEntity)
+	}
+
+	connection := newAnimeConnection(animes, hasNextPage, cursor != nil, func(animeModel *model.Anime) anime2.Cursor {
+		return cursors[animeModel.ID]
+	})
+
+	if totalCountRequested {
+		count, err := animeService.CountAnime(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		connection.TotalCount = int(count)
+	}
+
+	return connection, nil
+}
+
+func newAnimeConnection(animes []*model.Anime, hasNextPage bool, hasPreviousPage bool, cursorFor func(*model.Anime) anime2.Cursor) *model.AnimeConnection {
+	connection := &model.AnimeConnection{
+		Edges: make([]*model.AnimeEdge, 0, len(animes)),
+		PageInfo: &model.PageInfo{
+			HasNextPage:     hasNextPage,
+			HasPreviousPage: hasPreviousPage,
+		},
+	}
+	for _, animeModel := range animes {
+		connection.Edges = append(connection.Edges, &model.AnimeEdge{
+			Cursor: cursorFor(animeModel).Encode(),
+			Node:   animeModel,
+		})
+	}
+	if len(connection.Edges) > 0 {
+		connection.PageInfo.StartCursor = &connection.Edges[0].Cursor
+		connection.PageInfo.EndCursor = &connection.Edges[len(connection.Edges)-1].Cursor
+	}
+	return connection
+}
+
+// connectionArgs validates first and decodes after
+func connectionArgs(first *int, after *string) (int, *anime2.Cursor, error) {
+	size := defaultConnectionSize
+	if first != nil {
+		if *first < 0 || *first > maxConnectionSize {
+			return 0, nil, inputError("first", "first must be between 0 and %d", maxConnectionSize)
+		}
+		size = *first
+	}
+
+	if after == nil || *after == "" {
+		return size, nil, nil
+	}
+	cursor, err := anime2.DecodeCursor(*after)
+	if err != nil {
+		return 0, nil, inputError("after", "after is not a valid cursor")
+	}
+	return size, &cursor, nil
+}
+
+// connectionSelection reports whether the connection's totalCount and its nodes'
+// episodes are selected, so neither is loaded when not asked for
+func connectionSelection(ctx context.Context) (totalCount bool, episodes bool) {
+	if !graphql.HasOperationContext(ctx) || graphql.GetFieldContext(ctx) == nil {
+		return false, false
+	}
+
+	opCtx := graphql.GetOperationContext(ctx)
+	for _, field := range graphql.CollectFieldsCtx(ctx, nil) {
+		switch field.Name {
+		case "totalCount":
+			totalCount = true
+		case "edges":
+			for _, edgeField := range graphql.CollectFields(opCtx, field.Selections, nil) {
+				if edgeField.Name != "node" {
+					continue
+				}
+				for _, nodeField := range graphql.CollectFields(opCtx, edgeField.Selections, nil) {
+					if nodeField.Name == "episodes" {
+						episodes = true
+					}
+				}
+			}
+		}
+	}
+	return totalCount, episodes
+}
@@ -0,0 +1,87 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/weeb-vip/anime-api/graph/model"
+	anime_repo "github.com/weeb-vip/anime-api/internal/db/repositories/anime"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTopRatedAnimeConnection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAnimeService := NewMockAnimeService(ctrl)
+	ctx := context.Background()
+	filter := anime_repo.SearchFilter{Sort: anime_repo.SortTopRated}
+	ratings := []float64{9.1, 8.9, 8.7}
+
+	page := make([]*anime_repo.Anime, len(ratings))
+	for i := range ratings {
+		page[i] = &anime_repo.Anime{ID: []string{"a", "b", "c"}[i], Rating: &ratings[i]}
+	}
+
+	t.Run("first page fetches one extra anime", func(t *testing.T) {
+		mockAnimeService.EXPECT().AnimePage(ctx, filter, nil, 3, false).Return(page, nil)
+
+		first := 2
+		connection, err := TopRatedAnimeConnection(ctx, mockAnimeService, &first, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(connection.Edges) != 2 || !connection.PageInfo.HasNextPage || connection.PageInfo.HasPreviousPage {
+			t.Fatalf("unexpected connection: %+v %+v", connection.Edges, connection.PageInfo)
+		}
+		cursor, err := anime_repo.DecodeCursor(*connection.PageInfo.EndCursor)
+		if err != nil || cursor.ID != "b" || *cursor.Value != "8.9" {
+			t.Errorf("end cursor = %+v, %v", cursor, err)
+		}
+	})
+
+	t.Run("next page seeks after the cursor", func(t *testing.T) {
+		after := anime_repo.SortTopRated.CursorFor(page[1])
+		mockAnimeService.EXPECT().AnimePage(ctx, filter, &after, 3, false).Return(page[2:], nil)
+
+		first := 2
+		encoded := after.Encode()
+		connection, err := TopRatedAnimeConnection(ctx, mockAnimeService, &first, &encoded)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(connection.Edges) != 1 || connection.PageInfo.HasNextPage || !connection.PageInfo.HasPreviousPage {
+			t.Fatalf("unexpected connection: %+v %+v", connection.Edges, connection.PageInfo)
+		}
+	})
+
+	t.Run("rejects bad arguments", func(t *testing.T) {
+		tooMany := maxConnectionSize + 1
+		if _, err := TopRatedAnimeConnection(ctx, mockAnimeService, &tooMany, nil); errorCode(err) != "BAD_USER_INPUT" {
+			t.Errorf("expected BAD_USER_INPUT for first, got %v", err)
+		}
+		garbage := "not a cursor"
+		if _, err := TopRatedAnimeConnection(ctx, mockAnimeService, nil, &garbage); errorCode(err) != "BAD_USER_INPUT" {
+			t.Errorf("expected BAD_USER_INPUT for after, got %v", err)
+		}
+	})
+
+	t.Run("cursor of another query", func(t *testing.T) {
+		after := anime_repo.SortNewest.CursorFor(page[0])
+		mockAnimeService.EXPECT().AnimePage(ctx, filter, &after, defaultConnectionSize+1, false).Return(nil, anime_repo.ErrInvalidCursor)
+
+		encoded := after.Encode()
+		if _, err := TopRatedAnimeConnection(ctx, mockAnimeService, nil, &encoded); errorCode(err) != "BAD_USER_INPUT" {
+			t.Errorf("expected BAD_USER_INPUT, got %v", err)
+		}
+	})
+}
+
+func TestNewAnimeConnectionEmptyPage(t *testing.T) {
+	connection := newAnimeConnection([]*model.Anime{}, false, true, func(*model.Anime) anime_repo.Cursor {
+		return anime_repo.Cursor{}
+	})
+	if len(connection.Edges) != 0 || connection.PageInfo.StartCursor != nil || connection.PageInfo.EndCursor != nil {
+		t.Errorf("unexpected connection: %+v", connection.PageInfo)
+	}
+}
@@ -0,0 +1,323 @@
+package resolvers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/weeb-vip/anime-api/graph/model"
+	anime2 "github.com/weeb-vip/anime-api/internal/db/repositories/anime"
+	"github.com/weeb-vip/anime-api/internal/services/anime"
+	"github.com/weeb-vip/anime-api/metrics"
+	"gorm.io/gorm"
+)
+
+// CreateAnime validates the input and stores a new anime with its seasons
+func CreateAnime(ctx context.Context, animeService anime.AnimeServiceImpl, input model.CreateAnimeInput) (*model.Anime, error) {
+	startTime := time.Now()
+
+	animeEntity, seasons, err := animeEntityFromInput(input)
+	if err == nil && animeEntity.TitleEn == nil && animeEntity.TitleRomaji == nil && animeEntity.TitleJp == nil {
+		err = inputError("titleEn", "at least one of titleEn, titleRomaji or titleJp is required")
+	}
+	if err == nil {
+		err = animeService.CreateAnime(ctx, animeEntity, seasons)
+	}
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "CreateAnime", metrics.Error)
+		return nil, err
+	}
+
+	metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "CreateAnime", metrics.Success)
+
+	return transformAnimeToGraphQL(*animeEntity)
+}
+
+// UpdateAnime validates the input and writes only the fields it sets
+func UpdateAnime(ctx context.Context, animeService anime.AnimeServiceImpl, id string, input model.UpdateAnimeInput) (*model.Anime, error) {
+	startTime := time.Now()
+
+	values, columns, seasonsSet := updateInputValues(input)
+	animeEntity, seasons, err := animeEntityFromInput(values)
+	if err == nil {
+		if seasonsSet && seasons == nil {
+			// An explicit null clears the seasons
+			seasons = []anime2.Season{}
+		}
+		animeEntity.ID = id
+		err = requireTitleAfterUpdate(ctx, animeService, animeEntity, columns)
+	}
+	if err == nil {
+		err = animeService.UpdateAnime(ctx, animeEntity, columns, seasons)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = notFoundError("anime %s not found", id)
+		}
+	}
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "UpdateAnime", metrics.Error)
+		return nil, err
+	}
+
+	updated, err := animeService.AnimeByID(ctx, id)
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "UpdateAnime", metrics.Error)
+		return nil, err
+	}
+
+	metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "UpdateAnime", metrics.Success)
+
+	return transformAnimeToGraphQL(*updated)
+}
+
+// DeleteAnime removes an anime and everything stored for it
+func DeleteAnime(ctx context.Context, animeService anime.AnimeServiceImpl, id string) (bool, error) {
+	startTime := time.Now()
+
+	err := animeService.DeleteAnime(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = notFoundError("anime %s not found", id)
+	}
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "DeleteAnime", metrics.Error)
+		return false, err
+	}
+
+	metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "DeleteAnime", metrics.Success)
+
+	return true, nil
+}
+
+// requireTitleAfterUpdate keeps the rule createAnime enforces: an update that clears
+// titles must leave the anime with at least one of titleEn, titleRomaji or titleJp
+func requireTitleAfterUpdate(ctx context.Context, animeService anime.AnimeServiceImpl, update *anime2.Anime, columns []string) error {
+	titles := map[string]*string{
+		"title_en":     update.TitleEn,
+		"title_romaji": update.TitleRomaji,
+		"title_jp":     update.TitleJp,
+	}
+	cleared := false
+	for _, column := range columns {
+		if title, ok := titles[column]; ok {
+			if title != nil {
+				return nil
+			}
+			cleared = true
+		}
+	}
+	if !cleared {
+		return nil
+	}
+
+	current, err := animeService.AnimeByID(ctx, update.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return notFoundError("anime %s not found", update.ID)
+	}
+	if err != nil {
+		return err
+	}
+	merged := map[string]*string{
+		"title_en":     current.TitleEn,
+		"title_romaji": current.TitleRomaji,
+		"title_jp":     current.TitleJp,
+	}
+	for _, column := range columns {
+		if _, ok := merged[column]; ok {
+			merged[column] = nil
+		}
+	}
+	for _, title := range merged {
+		if title != nil && strings.TrimSpace(*title) != "" {
+			return nil
+		}
+	}
+	return inputError("titleEn", "at least one of titleEn, titleRomaji or titleJp is required")
+}
+
+// updateInputValues flattens an update into create-style values, the anime columns
+// it sets, and whether it sets seasons
+func updateInputValues(input model.UpdateAnimeInput) (model.CreateAnimeInput, []string, bool) {
+	var values model.CreateAnimeInput
+	var columns []string
+	set := func(ok bool, column string) {
+		if ok {
+			columns = append(columns, column)
+		}
+	}
+
+	var ok bool
+	values.Anidbid, ok = input.Anidbid.ValueOK()
+	set(ok, "anidbid")
+	values.Thetvdbid, ok = input.Thetvdbid.ValueOK()
+	set(ok, "thetvdbid")
+	values.MalID, ok = input.MalID.ValueOK()
+	set(ok, "mal_id")
+	values.TitleEn, ok = input.TitleEn.ValueOK()
+	set(ok, "title_en")
+	values.TitleJp, ok = input.TitleJp.ValueOK()
+	set(ok, "title_jp")
+	values.TitleRomaji, ok = input.TitleRomaji.ValueOK()
+	set(ok, "title_romaji")
+	values.TitleKanji, ok = input.TitleKanji.ValueOK()
+	set(ok, "title_kanji")
+	values.TitleSynonyms, ok = input.TitleSynonyms.ValueOK()
+	set(ok, "title_synonyms")
+	values.Description, ok = input.Description.ValueOK()
+	set(ok, "synopsis")
+	values.ImageURL, ok = input.ImageURL.ValueOK()
+	set(ok, "image_url")
+	values.Studios, ok = input.Studios.ValueOK()
+	set(ok, "studios")
+	values.Licensors, ok = input.Licensors.ValueOK()
+	set(ok, "licensors")
+	values.AnimeStatus, ok = input.AnimeStatus.ValueOK()
+	set(ok, "status")
+	values.EpisodeCount, ok = input.EpisodeCount.ValueOK()
+	set(ok, "episodes")
+	values.Duration, ok = input.Duration.ValueOK()
+	set(ok, "duration")
+	values.Rating, ok = input.Rating.ValueOK()
+	set(ok, "rating")
+	values.Ranking, ok = input.Ranking.ValueOK()
+	set(ok, "ranking")
+	values.StartDate, ok = input.StartDate.ValueOK()
+	set(ok, "start_date")
+	values.EndDate, ok = input.EndDate.ValueOK()
+	set(ok, "end_date")
+	values.Broadcast, ok = input.Broadcast.ValueOK()
+	set(ok, "broadcast")
+	values.Source, ok = input.Source.ValueOK()
+	set(ok, "source")
+
+	seasons, seasonsSet := input.Seasons.ValueOK()
+	values.Seasons = seasons
+
+	return values, columns, seasonsSet
+}
+
+// animeEntityFromInput validates mutation input and converts it to the stored
+// form: trimmed strings, JSON-encoded list columns and timestamp strings
+func animeEntityFromInput(input model.CreateAnimeInput) (*anime2.Anime, []anime2.Season, error) {
+	animeEntity := &anime2.Anime{
+		AnidbID:     trimmedString(input.Anidbid),
+		TheTVDBID:   trimmedString(input.Thetvdbid),
+		TitleEn:     trimmedString(input.TitleEn),
+		TitleJp:     trimmedString(input.TitleJp),
+		TitleRomaji: trimmedString(input.TitleRomaji),
+		TitleKanji:  trimmedString(input.TitleKanji),
+		Synopsis:    trimmedString(input.Description),
+		Status:      trimmedString(input.AnimeStatus),
+		Duration:    trimmedString(input.Duration),
+		Broadcast:   trimmedString(input.Broadcast),
+		Source:      trimmedString(input.Source),
+		StartDate:   formatAnimeDate(input.StartDate),
+		EndDate:     formatAnimeDate(input.EndDate),
+	}
+
+	if input.MalID != nil && *input.MalID <= 0 {
+		return nil, nil, inputError("malId", "malId must be positive")
+	}
+	animeEntity.MalID = input.MalID
+
+	if input.EpisodeCount != nil && *input.EpisodeCount < 0 {
+		return nil, nil, inputError("episodeCount", "episodeCount must not be negative")
+	}
+	animeEntity.Episodes = input.EpisodeCount
+
+	if input.Ranking != nil && *input.Ranking <= 0 {
+		return nil, nil, inputError("ranking", "ranking must be positive")
+	}
+	animeEntity.Ranking = input.Ranking
+
+	if input.Rating != nil && (*input.Rating < 0 || *input.Rating > 10) {
+		return nil, nil, inputError("rating", "rating must be between 0 and 10")
+	}
+	animeEntity.Rating = input.Rating
+
+	if input.StartDate != nil && input.EndDate != nil && input.EndDate.Before(*input.StartDate) {
+		return nil, nil, inputError("endDate", "endDate must not be before startDate")
+	}
+
+	animeEntity.ImageURL = trimmedString(input.ImageURL)
+	if animeEntity.ImageURL != nil {
+		parsed, err := url.ParseRequestURI(*animeEntity.ImageURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+			return nil, nil, inputError("imageUrl", "imageUrl must be an http(s) URL")
+		}
+	}
+
+	var err error
+	if animeEntity.TitleSynonyms, err = encodeStringList("titleSynonyms", input.TitleSynonyms); err != nil {
+		return nil, nil, err
+	}
+	if animeEntity.Studios, err = encodeStringList("studios", input.Studios); err != nil {
+		return nil, nil, err
+	}
+	if animeEntity.Licensors, err = encodeStringList("licensors", input.Licensors); err != nil {
+		return nil, nil, err
+	}
+
+	var seasons []anime2.Season
+	seen := make(map[anime2.Season]bool, len(input.Seasons))
+	for _, value := range input.Seasons {
+		season, err := anime2.ParseSeason(strings.ToUpper(strings.TrimSpace(value)))
+		if err != nil {
+			return nil, nil, inputError("seasons", "%s", err.Error())
+		}
+		if !seen[season] {
+			seen[season] = true
+			seasons = append(seasons, season)
+		}
+	}
+
+	return animeEntity, seasons, nil
+}
+
+// encodeStringList stores a list field as a JSON array, the format the anime table
+// uses for title_synonyms, studios and licensors. Empty lists are stored as NULL.
+func encodeStringList(field string, values []string) (*string, error) {
+	cleaned := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for i, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return nil, inputError(field, "%s[%d] must not be blank", field, i)
+		}
+		if !seen[value] {
+			seen[value] = true
+			cleaned = append(cleaned, value)
+		}
+	}
+	if len(cleaned) == 0 {
+		return nil, nil
+	}
+
+	encoded, err := json.Marshal(cleaned)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", field, err)
+	}
+	result := string(encoded)
+	return &result, nil
+}
+
+func trimmedString(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+func formatAnimeDate(value *time.Time) *string {
+	if value == nil {
+		return nil
+	}
+	formatted := value.UTC().Format("2006-01-02 15:04:05")
+	return &formatted
+}
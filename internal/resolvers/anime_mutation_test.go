@@ -0,0 +1,211 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/weeb-vip/anime-api/graph/model"
+	anime_repo "github.com/weeb-vip/anime-api/internal/db/repositories/anime"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func errorCode(err error) string {
+	gqlErr, ok := err.(*gqlerror.Error)
+	if !ok {
+		return ""
+	}
+	code, _ := gqlErr.Extensions["code"].(string)
+	return code
+}
+
+func TestAnimeEntityFromInput(t *testing.T) {
+	start := time.Date(2024, 4, 6, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 3, 0)
+
+	t.Run("encodes list fields and seasons", func(t *testing.T) {
+		entity, seasons, err := animeEntityFromInput(model.CreateAnimeInput{
+			TitleEn:   stringPtr("  Frieren  "),
+			Studios:   []string{"Madhouse", " Madhouse "},
+			Licensors: []string{},
+			StartDate: &start,
+			EndDate:   &end,
+			Seasons:   []string{"SPRING_2024", "SPRING_2024", "summer_2024"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if *entity.TitleEn != "Frieren" {
+			t.Errorf("TitleEn = %q, want trimmed", *entity.TitleEn)
+		}
+		if entity.Studios == nil || *entity.Studios != `["Madhouse"]` {
+			t.Errorf("Studios = %v, want deduplicated JSON array", entity.Studios)
+		}
+		if entity.Licensors != nil {
+			t.Errorf("Licensors = %q, want nil for an empty list", *entity.Licensors)
+		}
+		if *entity.StartDate != "2024-04-06 00:00:00" {
+			t.Errorf("StartDate = %q", *entity.StartDate)
+		}
+		if len(seasons) != 2 {
+			t.Errorf("got %d seasons, want 2", len(seasons))
+		}
+	})
+
+	tests := []struct {
+		name  string
+		input model.CreateAnimeInput
+		field string
+	}{
+		{"blank studio", model.CreateAnimeInput{Studios: []string{"Madhouse", " "}}, "studios"},
+		{"invalid season", model.CreateAnimeInput{Seasons: []string{"2024_SPRING"}}, "seasons"},
+		{"rating out of range", model.CreateAnimeInput{Rating: func() *float64 { v := 11.0; return &v }()}, "rating"},
+		{"negative episode count", model.CreateAnimeInput{EpisodeCount: intPtr(-1)}, "episodeCount"},
+		{"end before start", model.CreateAnimeInput{StartDate: &end, EndDate: &start}, "endDate"},
+		{"relative image url", model.CreateAnimeInput{ImageURL: stringPtr("/images/1.jpg")}, "imageUrl"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := animeEntityFromInput(tt.input)
+			gqlErr, ok := err.(*gqlerror.Error)
+			if !ok {
+				t.Fatalf("expected a gqlerror, got %v", err)
+			}
+			if gqlErr.Extensions["code"] != "BAD_USER_INPUT" || gqlErr.Extensions["field"] != tt.field {
+				t.Errorf("extensions = %v, want BAD_USER_INPUT on %s", gqlErr.Extensions, tt.field)
+			}
+		})
+	}
+}
+
+func TestCreateAnime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAnimeService := NewMockAnimeService(ctrl)
+	ctx := context.Background()
+
+	t.Run("requires a title", func(t *testing.T) {
+		_, err := CreateAnime(ctx, mockAnimeService, model.CreateAnimeInput{Studios: []string{"Madhouse"}})
+		if errorCode(err) != "BAD_USER_INPUT" {
+			t.Fatalf("expected BAD_USER_INPUT, got %v", err)
+		}
+	})
+
+	t.Run("stores the anime", func(t *testing.T) {
+		mockAnimeService.EXPECT().
+			CreateAnime(ctx, gomock.Any(), []anime_repo.Season{anime_repo.Season("SPRING_2024")}).
+			DoAndReturn(func(_ context.Context, entity *anime_repo.Anime, _ []anime_repo.Season) error {
+				entity.ID = "new-id"
+				return nil
+			})
+
+		result, err := CreateAnime(ctx, mockAnimeService, model.CreateAnimeInput{
+			TitleRomaji: stringPtr("Sousou no Frieren"),
+			Seasons:     []string{"SPRING_2024"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.ID != "new-id" || *result.TitleRomaji != "Sousou no Frieren" {
+			t.Errorf("unexpected result: %+v", result)
+		}
+	})
+}
+
+func TestUpdateAnime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAnimeService := NewMockAnimeService(ctrl)
+	ctx := context.Background()
+
+	t.Run("writes only the fields that are set", func(t *testing.T) {
+		input := model.UpdateAnimeInput{
+			TitleEn:     graphql.OmittableOf(stringPtr("Frieren")),
+			Description: graphql.OmittableOf[*string](nil),
+		}
+		mockAnimeService.EXPECT().
+			UpdateAnime(ctx, gomock.Any(), []string{"title_en", "synopsis"}, nil).
+			DoAndReturn(func(_ context.Context, entity *anime_repo.Anime, _ []string, _ []anime_repo.Season) error {
+				if entity.ID != "anime-1" || *entity.TitleEn != "Frieren" || entity.Synopsis != nil {
+					t.Errorf("unexpected entity: %+v", entity)
+				}
+				return nil
+			})
+		mockAnimeService.EXPECT().AnimeByID(ctx, "anime-1").Return(&anime_repo.Anime{ID: "anime-1", TitleEn: stringPtr("Frieren")}, nil)
+
+		result, err := UpdateAnime(ctx, mockAnimeService, "anime-1", input)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if *result.TitleEn != "Frieren" {
+			t.Errorf("TitleEn = %q", *result.TitleEn)
+		}
+	})
+
+	t.Run("null seasons clears them", func(t *testing.T) {
+		input := model.UpdateAnimeInput{Seasons: graphql.OmittableOf[[]string](nil)}
+		mockAnimeService.EXPECT().UpdateAnime(ctx, gomock.Any(), nil, []anime_repo.Season{}).Return(nil)
+		mockAnimeService.EXPECT().AnimeByID(ctx, "anime-1").Return(&anime_repo.Anime{ID: "anime-1"}, nil)
+
+		if _, err := UpdateAnime(ctx, mockAnimeService, "anime-1", input); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("clearing a title keeps another one", func(t *testing.T) {
+		input := model.UpdateAnimeInput{TitleEn: graphql.OmittableOf[*string](nil)}
+		mockAnimeService.EXPECT().AnimeByID(ctx, "anime-1").Return(&anime_repo.Anime{ID: "anime-1", TitleEn: stringPtr("Frieren"), TitleRomaji: stringPtr("Sousou no Frieren")}, nil)
+		mockAnimeService.EXPECT().UpdateAnime(ctx, gomock.Any(), []string{"title_en"}, nil).Return(nil)
+		mockAnimeService.EXPECT().AnimeByID(ctx, "anime-1").Return(&anime_repo.Anime{ID: "anime-1", TitleRomaji: stringPtr("Sousou no Frieren")}, nil)
+
+		if _, err := UpdateAnime(ctx, mockAnimeService, "anime-1", input); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("clearing the last title is rejected", func(t *testing.T) {
+		input := model.UpdateAnimeInput{
+			TitleEn:     graphql.OmittableOf[*string](nil),
+			TitleRomaji: graphql.OmittableOf(stringPtr("  ")),
+		}
+		mockAnimeService.EXPECT().AnimeByID(ctx, "anime-1").Return(&anime_repo.Anime{ID: "anime-1", TitleEn: stringPtr("Frieren"), TitleRomaji: stringPtr("Sousou no Frieren")}, nil)
+
+		_, err := UpdateAnime(ctx, mockAnimeService, "anime-1", input)
+		if errorCode(err) != "BAD_USER_INPUT" {
+			t.Fatalf("expected BAD_USER_INPUT, got %v", err)
+		}
+	})
+
+	t.Run("missing anime", func(t *testing.T) {
+		mockAnimeService.EXPECT().UpdateAnime(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(gorm.ErrRecordNotFound)
+
+		_, err := UpdateAnime(ctx, mockAnimeService, "missing", model.UpdateAnimeInput{TitleEn: graphql.OmittableOf(stringPtr("x"))})
+		if errorCode(err) != "NOT_FOUND" {
+			t.Fatalf("expected NOT_FOUND, got %v", err)
+		}
+	})
+}
+
+func TestDeleteAnime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAnimeService := NewMockAnimeService(ctrl)
+	ctx := context.Background()
+
+	mockAnimeService.EXPECT().DeleteAnime(ctx, "anime-1").Return(nil)
+	deleted, err := DeleteAnime(ctx, mockAnimeService, "anime-1")
+	if err != nil || !deleted {
+		t.Fatalf("DeleteAnime = %v, %v", deleted, err)
+	}
+
+	mockAnimeService.EXPECT().DeleteAnime(ctx, "missing").Return(gorm.ErrRecordNotFound)
+	if _, err := DeleteAnime(ctx, mockAnimeService, "missing"); errorCode(err) != "NOT_FOUND" {
+		t.Fatalf("expected NOT_FOUND, got %v", err)
+	}
+}
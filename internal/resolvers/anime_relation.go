@@ -0,0 +1,228 @@
+package resolvers
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/weeb-vip/anime-api/graph/model"
+	"github.com/weeb-vip/anime-api/internal/dataloaders"
+	anime_repo "github.com/weeb-vip/anime-api/internal/db/repositories/anime"
+	anime_relation_repo "github.com/weeb-vip/anime-api/internal/db/repositories/anime_relation"
+	"github.com/weeb-vip/anime-api/internal/services/anime"
+	"github.com/weeb-vip/anime-api/internal/services/anime_relation"
+	"github.com/weeb-vip/anime-api/metrics"
+	"github.com/weeb-vip/anime-api/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func relationTypeToGraphQL(relation anime_relation_repo.AnimeRelation) model.RelationType {
+	relationType := model.RelationType(strings.ToUpper(relation.NormalizedType()))
+	if !relationType.IsValid() {
+		return model.RelationTypeOther
+	}
+	return relationType
+}
+
+// AnimeRelations returns the relations listed on an anime with the related anime
+// loaded in one batch. Relations pointing at anime that no longer exist are skipped.
+func AnimeRelations(ctx context.Context, animeRelationService anime_relation.AnimeRelationServiceImpl, animeService anime.AnimeServiceImpl, animeID string) ([]*model.AnimeRelation, error) {
+	startTime := time.Now()
+
+	var relations []*anime_relation_repo.AnimeRelation
+	var err error
+	if loaders := dataloaders.For(ctx); loaders != nil && loaders.Relations != nil {
+		relations, err = loaders.Relations.Load(ctx, animeID)
+	} else {
+		relations, err = animeRelationService.FindByAnimeID(ctx, animeID)
+	}
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(
+			float64(time.Since(startTime).Milliseconds()),
+			"AnimeRelations",
+			metrics.Error,
+		)
+		return nil, err
+	}
+
+	if len(relations) == 0 {
+		metrics.GetAppMetrics().ResolverMetric(
+			float64(time.Since(startTime).Milliseconds()),
+			"AnimeRelations",
+			metrics.Success,
+		)
+		return []*model.AnimeRelation{}, nil
+	}
+
+	relatedIDs := make([]string, 0, len(relations))
+	for _, relation := range relations {
+		relatedIDs = append(relatedIDs, relation.RelatedAnimeID)
+	}
+
+	relatedAnime, err := animeByIDMap(ctx, animeService, relatedIDs)
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(
+			float64(time.Since(startTime).Milliseconds()),
+			"AnimeRelations",
+			metrics.Error,
+		)
+		return nil, err
+	}
+
+	result := make([]*model.AnimeRelation, 0, len(relations))
+	for _, relation := range relations {
+		related, ok := relatedAnime[relation.RelatedAnimeID]
+		if !ok {
+			continue
+		}
+		result = append(result, &model.AnimeRelation{
+			RelationType: relationTypeToGraphQL(*relation),
+			Anime:        related,
+		})
+	}
+
+	metrics.GetAppMetrics().ResolverMetric(
+		float64(time.Since(startTime).Milliseconds()),
+		"AnimeRelations",
+		metrics.Success,
+	)
+
+	return result, nil
+}
+
+// Franchise returns every anime connected to animeID through franchise relations,
+// in watch order: by start date, then by distance from animeID for anime without one.
+func Franchise(ctx context.Context, animeRelationService anime_relation.AnimeRelationServiceImpl, animeService anime.AnimeServiceImpl, animeID string, maxDepth *int) ([]*model.Anime, error) {
+	depth := 0
+	if maxDepth != nil {
+		depth = *maxDepth
+	}
+
+	tracer := tracing.GetTracer(ctx)
+	ctx, span := tracer.Start(ctx, "Franchise",
+		trace.WithAttributes(
+			attribute.String("anime.id", animeID),
+			attribute.Int("franchise.max_depth", depth),
+			attribute.String("resolver.name", "Franchise"),
+		),
+		tracing.GetEnvironmentAttribute(),
+	)
+	defer span.End()
+
+	startTime := time.Now()
+
+	entries, err := animeRelationService.Franchise(ctx, animeID, depth)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		metrics.GetAppMetrics().ResolverMetric(
+			float64(time.Since(startTime).Milliseconds()),
+			"Franchise",
+			metrics.Error,
+		)
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(entries))
+	depthByID := make(map[string]int, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.AnimeID)
+		depthByID[entry.AnimeID] = entry.Depth
+	}
+
+	animeByID, err := animeByIDMap(ctx, animeService, ids)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		metrics.GetAppMetrics().ResolverMetric(
+			float64(time.Since(startTime).Milliseconds()),
+			"Franchise",
+			metrics.Error,
+		)
+		return nil, err
+	}
+
+	franchise := make([]*model.Anime, 0, len(animeByID))
+	for _, id := range ids {
+		if animeGraphQL, ok := animeByID[id]; ok {
+			franchise = append(franchise, animeGraphQL)
+		}
+	}
+	sortWatchOrder(franchise, depthByID)
+
+	span.SetAttributes(attribute.Int("franchise.size", len(franchise)))
+	span.SetStatus(codes.Ok, "")
+
+	metrics.GetAppMetrics().ResolverMetric(
+		float64(time.Since(startTime).Milliseconds()),
+		"Franchise",
+		metrics.Success,
+	)
+
+	return franchise, nil
+}
+
+// sortWatchOrder orders anime by start date with undated anime last, breaking ties by depth then ID
+func sortWatchOrder(animeList []*model.Anime, depthByID map[string]int) {
+	sort.SliceStable(animeList, func(i, j int) bool {
+		a, b := animeList[i], animeList[j]
+		switch {
+		case a.StartDate != nil && b.StartDate != nil && !a.StartDate.Equal(*b.StartDate):
+			return a.StartDate.Before(*b.StartDate)
+		case a.StartDate != nil && b.StartDate == nil:
+			return true
+		case a.StartDate == nil && b.StartDate != nil:
+			return false
+		}
+		if depthByID[a.ID] != depthByID[b.ID] {
+			return depthByID[a.ID] < depthByID[b.ID]
+		}
+		return a.ID < b.ID
+	})
+}
+
+// animeByIDMap loads anime in one batch and indexes the GraphQL form by ID. With
+// request loaders the batch is shared with the other fields resolving anime.
+func animeByIDMap(ctx context.Context, animeService anime.AnimeServiceImpl, ids []string) (map[string]*model.Anime, error) {
+	var foundAnime []*anime_repo.Anime
+	var err error
+	if loaders := dataloaders.For(ctx); loaders != nil && loaders.Anime != nil {
+		foundAnime, err = loaders.Anime.LoadMany(ctx, uniqueIDs(ids))
+	} else {
+		foundAnime, err = animeService.AnimeByIDs(ctx, uniqueIDs(ids))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	animeByID := make(map[string]*model.Anime, len(foundAnime))
+	for _, animeEntity := range foundAnime {
+		if animeEntity == nil {
+			// the loader resolves anime it did not find to nil
+			continue
+		}
+		animeGraphQL, err := transformAnimeToGraphQL(*animeEntity)
+		if err != nil {
+			return nil, err
+		}
+		animeByID[animeEntity.ID] = animeGraphQL
+	}
+	return animeByID, nil
+}
+
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
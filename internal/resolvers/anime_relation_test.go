@@ -0,0 +1,159 @@
+package resolvers
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/weeb-vip/anime-api/graph/model"
+	"github.com/weeb-vip/anime-api/internal/dataloaders"
+	anime_repo "github.com/weeb-vip/anime-api/internal/db/repositories/anime"
+	anime_relation_repo "github.com/weeb-vip/anime-api/internal/db/repositories/anime_relation"
+	"github.com/weeb-vip/anime-api/internal/services/anime_relation"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeAnimeRelationService struct {
+	relations map[string][]*anime_relation_repo.AnimeRelation
+	franchise []anime_relation.FranchiseEntry
+}
+
+func (f *fakeAnimeRelationService) FindByAnimeID(ctx context.Context, animeID string) ([]*anime_relation_repo.AnimeRelation, error) {
+	return f.relations[animeID], nil
+}
+
+func (f *fakeAnimeRelationService) FindByAnimeIDs(ctx context.Context, animeIDs []string) (map[string][]*anime_relation_repo.AnimeRelation, error) {
+	return f.relations, nil
+}
+
+func (f *fakeAnimeRelationService) Franchise(ctx context.Context, animeID string, maxDepth int) ([]anime_relation.FranchiseEntry, error) {
+	return f.franchise, nil
+}
+
+func TestAnimeRelationsMapsTypesAndSkipsMissingAnime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAnimeService := NewMockAnimeService(ctrl)
+	sequel, spinOff, unknown := "Sequel", "spin-off", "remake"
+	relationService := &fakeAnimeRelationService{relations: map[string][]*anime_relation_repo.AnimeRelation{
+		"anime-1": {
+			{AnimeID: "anime-1", RelatedAnimeID: "anime-2", RelationType: &sequel},
+			{AnimeID: "anime-1", RelatedAnimeID: "deleted", RelationType: &sequel},
+			{AnimeID: "anime-1", RelatedAnimeID: "anime-3", RelationType: &spinOff},
+			{AnimeID: "anime-1", RelatedAnimeID: "anime-4", RelationType: &unknown},
+		},
+	}}
+
+	mockAnimeService.EXPECT().
+		AnimeByIDs(gomock.Any(), []string{"anime-2", "deleted", "anime-3", "anime-4"}).
+		Return([]*anime_repo.Anime{{ID: "anime-2"}, {ID: "anime-3"}, {ID: "anime-4"}}, nil).
+		Times(1)
+
+	relations, err := AnimeRelations(context.Background(), relationService, mockAnimeService, "anime-1")
+	if err != nil {
+		t.Fatalf("AnimeRelations returned error: %v", err)
+	}
+
+	expected := []struct {
+		id           string
+		relationType model.RelationType
+	}{
+		{"anime-2", model.RelationTypeSequel},
+		{"anime-3", model.RelationTypeSpinOff},
+		{"anime-4", model.RelationTypeOther},
+	}
+	if len(relations) != len(expected) {
+		t.Fatalf("Expected %d relations, got %d", len(expected), len(relations))
+	}
+	for i, want := range expected {
+		if relations[i].Anime.ID != want.id || relations[i].RelationType != want.relationType {
+			t.Errorf("relations[%d] = %s/%s, expected %s/%s", i, relations[i].Anime.ID, relations[i].RelationType, want.id, want.relationType)
+		}
+	}
+}
+
+func TestAnimeRelationsShareOneAnimeBatchAcrossParents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAnimeService := NewMockAnimeService(ctrl)
+	sequel := "Sequel"
+	relationService := &fakeAnimeRelationService{relations: map[string][]*anime_relation_repo.AnimeRelation{
+		"anime-1": {{AnimeID: "anime-1", RelatedAnimeID: "anime-2", RelationType: &sequel}},
+		"anime-2": {{AnimeID: "anime-2", RelatedAnimeID: "anime-3", RelationType: &sequel}},
+	}}
+
+	mockAnimeService.EXPECT().
+		AnimeByIDs(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ids []string) ([]*anime_repo.Anime, error) {
+			found := make([]*anime_repo.Anime, 0, len(ids))
+			for _, id := range ids {
+				found = append(found, &anime_repo.Anime{ID: id})
+			}
+			return found, nil
+		}).
+		Times(1)
+
+	ctx := dataloaders.WithLoaders(context.Background(), dataloaders.NewLoaders(dataloaders.Sources{AnimeService: mockAnimeService}))
+
+	parents := []string{"anime-1", "anime-2"}
+	results := make([][]*model.AnimeRelation, len(parents))
+	var wg sync.WaitGroup
+	for i, parent := range parents {
+		wg.Add(1)
+		go func(i int, parent string) {
+			defer wg.Done()
+			relations, err := AnimeRelations(ctx, relationService, mockAnimeService, parent)
+			if err != nil {
+				t.Errorf("AnimeRelations(%s) returned error: %v", parent, err)
+			}
+			results[i] = relations
+		}(i, parent)
+	}
+	wg.Wait()
+
+	for i, want := range []string{"anime-2", "anime-3"} {
+		if len(results[i]) != 1 || results[i][0].Anime.ID != want {
+			t.Errorf("relations of %s = %+v, expected %s", parents[i], results[i], want)
+		}
+	}
+}
+
+func TestFranchiseReturnsWatchOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAnimeService := NewMockAnimeService(ctrl)
+	relationService := &fakeAnimeRelationService{franchise: []anime_relation.FranchiseEntry{
+		{AnimeID: "season-2", Depth: 0},
+		{AnimeID: "season-1", Depth: 1},
+		{AnimeID: "movie", Depth: 1},
+		{AnimeID: "special", Depth: 2},
+	}}
+
+	mockAnimeService.EXPECT().
+		AnimeByIDs(gomock.Any(), []string{"season-2", "season-1", "movie", "special"}).
+		Return([]*anime_repo.Anime{
+			{ID: "season-2", StartDate: stringPtr("2022-04-01 00:00:00")},
+			{ID: "special"},
+			{ID: "season-1", StartDate: stringPtr("2020-01-01 00:00:00")},
+			{ID: "movie", StartDate: stringPtr("2021-07-01 00:00:00")},
+		}, nil).
+		Times(1)
+
+	franchise, err := Franchise(context.Background(), relationService, mockAnimeService, "season-2", nil)
+	if err != nil {
+		t.Fatalf("Franchise returned error: %v", err)
+	}
+
+	expected := []string{"season-1", "movie", "season-2", "special"}
+	if len(franchise) != len(expected) {
+		t.Fatalf("Expected %d anime, got %d", len(expected), len(franchise))
+	}
+	for i, id := range expected {
+		if franchise[i].ID != id {
+			t.Errorf("franchise[%d] = %s, expected %s", i, franchise[i].ID, id)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/weeb-vip/anime-api/graph/model"
+	"github.com/weeb-vip/anime-api/internal/search"
+	"github.com/weeb-vip/anime-api/internal/services/anime"
+	"github.com/weeb-vip/anime-api/metrics"
+)
+
+const (
+	defaultSearchLimit = 10
+	maxSearchLimit     = 100
+	maxSuggestionLimit = 25
+)
+
+// SearchAnime returns the anime whose titles best match query, most relevant first
+func SearchAnime(ctx context.Context, animeService anime.AnimeServiceImpl, query string, limit *int) ([]*model.AnimeSearchHit, error) {
+	startTime := time.Now()
+
+	hits, err := searchAnime(ctx, animeService, query, limit)
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "SearchAnime", metrics.Error)
+		return nil, err
+	}
+
+	metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "SearchAnime", metrics.Success)
+
+	return hits, nil
+}
+
+func searchAnime(ctx context.Context, animeService anime.AnimeServiceImpl, query string, limit *int) ([]*model.AnimeSearchHit, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, inputError("query", "query must not be blank")
+	}
+	size := defaultSearchLimit
+	if limit != nil {
+		if *limit < 1 || *limit > maxSearchLimit {
+			return nil, inputError("limit", "limit must be between 1 and %d", maxSearchLimit)
+		}
+		size = *limit
+	}
+
+	ranked, err := animeService.RankedSearch(ctx, query, size)
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]*model.AnimeSearchHit, 0, len(ranked))
+	for _, result := range ranked {
+		animeModel, err := transformAnimeToGraphQL(*result.Anime)
+		if err != nil {
+			return nil, err
+		}
+		hits = append(hits, &model.AnimeSearchHit{Anime: animeModel, Score: result.Score})
+	}
+	return hits, nil
+}
+
+// SearchSuggestions returns typeahead suggestions for prefix. Results are cached by
+// normalized prefix, so spellings that normalize alike share an entry. Results of the
+// fallback used while the index builds are not cached, as they miss some titles.
+func SearchSuggestions(ctx context.Context, animeService anime.AnimeServiceImpl, prefix string, limit *int, cacheService CacheServiceInterface) ([]*model.SearchSuggestion, error) {
+	startTime := time.Now()
+
+	normalized := search.Normalize(prefix)
+	if normalized == "" {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "SearchSuggestions", metrics.Error)
+		return nil, inputError("prefix", "prefix must contain letters or digits")
+	}
+	size := defaultSearchLimit
+	if limit != nil {
+		if *limit < 1 || *limit > maxSuggestionLimit {
+			metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "SearchSuggestions", metrics.Error)
+			return nil, inputError("limit", "limit must be between 1 and %d", maxSuggestionLimit)
+		}
+		size = *limit
+	}
+
+	cacheKey := cacheService.GetKeyBuilder().SearchSuggestions(normalized, size)
+	var cachedResult []*model.SearchSuggestion
+	if err := cacheService.GetJSON(ctx, cacheKey, &cachedResult); err == nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "SearchSuggestions", "cache_hit")
+		return cachedResult, nil
+	}
+
+	found, fromIndex, err := animeService.SearchSuggestions(ctx, prefix, size)
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "SearchSuggestions", metrics.Error)
+		return nil, err
+	}
+
+	suggestions := make([]*model.SearchSuggestion, 0, len(found))
+	for _, suggestion := range found {
+		suggestions = append(suggestions, &model.SearchSuggestion{
+			ID:       suggestion.ID,
+			Title:    suggestion.Title,
+			ImageURL: suggestion.ImageURL,
+			Year:     suggestion.Year,
+		})
+	}
+
+	if fromIndex {
+		go func() {
+			_ = cacheService.SetJSON(context.Background(), cacheKey, suggestions, cacheService.GetSearchSuggestionTTL())
+		}()
+	}
+
+	metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "SearchSuggestions", metrics.Success)
+
+	return suggestions, nil
+}
@@ -0,0 +1,120 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	anime_repo "github.com/weeb-vip/anime-api/internal/db/repositories/anime"
+	"github.com/weeb-vip/anime-api/internal/search"
+	"github.com/weeb-vip/anime-api/internal/services/anime"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSearchAnime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAnimeService := NewMockAnimeService(ctrl)
+	ctx := context.Background()
+
+	t.Run("returns hits in ranked order", func(t *testing.T) {
+		mockAnimeService.EXPECT().RankedSearch(ctx, "frieren", defaultSearchLimit).Return([]anime.RankedAnime{
+			{Anime: &anime_repo.Anime{ID: "a", TitleEn: stringPtr("Frieren")}, Score: 2},
+			{Anime: &anime_repo.Anime{ID: "b", TitleEn: stringPtr("Frieren Specials")}, Score: 1.4},
+		}, nil)
+
+		hits, err := SearchAnime(ctx, mockAnimeService, "  frieren ", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(hits) != 2 || hits[0].Anime.ID != "a" || hits[0].Score != 2 || hits[1].Anime.ID != "b" {
+			t.Errorf("unexpected hits: %+v", hits)
+		}
+	})
+
+	t.Run("passes service errors through", func(t *testing.T) {
+		mockAnimeService.EXPECT().RankedSearch(ctx, "frieren", 5).Return(nil, errors.New("boom"))
+
+		limit := 5
+		if _, err := SearchAnime(ctx, mockAnimeService, "frieren", &limit); err == nil {
+			t.Error("expected an error")
+		}
+	})
+
+	t.Run("does not cache the fallback", func(t *testing.T) {
+		fallbackCache := NewMockCacheService()
+		mockAnimeService.EXPECT().SearchSuggestions(ctx, "Fre", defaultSearchLimit).Return([]search.Suggestion{
+			{ID: "a", Title: "Frieren"},
+		}, false, nil).Times(2)
+
+		for i := 0; i < 2; i++ {
+			if _, err := SearchSuggestions(ctx, mockAnimeService, "Fre", nil, fallbackCache); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		if fallbackCache.setCalls != 0 {
+			t.Errorf("expected no cache writes, got %d", fallbackCache.setCalls)
+		}
+	})
+
+	t.Run("rejects bad arguments", func(t *testing.T) {
+		if _, err := SearchAnime(ctx, mockAnimeService, "   ", nil); errorCode(err) != "BAD_USER_INPUT" {
+			t.Errorf("blank query: got %v", err)
+		}
+		tooMany := maxSearchLimit + 1
+		if _, err := SearchAnime(ctx, mockAnimeService, "frieren", &tooMany); errorCode(err) != "BAD_USER_INPUT" {
+			t.Errorf("limit over max: got %v", err)
+		}
+	})
+}
+
+func TestSearchSuggestions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAnimeService := NewMockAnimeService(ctrl)
+	mockCache := NewMockCacheService()
+	ctx := context.Background()
+	year := 2023
+
+	t.Run("maps the service suggestions", func(t *testing.T) {
+		mockAnimeService.EXPECT().SearchSuggestions(ctx, "Fri", defaultSearchLimit).Return([]search.Suggestion{
+			{ID: "a", Title: "Frieren", Year: &year},
+		}, true, nil)
+
+		suggestions, err := SearchSuggestions(ctx, mockAnimeService, "Fri", nil, mockCache)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(suggestions) != 1 || suggestions[0].ID != "a" || suggestions[0].Title != "Frieren" || *suggestions[0].Year != 2023 {
+			t.Errorf("unexpected suggestions: %+v", suggestions)
+		}
+	})
+
+	t.Run("does not cache the fallback", func(t *testing.T) {
+		fallbackCache := NewMockCacheService()
+		mockAnimeService.EXPECT().SearchSuggestions(ctx, "Fre", defaultSearchLimit).Return([]search.Suggestion{
+			{ID: "a", Title: "Frieren"},
+		}, false, nil).Times(2)
+
+		for i := 0; i < 2; i++ {
+			if _, err := SearchSuggestions(ctx, mockAnimeService, "Fre", nil, fallbackCache); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		if fallbackCache.setCalls != 0 {
+			t.Errorf("expected no cache writes, got %d", fallbackCache.setCalls)
+		}
+	})
+
+	t.Run("rejects bad arguments", func(t *testing.T) {
+		if _, err := SearchSuggestions(ctx, mockAnimeService, " -- ", nil, mockCache); errorCode(err) != "BAD_USER_INPUT" {
+			t.Errorf("blank prefix: got %v", err)
+		}
+		tooMany := maxSuggestionLimit + 1
+		if _, err := SearchSuggestions(ctx, mockAnimeService, "fri", &tooMany, mockCache); errorCode(err) != "BAD_USER_INPUT" {
+			t.Errorf("limit over max: got %v", err)
+		}
+	})
+}
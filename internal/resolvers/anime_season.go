@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/weeb-vip/anime-api/graph/model"
+	"github.com/weeb-vip/anime-api/internal/dataloaders"
 	anime_repo "github.com/weeb-vip/anime-api/internal/db/repositories/anime"
 	anime_season_repo "github.com/weeb-vip/anime-api/internal/db/repositories/anime_season"
 	anime_service "github.com/weeb-vip/anime-api/internal/services/anime"
@@ -33,7 +34,13 @@ func transformAnimeSeasonToGraphQL(animeSeasonEntity anime_season_repo.AnimeSeas
 func AnimeSeasons(ctx context.Context, animeSeasonService anime_season.AnimeSeasonServiceImpl, animeID string) ([]*model.AnimeSeason, error) {
 	startTime := time.Now()
 
-	foundSeasons, err := animeSeasonService.FindByAnimeID(ctx, animeID)
+	var foundSeasons []*anime_season_repo.AnimeSeason
+	var err error
+	if loaders := dataloaders.For(ctx); loaders != nil && loaders.Seasons != nil {
+		foundSeasons, err = loaders.Seasons.Load(ctx, animeID)
+	} else {
+		foundSeasons, err = animeSeasonService.FindByAnimeID(ctx, animeID)
+	}
 	if err != nil {
 		metrics.GetAppMetrics().ResolverMetric(
 			float64(time.Since(startTime).Milliseconds()),
@@ -97,7 +97,7 @@ func BenchmarkAnimeBySeasonsOptimized(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, err := AnimeBySeasons(ctx, mockAnimeSeasonService, mockAnimeService, season)
+		_, err := AnimeBySeasons(ctx, mockAnimeSeasonService, mockAnimeService, season, nil)
 		if err != nil {
 			b.Fatal(err)
 		}
@@ -9,6 +9,8 @@ import (
 	anime_repo "github.com/weeb-vip/anime-api/internal/db/repositories/anime"
 	anime_episode_repo "github.com/weeb-vip/anime-api/internal/db/repositories/anime_episode"
 	anime_season_repo "github.com/weeb-vip/anime-api/internal/db/repositories/anime_season"
+	"github.com/weeb-vip/anime-api/internal/search"
+	"github.com/weeb-vip/anime-api/internal/services/anime"
 	"go.uber.org/mock/gomock"
 )
 
@@ -214,6 +216,19 @@ func (c *MockAnimeServiceMockRecorder) SearchedAnimeWithEpisodes(ctx, query, pag
 	return c.mock.ctrl.RecordCallWithMethodType(c.mock, "SearchedAnimeWithEpisodes", reflect.TypeOf((*MockAnimeService)(nil).SearchedAnimeWithEpisodes), ctx, query, page, limit)
 }
 
+func (m *MockAnimeService) SearchedAnimeFilteredWithEpisodes(ctx context.Context, filter anime_repo.SearchFilter, page int, limit int) ([]*anime_repo.Anime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchedAnimeFilteredWithEpisodes", ctx, filter, page, limit)
+	ret0, _ := ret[0].([]*anime_repo.Anime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (c *MockAnimeServiceMockRecorder) SearchedAnimeFilteredWithEpisodes(ctx, filter, page, limit interface{}) *gomock.Call {
+	c.mock.ctrl.T.Helper()
+	return c.mock.ctrl.RecordCallWithMethodType(c.mock, "SearchedAnimeFilteredWithEpisodes", reflect.TypeOf((*MockAnimeService)(nil).SearchedAnimeFilteredWithEpisodes), ctx, filter, page, limit)
+}
+
 func (m *MockAnimeService) AnimeBySeasonWithEpisodes(ctx context.Context, season string) ([]*anime_repo.Anime, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "AnimeBySeasonWithEpisodes", ctx, season)
@@ -279,6 +294,95 @@ func (c *MockAnimeServiceMockRecorder) AnimeBySeasonBatched(ctx, season interfac
 	return c.mock.ctrl.RecordCallWithMethodType(c.mock, "AnimeBySeasonBatched", reflect.TypeOf((*MockAnimeService)(nil).AnimeBySeasonBatched), ctx, season)
 }
 
+func (m *MockAnimeService) CreateAnime(ctx context.Context, entity *anime_repo.Anime, seasons []anime_repo.Season) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAnime", ctx, entity, seasons)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (c *MockAnimeServiceMockRecorder) CreateAnime(ctx, entity, seasons interface{}) *gomock.Call {
+	c.mock.ctrl.T.Helper()
+	return c.mock.ctrl.RecordCallWithMethodType(c.mock, "CreateAnime", reflect.TypeOf((*MockAnimeService)(nil).CreateAnime), ctx, entity, seasons)
+}
+
+func (m *MockAnimeService) UpdateAnime(ctx context.Context, entity *anime_repo.Anime, columns []string, seasons []anime_repo.Season) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAnime", ctx, entity, columns, seasons)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (c *MockAnimeServiceMockRecorder) UpdateAnime(ctx, entity, columns, seasons interface{}) *gomock.Call {
+	c.mock.ctrl.T.Helper()
+	return c.mock.ctrl.RecordCallWithMethodType(c.mock, "UpdateAnime", reflect.TypeOf((*MockAnimeService)(nil).UpdateAnime), ctx, entity, columns, seasons)
+}
+
+func (m *MockAnimeService) DeleteAnime(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAnime", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (c *MockAnimeServiceMockRecorder) DeleteAnime(ctx, id interface{}) *gomock.Call {
+	c.mock.ctrl.T.Helper()
+	return c.mock.ctrl.RecordCallWithMethodType(c.mock, "DeleteAnime", reflect.TypeOf((*MockAnimeService)(nil).DeleteAnime), ctx, id)
+}
+
+func (m *MockAnimeService) AnimePage(ctx context.Context, filter anime_repo.SearchFilter, after *anime_repo.Cursor, limit int, withEpisodes bool) ([]*anime_repo.Anime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnimePage", ctx, filter, after, limit, withEpisodes)
+	ret0, _ := ret[0].([]*anime_repo.Anime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (c *MockAnimeServiceMockRecorder) AnimePage(ctx, filter, after, limit, withEpisodes interface{}) *gomock.Call {
+	c.mock.ctrl.T.Helper()
+	return c.mock.ctrl.RecordCallWithMethodType(c.mock, "AnimePage", reflect.TypeOf((*MockAnimeService)(nil).AnimePage), ctx, filter, after, limit, withEpisodes)
+}
+
+func (m *MockAnimeService) CountAnime(ctx context.Context, filter anime_repo.SearchFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAnime", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (c *MockAnimeServiceMockRecorder) CountAnime(ctx, filter interface{}) *gomock.Call {
+	c.mock.ctrl.T.Helper()
+	return c.mock.ctrl.RecordCallWithMethodType(c.mock, "CountAnime", reflect.TypeOf((*MockAnimeService)(nil).CountAnime), ctx, filter)
+}
+
+func (m *MockAnimeService) RankedSearch(ctx context.Context, query string, limit int) ([]anime.RankedAnime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RankedSearch", ctx, query, limit)
+	ret0, _ := ret[0].([]anime.RankedAnime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (c *MockAnimeServiceMockRecorder) RankedSearch(ctx, query, limit interface{}) *gomock.Call {
+	c.mock.ctrl.T.Helper()
+	return c.mock.ctrl.RecordCallWithMethodType(c.mock, "RankedSearch", reflect.TypeOf((*MockAnimeService)(nil).RankedSearch), ctx, query, limit)
+}
+
+func (m *MockAnimeService) SearchSuggestions(ctx context.Context, prefix string, limit int) ([]search.Suggestion, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchSuggestions", ctx, prefix, limit)
+	ret0, _ := ret[0].([]search.Suggestion)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+func (c *MockAnimeServiceMockRecorder) SearchSuggestions(ctx, prefix, limit interface{}) *gomock.Call {
+	c.mock.ctrl.T.Helper()
+	return c.mock.ctrl.RecordCallWithMethodType(c.mock, "SearchSuggestions", reflect.TypeOf((*MockAnimeService)(nil).SearchSuggestions), ctx, prefix, limit)
+}
+
 func (m *MockAnimeService) AnimeBySeasonOptimized(ctx context.Context, season string) ([]*anime_repo.Anime, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "AnimeBySeasonOptimized", ctx, season)
@@ -292,17 +396,17 @@ func (c *MockAnimeServiceMockRecorder) AnimeBySeasonOptimized(ctx, season interf
 	return c.mock.ctrl.RecordCallWithMethodType(c.mock, "AnimeBySeasonOptimized", reflect.TypeOf((*MockAnimeService)(nil).AnimeBySeasonOptimized), ctx, season)
 }
 
-func (m *MockAnimeService) AnimeBySeasonWithFieldSelection(ctx context.Context, season string, fields *anime_repo.FieldSelection) ([]*anime_repo.Anime, error) {
+func (m *MockAnimeService) AnimeBySeasonWithFieldSelection(ctx context.Context, season string, fields *anime_repo.FieldSelection, limit int) ([]*anime_repo.Anime, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AnimeBySeasonWithFieldSelection", ctx, season, fields)
+	ret := m.ctrl.Call(m, "AnimeBySeasonWithFieldSelection", ctx, season, fields, limit)
 	ret0, _ := ret[0].([]*anime_repo.Anime)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-func (c *MockAnimeServiceMockRecorder) AnimeBySeasonWithFieldSelection(ctx, season, fields interface{}) *gomock.Call {
+func (c *MockAnimeServiceMockRecorder) AnimeBySeasonWithFieldSelection(ctx, season, fields, limit interface{}) *gomock.Call {
 	c.mock.ctrl.T.Helper()
-	return c.mock.ctrl.RecordCallWithMethodType(c.mock, "AnimeBySeasonWithFieldSelection", reflect.TypeOf((*MockAnimeService)(nil).AnimeBySeasonWithFieldSelection), ctx, season, fields)
+	return c.mock.ctrl.RecordCallWithMethodType(c.mock, "AnimeBySeasonWithFieldSelection", reflect.TypeOf((*MockAnimeService)(nil).AnimeBySeasonWithFieldSelection), ctx, season, fields, limit)
 }
 
 // MockAnimeSeasonService implements the AnimeSeasonServiceImpl interface for testing
@@ -331,6 +435,11 @@ func (m *MockAnimeSeasonService) FindByAnimeID(ctx context.Context, animeID stri
 	return nil, nil
 }
 
+func (m *MockAnimeSeasonService) FindByAnimeIDs(ctx context.Context, animeIDs []string) (map[string][]*anime_season_repo.AnimeSeason, error) {
+	// Implementation not needed for this test
+	return nil, nil
+}
+
 func (m *MockAnimeSeasonService) FindBySeason(ctx context.Context, season string) ([]*anime_season_repo.AnimeSeason, error) {
 	// Implementation not needed for this test
 	return nil, nil
@@ -397,6 +506,43 @@ func (c *MockAnimeEpisodeServiceMockRecorder) GetNextEpisode(ctx, animeID interf
 	return c.mock.ctrl.RecordCallWithMethodType(c.mock, "GetNextEpisode", reflect.TypeOf((*MockAnimeEpisodeService)(nil).GetNextEpisode), ctx, animeID)
 }
 
+func (m *MockAnimeEpisodeService) GetEpisodeByID(ctx context.Context, id string) (*anime_episode_repo.AnimeEpisode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEpisodeByID", ctx, id)
+	ret0, _ := ret[0].(*anime_episode_repo.AnimeEpisode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (c *MockAnimeEpisodeServiceMockRecorder) GetEpisodeByID(ctx, id interface{}) *gomock.Call {
+	c.mock.ctrl.T.Helper()
+	return c.mock.ctrl.RecordCallWithMethodType(c.mock, "GetEpisodeByID", reflect.TypeOf((*MockAnimeEpisodeService)(nil).GetEpisodeByID), ctx, id)
+}
+
+func (m *MockAnimeEpisodeService) UpsertEpisodes(ctx context.Context, animeID string, episodes []*anime_episode_repo.AnimeEpisode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertEpisodes", ctx, animeID, episodes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (c *MockAnimeEpisodeServiceMockRecorder) UpsertEpisodes(ctx, animeID, episodes interface{}) *gomock.Call {
+	c.mock.ctrl.T.Helper()
+	return c.mock.ctrl.RecordCallWithMethodType(c.mock, "UpsertEpisodes", reflect.TypeOf((*MockAnimeEpisodeService)(nil).UpsertEpisodes), ctx, animeID, episodes)
+}
+
+func (m *MockAnimeEpisodeService) DeleteEpisode(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEpisode", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (c *MockAnimeEpisodeServiceMockRecorder) DeleteEpisode(ctx, id interface{}) *gomock.Call {
+	c.mock.ctrl.T.Helper()
+	return c.mock.ctrl.RecordCallWithMethodType(c.mock, "DeleteEpisode", reflect.TypeOf((*MockAnimeEpisodeService)(nil).DeleteEpisode), ctx, id)
+}
+
 func TestAnimeBySeasonsNoAdditionalEpisodeQueries(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -436,9 +582,9 @@ func TestAnimeBySeasonsNoAdditionalEpisodeQueries(t *testing.T) {
 		UpdatedAt: now,
 	}
 
-	// Expect only ONE call to the optimized AnimeBySeasonOptimized method (without episodes)
+	// Expect only ONE call to the field-selected season query
 	mockAnimeService.EXPECT().
-		AnimeBySeasonOptimized(ctx, season).
+		AnimeBySeasonWithFieldSelection(ctx, season, gomock.Any(), 10).
 		Return([]*anime_repo.Anime{testAnime}, nil).
 		Times(1)
 
@@ -449,7 +595,7 @@ func TestAnimeBySeasonsNoAdditionalEpisodeQueries(t *testing.T) {
 		Times(0) // Zero calls expected
 
 	// Execute the resolver
-	result, err := AnimeBySeasons(ctx, mockAnimeSeasonService, mockAnimeService, season)
+	result, err := AnimeBySeasons(ctx, mockAnimeSeasonService, mockAnimeService, season, nil)
 
 	// Verify results
 	if err != nil {
@@ -502,9 +648,9 @@ func TestAnimeBySeasonsWithEmptyEpisodesNoAdditionalQueries(t *testing.T) {
 		UpdatedAt:     now,
 	}
 
-	// Expect only ONE call to the optimized AnimeBySeasonOptimized method (without episodes)
+	// Expect only ONE call to the field-selected season query
 	mockAnimeService.EXPECT().
-		AnimeBySeasonOptimized(ctx, season).
+		AnimeBySeasonWithFieldSelection(ctx, season, gomock.Any(), 10).
 		Return([]*anime_repo.Anime{testAnime}, nil).
 		Times(1)
 
@@ -515,7 +661,7 @@ func TestAnimeBySeasonsWithEmptyEpisodesNoAdditionalQueries(t *testing.T) {
 		Times(0) // Zero calls expected
 
 	// Execute the resolver
-	result, err := AnimeBySeasons(ctx, mockAnimeSeasonService, mockAnimeService, season)
+	result, err := AnimeBySeasons(ctx, mockAnimeSeasonService, mockAnimeService, season, nil)
 
 	// Verify results
 	if err != nil {
@@ -0,0 +1,37 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/weeb-vip/anime-api/graph/model"
+	"github.com/weeb-vip/anime-api/internal/broadcast"
+	"github.com/weeb-vip/anime-api/internal/logger"
+)
+
+// BroadcastInfo reads the weekly slot of a broadcast value. Values naming no slot or
+// that cannot be read give null, since the raw broadcast field still carries them.
+func BroadcastInfo(ctx context.Context, value *string) (*model.BroadcastInfo, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	slot, err := broadcast.Parse(*value)
+	if err != nil {
+		if !errors.Is(err, broadcast.ErrNotScheduled) {
+			log := logger.FromCtx(ctx)
+			log.Debug().Err(err).Msg("Failed to parse broadcast")
+		}
+		return nil, nil
+	}
+
+	info := &model.BroadcastInfo{
+		LocalTime: slot.LocalTime(),
+		Timezone:  slot.Location.String(),
+	}
+	if weekday := slot.AirsOn(); weekday != nil {
+		name := weekday.String()
+		info.Weekday = &name
+	}
+	return info, nil
+}
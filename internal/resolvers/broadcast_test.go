@@ -0,0 +1,25 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+)
+
+func TestBroadcastInfo(t *testing.T) {
+	ctx := context.Background()
+
+	info, err := BroadcastInfo(ctx, stringPtr("Fridays at 25:30 (JST)"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info == nil || info.Weekday == nil || *info.Weekday != "Saturday" || info.LocalTime != "01:30" || info.Timezone != "Asia/Tokyo" {
+		t.Errorf("unexpected info: %+v", info)
+	}
+
+	for _, value := range []*string{nil, stringPtr("Unknown"), stringPtr("Fridays at 23:00 (XYZ)")} {
+		info, err := BroadcastInfo(ctx, value)
+		if err != nil || info != nil {
+			t.Errorf("expected null without an error, got %+v, %v", info, err)
+		}
+	}
+}
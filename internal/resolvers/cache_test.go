@@ -49,6 +49,14 @@ func (m *MockCacheService) GetCurrentlyAiringTTL() time.Duration {
 	return 5 * time.Minute
 }
 
+func (m *MockCacheService) GetSearchSuggestionTTL() time.Duration {
+	return 5 * time.Minute
+}
+
+func (m *MockCacheService) GetSharedCastTTL() time.Duration {
+	return 5 * time.Minute
+}
+
 func TestCacheKeyGeneration(t *testing.T) {
 	mockCache := NewMockCacheService()
 	keyBuilder := mockCache.GetKeyBuilder()
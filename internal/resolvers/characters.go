@@ -2,53 +2,67 @@ package resolvers
 
 import (
 	"context"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/weeb-vip/anime-api/graph/model"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_character"
 	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_character_staff_link"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_staff"
 	anime_character_staff_link2 "github.com/weeb-vip/anime-api/internal/services/anime_character_staff_link"
+	"github.com/weeb-vip/anime-api/metrics"
 )
 
+func convertCharacterToGraphql(animeCharacter anime_character.AnimeCharacter) *model.AnimeCharacter {
+	return &model.AnimeCharacter{
+		ID:            animeCharacter.ID,
+		AnimeID:       animeCharacter.AnimeID,
+		Name:          animeCharacter.Name,
+		Role:          animeCharacter.Role,
+		Birthday:      &animeCharacter.Birthday,
+		Zodiac:        &animeCharacter.Zodiac,
+		Gender:        &animeCharacter.Gender,
+		Race:          &animeCharacter.Race,
+		Height:        &animeCharacter.Height,
+		Weight:        &animeCharacter.Weight,
+		Title:         &animeCharacter.Title,
+		MartialStatus: &animeCharacter.MartialStatus,
+		Summary:       &animeCharacter.Summary,
+		Image:         &animeCharacter.Image,
+		CreatedAt:     &animeCharacter.CreatedAt,
+		UpdatedAt:     &animeCharacter.UpdatedAt,
+	}
+}
+
+func convertStaffToGraphql(staff anime_staff.AnimeStaff) *model.AnimeStaff {
+	return &model.AnimeStaff{
+		ID:         staff.ID,
+		Language:   &staff.Language,
+		GivenName:  staff.GivenName,
+		FamilyName: staff.FamilyName,
+		Image:      &staff.Image,
+		Birthday:   &staff.Birthday,
+		BirthPlace: &staff.BirthPlace,
+		BloodType:  &staff.BloodType,
+		Hobbies:    &staff.Hobbies,
+		Summary:    &staff.Summary,
+		CreatedAt:  &staff.CreatedAt,
+		UpdatedAt:  &staff.UpdatedAt,
+	}
+}
+
 func convertAnimeCharacterToGraphql(animeCharacterEntity *anime_character_staff_link.AnimeCharacterWithStaff) (*model.CharacterWithStaff, error) {
 	if animeCharacterEntity == nil {
 		return nil, nil
 	}
 
-	character := &model.AnimeCharacter{
-		ID:            animeCharacterEntity.AnimeCharacter.ID,
-		AnimeID:       animeCharacterEntity.AnimeCharacter.AnimeID,
-		Name:          animeCharacterEntity.AnimeCharacter.Name,
-		Role:          animeCharacterEntity.AnimeCharacter.Role,
-		Birthday:      &animeCharacterEntity.AnimeCharacter.Birthday,
-		Zodiac:        &animeCharacterEntity.AnimeCharacter.Zodiac,
-		Gender:        &animeCharacterEntity.AnimeCharacter.Gender,
-		Race:          &animeCharacterEntity.AnimeCharacter.Race,
-		Height:        &animeCharacterEntity.AnimeCharacter.Height,
-		Weight:        &animeCharacterEntity.AnimeCharacter.Weight,
-		Title:         &animeCharacterEntity.AnimeCharacter.Title,
-		MartialStatus: &animeCharacterEntity.AnimeCharacter.MartialStatus,
-		Summary:       &animeCharacterEntity.AnimeCharacter.Summary,
-		Image:         &animeCharacterEntity.AnimeCharacter.Image,
-		CreatedAt:     &animeCharacterEntity.AnimeCharacter.CreatedAt,
-		UpdatedAt:     &animeCharacterEntity.AnimeCharacter.UpdatedAt,
-	}
+	character := convertCharacterToGraphql(animeCharacterEntity.AnimeCharacter)
 	staffs := make([]*model.AnimeStaff, 0, len(animeCharacterEntity.VoiceActors))
-
 	for _, staff := range animeCharacterEntity.VoiceActors {
-		voiceActor := &model.AnimeStaff{
-			ID:         staff.ID,
-			Language:   &staff.Language,
-			GivenName:  staff.GivenName,
-			FamilyName: staff.FamilyName,
-			Image:      &staff.Image,
-			Birthday:   &staff.Birthday,
-			BirthPlace: &staff.BirthPlace,
-			BloodType:  &staff.BloodType,
-			Hobbies:    &staff.Hobbies,
-			Summary:    &staff.Summary,
-			CreatedAt:  &staff.CreatedAt,
-			UpdatedAt:  &staff.UpdatedAt,
-		}
-		staffs = append(staffs, voiceActor)
+		staffs = append(staffs, convertStaffToGraphql(staff))
 	}
+	character.Staff = staffs
 
 	return &model.CharacterWithStaff{
 		Staff:     staffs,
@@ -56,12 +70,51 @@ func convertAnimeCharacterToGraphql(animeCharacterEntity *anime_character_staff_
 	}, nil
 }
 
-func CharactersAndStaffByAnimeID(ctx context.Context, animeCharacterStaffLinkService anime_character_staff_link2.AnimeCharacterStaffLinkImpl, animeId string) ([]*model.CharacterWithStaff, error) {
-	animeCharacters, err := animeCharacterStaffLinkService.FindAnimeCharacterAndStaffByAnimeId(ctx, animeId)
+// characterRoleRank orders main characters before supporting and background ones, and
+// unrecognised roles last
+func characterRoleRank(role string) int {
+	switch strings.ToLower(strings.TrimSpace(role)) {
+	case "main":
+		return 0
+	case "supporting":
+		return 1
+	case "background":
+		return 2
+	default:
+		return 3
+	}
+}
+
+// sortByCharacterRole orders characters main first, then by name
+func sortByCharacterRole(animeCharacters []*anime_character_staff_link.AnimeCharacterWithStaff) {
+	sort.SliceStable(animeCharacters, func(i, j int) bool {
+		rankI, rankJ := characterRoleRank(animeCharacters[i].Role), characterRoleRank(animeCharacters[j].Role)
+		if rankI != rankJ {
+			return rankI < rankJ
+		}
+		if animeCharacters[i].Name != animeCharacters[j].Name {
+			return animeCharacters[i].Name < animeCharacters[j].Name
+		}
+		return animeCharacters[i].ID < animeCharacters[j].ID
+	})
+}
+
+func CharactersAndStaffByAnimeID(ctx context.Context, animeCharacterStaffLinkService anime_character_staff_link2.AnimeCharacterStaffLinkImpl, animeId string, languages []string, roles []string) ([]*model.CharacterWithStaff, error) {
+	startTime := time.Now()
+
+	animeCharacters, err := animeCharacterStaffLinkService.FindAnimeCharacterAndStaffByAnimeId(ctx, animeId, anime_character_staff_link.CharacterStaffFilter{
+		Languages: languages,
+		Roles:     roles,
+	})
 	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "CharactersAndStaffByAnimeID", metrics.Error)
 		return nil, err
 	}
 
+	metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "CharactersAndStaffByAnimeID", metrics.Success)
+
+	sortByCharacterRole(animeCharacters)
+
 	var characters []*model.CharacterWithStaff
 	for _, animeCharacter := range animeCharacters {
 		character, err := convertAnimeCharacterToGraphql(animeCharacter)
@@ -73,3 +126,42 @@ func CharactersAndStaffByAnimeID(ctx context.Context, animeCharacterStaffLinkSer
 
 	return characters, nil
 }
+
+// CastComparison lines up each character of an anime with their voice actors by language
+func CastComparison(ctx context.Context, animeCharacterStaffLinkService anime_character_staff_link2.AnimeCharacterStaffLinkImpl, animeId string) ([]*model.CastComparison, error) {
+	startTime := time.Now()
+
+	animeCharacters, err := animeCharacterStaffLinkService.FindAnimeCharacterAndStaffByAnimeId(ctx, animeId, anime_character_staff_link.CharacterStaffFilter{})
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "CastComparison", metrics.Error)
+		return nil, err
+	}
+
+	metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "CastComparison", metrics.Success)
+
+	sortByCharacterRole(animeCharacters)
+
+	comparison := make([]*model.CastComparison, 0, len(animeCharacters))
+	for _, animeCharacter := range animeCharacters {
+		entry := &model.CastComparison{
+			Character: convertCharacterToGraphql(animeCharacter.AnimeCharacter),
+			Japanese:  []*model.AnimeStaff{},
+			English:   []*model.AnimeStaff{},
+			Other:     []*model.AnimeStaff{},
+		}
+		for _, staff := range animeCharacter.VoiceActors {
+			voiceActor := convertStaffToGraphql(staff)
+			switch strings.ToLower(strings.TrimSpace(staff.Language)) {
+			case "japanese":
+				entry.Japanese = append(entry.Japanese, voiceActor)
+			case "english":
+				entry.English = append(entry.English, voiceActor)
+			default:
+				entry.Other = append(entry.Other, voiceActor)
+			}
+		}
+		comparison = append(comparison, entry)
+	}
+
+	return comparison, nil
+}
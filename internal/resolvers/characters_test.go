@@ -0,0 +1,89 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_character"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_character_staff_link"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_staff"
+)
+
+func testCast() []*anime_character_staff_link.AnimeCharacterWithStaff {
+	return []*anime_character_staff_link.AnimeCharacterWithStaff{
+		{
+			AnimeCharacter: anime_character.AnimeCharacter{ID: "c3", Name: "Shopkeeper", Role: "Background"},
+			VoiceActors:    []anime_staff.AnimeStaff{{ID: "s5", Language: "Japanese"}},
+		},
+		{
+			AnimeCharacter: anime_character.AnimeCharacter{ID: "c2", Name: "Kurisu Makise", Role: "Main"},
+			VoiceActors: []anime_staff.AnimeStaff{
+				{ID: "s3", Language: "English"},
+				{ID: "s4", Language: "japanese"},
+			},
+		},
+		{
+			AnimeCharacter: anime_character.AnimeCharacter{ID: "c4", Name: "Itaru Hashida", Role: "Supporting"},
+			VoiceActors:    []anime_staff.AnimeStaff{{ID: "s6", Language: "German"}},
+		},
+		{
+			AnimeCharacter: anime_character.AnimeCharacter{ID: "c1", Name: "Okabe Rintarou", Role: "main"},
+			VoiceActors:    []anime_staff.AnimeStaff{{ID: "s1", Language: "Japanese"}, {ID: "s2"}},
+		},
+	}
+}
+
+func TestCharactersAndStaffByAnimeID(t *testing.T) {
+	service := &fakeCharacterStaffLinkService{cast: testCast()}
+
+	characters, err := CharactersAndStaffByAnimeID(context.Background(), service, "a1", []string{"English"}, []string{"Main"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(service.lastFilter.Languages) != 1 || service.lastFilter.Languages[0] != "English" || len(service.lastFilter.Roles) != 1 || service.lastFilter.Roles[0] != "Main" {
+		t.Errorf("expected the filter to be passed on, got %+v", service.lastFilter)
+	}
+
+	var order []string
+	for _, character := range characters {
+		order = append(order, character.Character.ID)
+	}
+	want := []string{"c2", "c1", "c4", "c3"}
+	if len(order) != len(want) {
+		t.Fatalf("expected %v, got %v", want, order)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, order)
+		}
+	}
+}
+
+func TestCastComparison(t *testing.T) {
+	service := &fakeCharacterStaffLinkService{cast: testCast()}
+
+	comparison, err := CastComparison(context.Background(), service, "a1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(comparison) != 4 {
+		t.Fatalf("expected 4 characters, got %d", len(comparison))
+	}
+	if len(service.lastFilter.Languages) != 0 || len(service.lastFilter.Roles) != 0 {
+		t.Errorf("expected the whole cast to be loaded, got filter %+v", service.lastFilter)
+	}
+
+	kurisu := comparison[0]
+	if kurisu.Character.ID != "c2" || len(kurisu.Japanese) != 1 || kurisu.Japanese[0].ID != "s4" || len(kurisu.English) != 1 || kurisu.English[0].ID != "s3" || len(kurisu.Other) != 0 {
+		t.Errorf("unexpected comparison for Kurisu: %+v", kurisu)
+	}
+
+	okabe := comparison[1]
+	if okabe.Character.ID != "c1" || len(okabe.Japanese) != 1 || len(okabe.English) != 0 || len(okabe.Other) != 1 || okabe.Other[0].ID != "s2" {
+		t.Errorf("unexpected comparison for Okabe: %+v", okabe)
+	}
+
+	if hashida := comparison[2]; hashida.Character.ID != "c4" || len(hashida.Other) != 1 {
+		t.Errorf("unexpected comparison for Hashida: %+v", hashida)
+	}
+}
@@ -0,0 +1,87 @@
+package resolvers
+
+import (
+	"context"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/weeb-vip/anime-api/graph/model"
+	"github.com/weeb-vip/anime-api/internal/services/anime"
+	"github.com/weeb-vip/anime-api/internal/services/episodes"
+	"github.com/weeb-vip/anime-api/metrics"
+	"github.com/weeb-vip/anime-api/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// AnimeEntitiesByIDs resolves federation representations for Anime with a single
+// batched lookup. The result has one entry per id in the same order; ids that do
+// not exist resolve to null and add an error to the response instead of failing
+// the whole batch.
+func AnimeEntitiesByIDs(ctx context.Context, animeService anime.AnimeServiceImpl, ids []string) ([]*model.Anime, error) {
+	tracer := tracing.GetTracer(ctx)
+	ctx, span := tracer.Start(ctx, "AnimeEntitiesByIDs",
+		trace.WithAttributes(
+			attribute.Int("anime.ids.count", len(ids)),
+			attribute.String("resolver.name", "AnimeEntitiesByIDs"),
+		),
+		tracing.GetEnvironmentAttribute(),
+	)
+	defer span.End()
+
+	startTime := time.Now()
+
+	foundAnime, err := animeService.AnimeByIDs(ctx, ids)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		metrics.GetAppMetrics().ResolverMetric(
+			float64(time.Since(startTime).Milliseconds()),
+			"AnimeEntitiesByIDs",
+			metrics.Error,
+		)
+		return nil, err
+	}
+
+	byID := make(map[string]*model.Anime, len(foundAnime))
+	for _, animeEntity := range foundAnime {
+		animeGraphQL, err := transformAnimeToGraphQL(*animeEntity)
+		if err != nil {
+			graphql.AddErrorf(ctx, "failed to resolve anime %s: %v", animeEntity.ID, err)
+			continue
+		}
+		byID[animeEntity.ID] = animeGraphQL
+	}
+
+	result := make([]*model.Anime, len(ids))
+	for i, id := range ids {
+		animeGraphQL, ok := byID[id]
+		if !ok {
+			graphql.AddErrorf(ctx, "anime %s not found", id)
+			continue
+		}
+		result[i] = animeGraphQL
+	}
+
+	span.SetAttributes(attribute.Int("anime.found.count", len(byID)))
+	span.SetStatus(codes.Ok, "")
+
+	metrics.GetAppMetrics().ResolverMetric(
+		float64(time.Since(startTime).Milliseconds()),
+		"AnimeEntitiesByIDs",
+		metrics.Success,
+	)
+
+	return result, nil
+}
+
+// EpisodeEntityByAnimeID resolves a federation representation for Episode, which is
+// keyed by animeId, to the next episode of that anime.
+func EpisodeEntityByAnimeID(ctx context.Context, animeEpisodeService episodes.AnimeEpisodeServiceImpl, animeID *string) (*model.Episode, error) {
+	if animeID == nil {
+		return nil, nil
+	}
+	return NextEpisode(ctx, animeEpisodeService, *animeID)
+}
@@ -0,0 +1,55 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	anime_repo "github.com/weeb-vip/anime-api/internal/db/repositories/anime"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAnimeEntitiesByIDsPreservesOrderAndNullsMissing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAnimeService := NewMockAnimeService(ctrl)
+
+	ctx := graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover)
+	ids := []string{"anime-2", "missing", "anime-1"}
+
+	// Single batched lookup, returned in database order rather than request order
+	mockAnimeService.EXPECT().
+		AnimeByIDs(gomock.Any(), ids).
+		Return([]*anime_repo.Anime{
+			{ID: "anime-1", TitleEn: stringPtr("First")},
+			{ID: "anime-2", TitleEn: stringPtr("Second")},
+		}, nil).
+		Times(1)
+
+	result, err := AnimeEntitiesByIDs(ctx, mockAnimeService, ids)
+	if err != nil {
+		t.Fatalf("AnimeEntitiesByIDs returned error: %v", err)
+	}
+
+	if len(result) != len(ids) {
+		t.Fatalf("Expected %d results, got %d", len(ids), len(result))
+	}
+	if result[0] == nil || result[0].ID != "anime-2" {
+		t.Errorf("Expected result[0] to be anime-2, got %+v", result[0])
+	}
+	if result[1] != nil {
+		t.Errorf("Expected result[1] to be nil for missing id, got %+v", result[1])
+	}
+	if result[2] == nil || result[2].ID != "anime-1" {
+		t.Errorf("Expected result[2] to be anime-1, got %+v", result[2])
+	}
+
+	errs := graphql.GetErrors(ctx)
+	if len(errs) != 1 {
+		t.Fatalf("Expected 1 error for the missing id, got %d: %v", len(errs), errs)
+	}
+	if errs[0].Message != "anime missing not found" {
+		t.Errorf("Unexpected error message: %s", errs[0].Message)
+	}
+}
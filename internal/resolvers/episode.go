@@ -2,8 +2,12 @@ package resolvers
 
 import (
 	"context"
+
 	"github.com/weeb-vip/anime-api/graph/model"
 	anime2 "github.com/weeb-vip/anime-api/internal/db/repositories/anime_episode"
+	"github.com/weeb-vip/anime-api/internal/logger"
+	"github.com/weeb-vip/anime-api/internal/services"
+	"github.com/weeb-vip/anime-api/internal/services/anime"
 	"github.com/weeb-vip/anime-api/internal/services/episodes"
 )
 
@@ -52,3 +56,29 @@ func NextEpisode(ctx context.Context, animeEpisodeService episodes.AnimeEpisodeS
 
 	return transformEpisodeToGraphql(*episodeEntity)
 }
+
+func EpisodeByID(ctx context.Context, animeEpisodeService episodes.AnimeEpisodeServiceImpl, animeService anime.AnimeServiceImpl, id string) (*model.Episode, error) {
+	episodeEntity, err := animeEpisodeService.GetEpisodeByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	episode, err := transformEpisodeToGraphql(*episodeEntity)
+	if err != nil {
+		return nil, err
+	}
+
+	// airTime needs the parent anime's broadcast; without it fall back to the raw air date
+	episode.AirTime = episodeEntity.Aired
+	if episodeEntity.AnimeID != nil {
+		animeEntity, err := animeService.AnimeByID(ctx, *episodeEntity.AnimeID)
+		if err != nil {
+			log := logger.FromCtx(ctx)
+			log.Warn().Err(err).Str("episode_id", id).Msg("Failed to load anime for episode air time")
+		} else {
+			episode.AirTime = services.ParseAirTime(episodeEntity.Aired, animeEntity.Broadcast)
+		}
+	}
+
+	return episode, nil
+}
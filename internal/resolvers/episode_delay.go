@@ -0,0 +1,109 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/weeb-vip/anime-api/graph/model"
+	"github.com/weeb-vip/anime-api/internal/dataloaders"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/episode_delay"
+	"github.com/weeb-vip/anime-api/metrics"
+)
+
+// broadcastAirType is the air type of the Japanese broadcast, which is what
+// Episode.delay and Anime.onHiatus describe
+const broadcastAirType = "raw"
+
+func episodeDelayToGraphQL(delay episode_delay.EpisodeDelay) *model.EpisodeDelay {
+	return &model.EpisodeDelay{
+		AirType:            model.AirType(strings.ToUpper(delay.AirType)),
+		OriginalAirTime:    delay.OriginalAirDatetime,
+		RescheduledAirTime: delay.RescheduledAirDatetime,
+		Reason:             delay.Reason,
+		BreakWeeks:         delay.BreakWeeks,
+	}
+}
+
+// animeDelays loads the delays of an anime, batched with the rest of the request
+// when a loader is installed
+func animeDelays(ctx context.Context, episodeDelayRepository episode_delay.EpisodeDelayRepositoryImpl, animeID string) ([]episode_delay.EpisodeDelay, error) {
+	if loaders := dataloaders.For(ctx); loaders != nil && loaders.EpisodeDelays != nil {
+		return loaders.EpisodeDelays.Load(ctx, animeID)
+	}
+	return episodeDelayRepository.FindByAnimeID(animeID)
+}
+
+// EpisodeDelay returns the broadcast delay of an episode, or nil when it airs as scheduled
+func EpisodeDelay(ctx context.Context, episodeDelayRepository episode_delay.EpisodeDelayRepositoryImpl, animeID string, episodeNumber int) (*model.EpisodeDelay, error) {
+	startTime := time.Now()
+
+	delays, err := animeDelays(ctx, episodeDelayRepository, animeID)
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "EpisodeDelay", metrics.Error)
+		return nil, err
+	}
+
+	metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "EpisodeDelay", metrics.Success)
+
+	for _, delay := range delays {
+		if delay.EpisodeNumber == episodeNumber && delay.AirType == broadcastAirType {
+			return episodeDelayToGraphQL(delay), nil
+		}
+	}
+	return nil, nil
+}
+
+// OnHiatus reports whether an anime's broadcast is on a break
+func OnHiatus(ctx context.Context, episodeDelayRepository episode_delay.EpisodeDelayRepositoryImpl, animeID string) (bool, error) {
+	startTime := time.Now()
+
+	delays, err := animeDelays(ctx, episodeDelayRepository, animeID)
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "OnHiatus", metrics.Error)
+		return false, err
+	}
+
+	metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "OnHiatus", metrics.Success)
+
+	return onHiatus(delays, time.Now()), nil
+}
+
+// onHiatus is true while a broadcast delay is open-ended, or is a break of one or
+// more weeks that has not yet ended. A one-off delay of an episode is not a hiatus.
+func onHiatus(delays []episode_delay.EpisodeDelay, now time.Time) bool {
+	for _, delay := range delays {
+		if delay.AirType != broadcastAirType {
+			continue
+		}
+		if delay.RescheduledAirDatetime == nil {
+			return true
+		}
+		if delay.BreakWeeks > 0 && delay.RescheduledAirDatetime.After(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// attachEpisodeDelays sets Delay on the listed episodes that have a broadcast delay,
+// so the next episode is worked out from their rescheduled air times
+func attachEpisodeDelays(animes []*model.Anime, delays map[string][]episode_delay.EpisodeDelay) {
+	for _, animeModel := range animes {
+		animeDelays := delays[animeModel.ID]
+		if len(animeDelays) == 0 {
+			continue
+		}
+		for _, episode := range animeModel.Episodes {
+			if episode.EpisodeNumber == nil {
+				continue
+			}
+			for _, delay := range animeDelays {
+				if delay.EpisodeNumber == *episode.EpisodeNumber && delay.AirType == broadcastAirType {
+					episode.Delay = episodeDelayToGraphQL(delay)
+					break
+				}
+			}
+		}
+	}
+}
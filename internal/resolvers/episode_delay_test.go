@@ -0,0 +1,94 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/weeb-vip/anime-api/graph/model"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/episode_delay"
+)
+
+// fakeEpisodeDelayRepository serves fixed delays; the embedded interface panics on anything else
+type fakeEpisodeDelayRepository struct {
+	episode_delay.EpisodeDelayRepositoryImpl
+	delays map[string][]episode_delay.EpisodeDelay
+}
+
+func (f *fakeEpisodeDelayRepository) FindByAnimeID(animeID string) ([]episode_delay.EpisodeDelay, error) {
+	return f.delays[animeID], nil
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestEpisodeDelay(t *testing.T) {
+	ctx := context.Background()
+	repository := &fakeEpisodeDelayRepository{delays: map[string][]episode_delay.EpisodeDelay{
+		"1": {
+			{AnimeID: "1", EpisodeNumber: 5, AirType: "raw", RescheduledAirDatetime: timePtr(time.Date(2026, 11, 6, 15, 0, 0, 0, time.UTC)), Reason: stringPtr("Sports coverage")},
+			{AnimeID: "1", EpisodeNumber: 6, AirType: "sub"},
+		},
+	}}
+
+	delay, err := EpisodeDelay(ctx, repository, "1", 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if delay == nil || delay.AirType != model.AirTypeRaw || delay.Reason == nil || *delay.Reason != "Sports coverage" {
+		t.Errorf("unexpected delay: %+v", delay)
+	}
+
+	// only the Japanese broadcast is reported on the episode
+	if delay, err := EpisodeDelay(ctx, repository, "1", 6); err != nil || delay != nil {
+		t.Errorf("expected no broadcast delay, got %+v, %v", delay, err)
+	}
+}
+
+func TestOnHiatus(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	nextMonth := now.AddDate(0, 1, 0)
+	lastMonth := now.AddDate(0, -1, 0)
+
+	tests := []struct {
+		name   string
+		delays []episode_delay.EpisodeDelay
+		want   bool
+	}{
+		{"no delays", nil, false},
+		{"one-off delay", []episode_delay.EpisodeDelay{{AirType: "raw", RescheduledAirDatetime: &nextMonth}}, false},
+		{"indefinite delay", []episode_delay.EpisodeDelay{{AirType: "raw"}}, true},
+		{"break until next month", []episode_delay.EpisodeDelay{{AirType: "raw", RescheduledAirDatetime: &nextMonth, BreakWeeks: 4}}, true},
+		{"break that has ended", []episode_delay.EpisodeDelay{{AirType: "raw", RescheduledAirDatetime: &lastMonth, BreakWeeks: 4}}, false},
+		{"dub delay only", []episode_delay.EpisodeDelay{{AirType: "dub"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := onHiatus(tt.delays, now); got != tt.want {
+				t.Errorf("onHiatus = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttachEpisodeDelays(t *testing.T) {
+	animes := []*model.Anime{{
+		ID: "1",
+		Episodes: []*model.Episode{
+			{ID: "ep-4", EpisodeNumber: intPtr(4)},
+			{ID: "ep-5", EpisodeNumber: intPtr(5)},
+		},
+	}}
+	attachEpisodeDelays(animes, map[string][]episode_delay.EpisodeDelay{
+		"1": {{AnimeID: "1", EpisodeNumber: 5, AirType: "raw", BreakWeeks: 1}},
+	})
+
+	if animes[0].Episodes[0].Delay != nil {
+		t.Errorf("expected episode 4 to air as scheduled, got %+v", animes[0].Episodes[0].Delay)
+	}
+	if delay := animes[0].Episodes[1].Delay; delay == nil || delay.BreakWeeks != 1 {
+		t.Errorf("expected the delay on episode 5, got %+v", delay)
+	}
+}
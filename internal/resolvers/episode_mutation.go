@@ -0,0 +1,124 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/weeb-vip/anime-api/graph/model"
+	anime2 "github.com/weeb-vip/anime-api/internal/db/repositories/anime_episode"
+	"github.com/weeb-vip/anime-api/internal/services"
+	"github.com/weeb-vip/anime-api/internal/services/anime"
+	"github.com/weeb-vip/anime-api/internal/services/episodes"
+	"github.com/weeb-vip/anime-api/metrics"
+	"gorm.io/gorm"
+)
+
+// UpsertEpisodes validates the episodes and saves them for the anime in one transaction
+func UpsertEpisodes(ctx context.Context, animeService anime.AnimeServiceImpl, animeEpisodeService episodes.AnimeEpisodeServiceImpl, animeID string, inputs []*model.EpisodeInput) ([]*model.Episode, error) {
+	startTime := time.Now()
+
+	result, err := upsertEpisodes(ctx, animeService, animeEpisodeService, animeID, inputs)
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "UpsertEpisodes", metrics.Error)
+		return nil, err
+	}
+
+	metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "UpsertEpisodes", metrics.Success)
+
+	return result, nil
+}
+
+func upsertEpisodes(ctx context.Context, animeService anime.AnimeServiceImpl, animeEpisodeService episodes.AnimeEpisodeServiceImpl, animeID string, inputs []*model.EpisodeInput) ([]*model.Episode, error) {
+	episodeEntities, err := episodeEntitiesFromInput(inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	animeEntity, err := animeService.AnimeByID(ctx, animeID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, notFoundError("anime %s not found", animeID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(episodeEntities) > 0 {
+		err = animeEpisodeService.UpsertEpisodes(ctx, animeID, episodeEntities)
+		if errors.Is(err, anime2.ErrEpisodeNotInAnime) {
+			return nil, inputError("episodes", "episode ids must belong to anime %s", animeID)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := make([]*model.Episode, len(episodeEntities))
+	for i, episodeEntity := range episodeEntities {
+		episode, err := transformEpisodeToGraphql(*episodeEntity)
+		if err != nil {
+			return nil, err
+		}
+		episode.AirTime = services.ParseAirTime(episodeEntity.Aired, animeEntity.Broadcast)
+		result[i] = episode
+	}
+
+	return result, nil
+}
+
+// DeleteEpisode removes an episode
+func DeleteEpisode(ctx context.Context, animeEpisodeService episodes.AnimeEpisodeServiceImpl, id string) (bool, error) {
+	startTime := time.Now()
+
+	err := animeEpisodeService.DeleteEpisode(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = notFoundError("episode %s not found", id)
+	}
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "DeleteEpisode", metrics.Error)
+		return false, err
+	}
+
+	metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "DeleteEpisode", metrics.Success)
+
+	return true, nil
+}
+
+// episodeEntitiesFromInput rejects negative or repeated episode numbers and repeated
+// ids, since either would make the upsert write the same row twice
+func episodeEntitiesFromInput(inputs []*model.EpisodeInput) ([]*anime2.AnimeEpisode, error) {
+	episodeEntities := make([]*anime2.AnimeEpisode, 0, len(inputs))
+	seenNumbers := make(map[int]bool, len(inputs))
+	seenIDs := make(map[string]bool, len(inputs))
+	for i, input := range inputs {
+		if input.EpisodeNumber < 0 {
+			return nil, inputError("episodes", "episodes[%d].episodeNumber must not be negative", i)
+		}
+		if seenNumbers[input.EpisodeNumber] {
+			return nil, inputError("episodes", "episode %d is given more than once", input.EpisodeNumber)
+		}
+		seenNumbers[input.EpisodeNumber] = true
+
+		episodeEntity := &anime2.AnimeEpisode{
+			TitleEn:  trimmedString(input.TitleEn),
+			TitleJp:  trimmedString(input.TitleJp),
+			Synopsis: trimmedString(input.Synopsis),
+		}
+		if input.ID != nil {
+			episodeEntity.ID = strings.TrimSpace(*input.ID)
+			if seenIDs[episodeEntity.ID] {
+				return nil, inputError("episodes", "episode id %s is given more than once", episodeEntity.ID)
+			}
+			seenIDs[episodeEntity.ID] = true
+		}
+		number := input.EpisodeNumber
+		episodeEntity.Episode = &number
+		if input.AirDate != nil {
+			aired := input.AirDate.UTC()
+			episodeEntity.Aired = &aired
+		}
+		episodeEntities = append(episodeEntities, episodeEntity)
+	}
+	return episodeEntities, nil
+}
@@ -0,0 +1,97 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/weeb-vip/anime-api/graph/model"
+	anime_repo "github.com/weeb-vip/anime-api/internal/db/repositories/anime"
+	anime_episode_repo "github.com/weeb-vip/anime-api/internal/db/repositories/anime_episode"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestUpsertEpisodes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAnimeService := NewMockAnimeService(ctrl)
+	mockAnimeEpisodeService := NewMockAnimeEpisodeService(ctrl)
+	ctx := context.Background()
+	aired := time.Date(2024, 4, 5, 0, 0, 0, 0, time.UTC)
+
+	t.Run("saves episodes and computes air time", func(t *testing.T) {
+		mockAnimeService.EXPECT().AnimeByID(ctx, "anime-1").
+			Return(&anime_repo.Anime{ID: "anime-1", Broadcast: stringPtr("Fridays at 23:00 (JST)")}, nil)
+		mockAnimeEpisodeService.EXPECT().UpsertEpisodes(ctx, "anime-1", gomock.Len(2)).
+			DoAndReturn(func(_ context.Context, _ string, episodes []*anime_episode_repo.AnimeEpisode) error {
+				for i, episode := range episodes {
+					episode.ID = []string{"ep-1", "ep-2"}[i]
+				}
+				return nil
+			})
+
+		result, err := UpsertEpisodes(ctx, mockAnimeService, mockAnimeEpisodeService, "anime-1", []*model.EpisodeInput{
+			{EpisodeNumber: 1, TitleEn: stringPtr(" The Journey's End "), AirDate: &aired},
+			{EpisodeNumber: 2},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result) != 2 || result[0].ID != "ep-1" || *result[0].TitleEn != "The Journey's End" {
+			t.Fatalf("unexpected result: %+v", result)
+		}
+		if result[0].AirTime == nil || result[0].AirTime.Equal(aired) {
+			t.Errorf("AirTime = %v, want the broadcast time applied", result[0].AirTime)
+		}
+	})
+
+	t.Run("rejects repeated episode numbers", func(t *testing.T) {
+		_, err := UpsertEpisodes(ctx, mockAnimeService, mockAnimeEpisodeService, "anime-1", []*model.EpisodeInput{
+			{EpisodeNumber: 1},
+			{EpisodeNumber: 1},
+		})
+		if errorCode(err) != "BAD_USER_INPUT" {
+			t.Fatalf("expected BAD_USER_INPUT, got %v", err)
+		}
+	})
+
+	t.Run("missing anime", func(t *testing.T) {
+		mockAnimeService.EXPECT().AnimeByID(ctx, "missing").Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := UpsertEpisodes(ctx, mockAnimeService, mockAnimeEpisodeService, "missing", []*model.EpisodeInput{{EpisodeNumber: 1}})
+		if errorCode(err) != "NOT_FOUND" {
+			t.Fatalf("expected NOT_FOUND, got %v", err)
+		}
+	})
+
+	t.Run("episode of another anime", func(t *testing.T) {
+		mockAnimeService.EXPECT().AnimeByID(ctx, "anime-1").Return(&anime_repo.Anime{ID: "anime-1"}, nil)
+		mockAnimeEpisodeService.EXPECT().UpsertEpisodes(ctx, "anime-1", gomock.Any()).Return(anime_episode_repo.ErrEpisodeNotInAnime)
+
+		_, err := UpsertEpisodes(ctx, mockAnimeService, mockAnimeEpisodeService, "anime-1", []*model.EpisodeInput{{ID: stringPtr("ep-9"), EpisodeNumber: 1}})
+		if errorCode(err) != "BAD_USER_INPUT" {
+			t.Fatalf("expected BAD_USER_INPUT, got %v", err)
+		}
+	})
+}
+
+func TestDeleteEpisode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAnimeEpisodeService := NewMockAnimeEpisodeService(ctrl)
+	ctx := context.Background()
+
+	mockAnimeEpisodeService.EXPECT().DeleteEpisode(ctx, "ep-1").Return(nil)
+	deleted, err := DeleteEpisode(ctx, mockAnimeEpisodeService, "ep-1")
+	if err != nil || !deleted {
+		t.Fatalf("DeleteEpisode = %v, %v", deleted, err)
+	}
+
+	mockAnimeEpisodeService.EXPECT().DeleteEpisode(ctx, "missing").Return(gorm.ErrRecordNotFound)
+	if _, err := DeleteEpisode(ctx, mockAnimeEpisodeService, "missing"); errorCode(err) != "NOT_FOUND" {
+		t.Fatalf("expected NOT_FOUND, got %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	anime_repo "github.com/weeb-vip/anime-api/internal/db/repositories/anime"
+	anime_episode_repo "github.com/weeb-vip/anime-api/internal/db/repositories/anime_episode"
+	"go.uber.org/mock/gomock"
+)
+
+func TestEpisodeByIDComputesAirTimeFromBroadcast(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAnimeService := NewMockAnimeService(ctrl)
+	mockAnimeEpisodeService := NewMockAnimeEpisodeService(ctrl)
+
+	ctx := context.Background()
+	aired := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	episodeEntity := &anime_episode_repo.AnimeEpisode{
+		ID:      "ep-1",
+		AnimeID: stringPtr("anime-1"),
+		Episode: intPtr(1),
+		Aired:   &aired,
+	}
+
+	mockAnimeEpisodeService.EXPECT().
+		GetEpisodeByID(ctx, "ep-1").
+		Return(episodeEntity, nil).
+		Times(1)
+	mockAnimeService.EXPECT().
+		AnimeByID(ctx, "anime-1").
+		Return(&anime_repo.Anime{ID: "anime-1", Broadcast: stringPtr("Wednesdays at 01:29 (JST)")}, nil).
+		Times(1)
+
+	episode, err := EpisodeByID(ctx, mockAnimeEpisodeService, mockAnimeService, "ep-1")
+	if err != nil {
+		t.Fatalf("EpisodeByID returned error: %v", err)
+	}
+
+	// 01:29 JST on the 10th is 16:29 UTC on the 9th
+	expected := time.Date(2024, 1, 9, 16, 29, 0, 0, time.UTC)
+	if episode.AirTime == nil || !episode.AirTime.Equal(expected) {
+		t.Errorf("Expected air time %v, got %v", expected, episode.AirTime)
+	}
+	if episode.ID != "ep-1" {
+		t.Errorf("Expected episode ID 'ep-1', got '%s'", episode.ID)
+	}
+}
+
+func TestEpisodeByIDFallsBackToAirDateWhenAnimeMissing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAnimeService := NewMockAnimeService(ctrl)
+	mockAnimeEpisodeService := NewMockAnimeEpisodeService(ctrl)
+
+	ctx := context.Background()
+	aired := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	mockAnimeEpisodeService.EXPECT().
+		GetEpisodeByID(ctx, "ep-1").
+		Return(&anime_episode_repo.AnimeEpisode{ID: "ep-1", AnimeID: stringPtr("anime-1"), Aired: &aired}, nil).
+		Times(1)
+	mockAnimeService.EXPECT().
+		AnimeByID(ctx, "anime-1").
+		Return(nil, errors.New("record not found")).
+		Times(1)
+
+	episode, err := EpisodeByID(ctx, mockAnimeEpisodeService, mockAnimeService, "ep-1")
+	if err != nil {
+		t.Fatalf("EpisodeByID returned error: %v", err)
+	}
+
+	if episode.AirTime == nil || !episode.AirTime.Equal(aired) {
+		t.Errorf("Expected air time to fall back to %v, got %v", aired, episode.AirTime)
+	}
+}
+
+func TestEpisodeByIDReturnsLookupError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAnimeService := NewMockAnimeService(ctrl)
+	mockAnimeEpisodeService := NewMockAnimeEpisodeService(ctrl)
+
+	ctx := context.Background()
+	lookupErr := errors.New("record not found")
+
+	mockAnimeEpisodeService.EXPECT().
+		GetEpisodeByID(ctx, "missing").
+		Return(nil, lookupErr).
+		Times(1)
+
+	_, err := EpisodeByID(ctx, mockAnimeEpisodeService, mockAnimeService, "missing")
+	if !errors.Is(err, lookupErr) {
+		t.Errorf("Expected lookup error, got %v", err)
+	}
+}
@@ -0,0 +1,23 @@
+package resolvers
+
+import (
+	"fmt"
+
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// inputError reports an invalid mutation argument with the offending field in its extensions
+func inputError(field string, format string, args ...interface{}) *gqlerror.Error {
+	return &gqlerror.Error{
+		Message:    fmt.Sprintf(format, args...),
+		Extensions: map[string]interface{}{"code": "BAD_USER_INPUT", "field": field},
+	}
+}
+
+// notFoundError reports that the entity a mutation targets does not exist
+func notFoundError(format string, args ...interface{}) *gqlerror.Error {
+	return &gqlerror.Error{
+		Message:    fmt.Sprintf(format, args...),
+		Extensions: map[string]interface{}{"code": "NOT_FOUND"},
+	}
+}
@@ -0,0 +1,93 @@
+package resolvers
+
+import (
+	"context"
+	"time"
+
+	"github.com/weeb-vip/anime-api/graph/model"
+	"github.com/weeb-vip/anime-api/internal/services/anime"
+	anime_character_staff_link2 "github.com/weeb-vip/anime-api/internal/services/anime_character_staff_link"
+	"github.com/weeb-vip/anime-api/metrics"
+)
+
+const (
+	defaultSharedCastMinShared = 2
+	defaultSharedCastLimit     = 10
+	maxSharedCastLimit         = 50
+)
+
+// SharedCast ranks other anime by the staff their cast shares with an anime. Results are
+// cached per anime; the anime of each result is resolved separately.
+func SharedCast(ctx context.Context, animeCharacterStaffLinkService anime_character_staff_link2.AnimeCharacterStaffLinkImpl, cacheService CacheServiceInterface, animeID string, minShared *int, limit *int) ([]*model.SharedCastResult, error) {
+	startTime := time.Now()
+
+	minimum := defaultSharedCastMinShared
+	if minShared != nil {
+		if *minShared < 1 {
+			metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "SharedCast", metrics.Error)
+			return nil, inputError("minShared", "minShared must be at least 1")
+		}
+		minimum = *minShared
+	}
+	size := defaultSharedCastLimit
+	if limit != nil {
+		if *limit < 1 || *limit > maxSharedCastLimit {
+			metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "SharedCast", metrics.Error)
+			return nil, inputError("limit", "limit must be between 1 and %d", maxSharedCastLimit)
+		}
+		size = *limit
+	}
+
+	var cacheKey string
+	if cacheService != nil {
+		cacheKey = cacheService.GetKeyBuilder().SharedCast(animeID, minimum, size)
+		var cachedResult []*model.SharedCastResult
+		if err := cacheService.GetJSON(ctx, cacheKey, &cachedResult); err == nil {
+			metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "SharedCast", "cache_hit")
+			return cachedResult, nil
+		}
+	}
+
+	found, err := animeCharacterStaffLinkService.FindSharedCastByAnimeId(ctx, animeID, minimum, size)
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "SharedCast", metrics.Error)
+		return nil, err
+	}
+
+	results := make([]*model.SharedCastResult, 0, len(found))
+	for _, shared := range found {
+		staff := make([]*model.AnimeStaff, 0, len(shared.Staff))
+		for _, animeStaff := range shared.Staff {
+			staff = append(staff, convertStaffToGraphql(animeStaff))
+		}
+		results = append(results, &model.SharedCastResult{
+			AnimeID:     shared.AnimeID,
+			SharedCount: shared.SharedCount,
+			SharedStaff: staff,
+		})
+	}
+
+	if cacheService != nil {
+		go func() {
+			_ = cacheService.SetJSON(context.Background(), cacheKey, results, cacheService.GetSharedCastTTL())
+		}()
+	}
+
+	metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "SharedCast", metrics.Success)
+
+	return results, nil
+}
+
+// SharedCastAnime returns the anime of a shared cast result, or nil when it no longer exists
+func SharedCastAnime(ctx context.Context, animeService anime.AnimeServiceImpl, result *model.SharedCastResult) (*model.Anime, error) {
+	startTime := time.Now()
+
+	animeModel, err := loadAnime(ctx, animeService, result.AnimeID)
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "SharedCastAnime", metrics.Error)
+		return nil, err
+	}
+
+	metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "SharedCastAnime", metrics.Success)
+	return animeModel, nil
+}
@@ -0,0 +1,50 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_character_staff_link"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_staff"
+)
+
+func TestSharedCast(t *testing.T) {
+	service := &fakeCharacterStaffLinkService{shared: []*anime_character_staff_link.SharedCast{
+		{AnimeID: "a2", SharedCount: 3, Staff: []anime_staff.AnimeStaff{{ID: "s1"}, {ID: "s2"}, {ID: "s3"}}},
+		{AnimeID: "a3", SharedCount: 2, Staff: []anime_staff.AnimeStaff{{ID: "s1"}, {ID: "s4"}}},
+	}}
+
+	results, err := SharedCast(context.Background(), service, nil, "a1", nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if service.lastShared != [2]int{defaultSharedCastMinShared, defaultSharedCastLimit} {
+		t.Errorf("expected the default minShared and limit, got %v", service.lastShared)
+	}
+	if len(results) != 2 || results[0].AnimeID != "a2" || results[0].SharedCount != 3 || len(results[0].SharedStaff) != 3 {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+	if results[1].SharedStaff[1].ID != "s4" {
+		t.Errorf("expected the staff shared with a3, got %+v", results[1].SharedStaff)
+	}
+
+	if _, err := SharedCast(context.Background(), service, nil, "a1", intPtr(0), nil); err == nil {
+		t.Error("expected an error for minShared below 1")
+	}
+	if _, err := SharedCast(context.Background(), service, nil, "a1", nil, intPtr(maxSharedCastLimit+1)); err == nil {
+		t.Error("expected an error for a limit over the maximum")
+	}
+}
+
+func TestSharedCastUsesCache(t *testing.T) {
+	service := &fakeCharacterStaffLinkService{}
+	cacheService := NewMockCacheService()
+	cacheService.storage[cacheService.GetKeyBuilder().SharedCast("a1", 3, 5)] = []interface{}{}
+
+	if _, err := SharedCast(context.Background(), service, cacheService, "a1", intPtr(3), intPtr(5)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if service.lastShared != [2]int{} {
+		t.Errorf("expected a cache hit not to query the database, got %v", service.lastShared)
+	}
+}
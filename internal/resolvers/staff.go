@@ -0,0 +1,208 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/weeb-vip/anime-api/graph/model"
+	"github.com/weeb-vip/anime-api/internal/dataloaders"
+	anime2 "github.com/weeb-vip/anime-api/internal/db/repositories/anime"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_character"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_staff"
+	"github.com/weeb-vip/anime-api/internal/services/anime"
+	anime_character_service "github.com/weeb-vip/anime-api/internal/services/anime_character"
+	anime_character_staff_link2 "github.com/weeb-vip/anime-api/internal/services/anime_character_staff_link"
+	anime_staff_service "github.com/weeb-vip/anime-api/internal/services/anime_staff"
+	"github.com/weeb-vip/anime-api/metrics"
+	"gorm.io/gorm"
+)
+
+// StaffByID returns a staff member, or a NOT_FOUND error when there is none with the id
+func StaffByID(ctx context.Context, animeStaffService anime_staff_service.AnimeStaffServiceImpl, id string) (*model.AnimeStaff, error) {
+	startTime := time.Now()
+
+	staff, err := animeStaffService.FindAnimeStaffById(ctx, id)
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "StaffByID", metrics.Error)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, notFoundError("staff %s not found", id)
+		}
+		return nil, err
+	}
+
+	metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "StaffByID", metrics.Success)
+	return convertStaffToGraphql(*staff), nil
+}
+
+// CharacterByID returns a character, or a NOT_FOUND error when there is none with the id
+func CharacterByID(ctx context.Context, animeCharacterService anime_character_service.AnimeCharacterServiceImpl, id string) (*model.AnimeCharacter, error) {
+	startTime := time.Now()
+
+	character, err := animeCharacterService.FindAnimeCharacterById(ctx, id)
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "CharacterByID", metrics.Error)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, notFoundError("character %s not found", id)
+		}
+		return nil, err
+	}
+
+	metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "CharacterByID", metrics.Success)
+	return convertCharacterToGraphql(*character), nil
+}
+
+func SearchStaff(ctx context.Context, animeStaffService anime_staff_service.AnimeStaffServiceImpl, name string, limit *int) ([]*model.AnimeStaff, error) {
+	startTime := time.Now()
+
+	staff, err := searchStaff(ctx, animeStaffService, name, limit)
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "SearchStaff", metrics.Error)
+		return nil, err
+	}
+
+	metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "SearchStaff", metrics.Success)
+	return staff, nil
+}
+
+func searchStaff(ctx context.Context, animeStaffService anime_staff_service.AnimeStaffServiceImpl, name string, limit *int) ([]*model.AnimeStaff, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, inputError("name", "name must not be blank")
+	}
+	size := defaultSearchLimit
+	if limit != nil {
+		if *limit < 1 || *limit > maxSearchLimit {
+			return nil, inputError("limit", "limit must be between 1 and %d", maxSearchLimit)
+		}
+		size = *limit
+	}
+
+	found, err := animeStaffService.SearchAnimeStaffByName(ctx, name, size)
+	if err != nil {
+		return nil, err
+	}
+
+	staff := make([]*model.AnimeStaff, 0, len(found))
+	for _, animeStaff := range found {
+		staff = append(staff, convertStaffToGraphql(*animeStaff))
+	}
+	return staff, nil
+}
+
+// staffCharacters loads the characters of a staff member, batched with the rest of the
+// request when a loader is installed
+func staffCharacters(ctx context.Context, animeCharacterStaffLinkService anime_character_staff_link2.AnimeCharacterStaffLinkImpl, staffID string) ([]anime_character.AnimeCharacter, error) {
+	if loaders := dataloaders.For(ctx); loaders != nil && loaders.StaffCharacters != nil {
+		return loaders.StaffCharacters.Load(ctx, staffID)
+	}
+	characters, err := animeCharacterStaffLinkService.FindCharactersByStaffIds(ctx, []string{staffID})
+	if err != nil {
+		return nil, err
+	}
+	return characters[staffID], nil
+}
+
+// StaffCharacters returns the characters a staff member is linked to
+func StaffCharacters(ctx context.Context, animeCharacterStaffLinkService anime_character_staff_link2.AnimeCharacterStaffLinkImpl, staffID string) ([]*model.AnimeCharacter, error) {
+	startTime := time.Now()
+
+	characters, err := staffCharacters(ctx, animeCharacterStaffLinkService, staffID)
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "StaffCharacters", metrics.Error)
+		return nil, err
+	}
+
+	metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "StaffCharacters", metrics.Success)
+
+	result := make([]*model.AnimeCharacter, 0, len(characters))
+	for _, character := range characters {
+		result = append(result, convertCharacterToGraphql(character))
+	}
+	return result, nil
+}
+
+// StaffRoles returns the filmography of a staff member. The anime of each role is
+// resolved separately so that it is only loaded when selected.
+func StaffRoles(ctx context.Context, animeCharacterStaffLinkService anime_character_staff_link2.AnimeCharacterStaffLinkImpl, staff *model.AnimeStaff) ([]*model.StaffRole, error) {
+	startTime := time.Now()
+
+	characters, err := staffCharacters(ctx, animeCharacterStaffLinkService, staff.ID)
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "StaffRoles", metrics.Error)
+		return nil, err
+	}
+
+	metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "StaffRoles", metrics.Success)
+
+	roles := make([]*model.StaffRole, 0, len(characters))
+	for _, character := range characters {
+		roles = append(roles, &model.StaffRole{
+			Character: convertCharacterToGraphql(character),
+			Language:  staff.Language,
+		})
+	}
+	return roles, nil
+}
+
+// CharacterStaff returns the staff linked to a character
+func CharacterStaff(ctx context.Context, animeCharacterStaffLinkService anime_character_staff_link2.AnimeCharacterStaffLinkImpl, characterID string) ([]*model.AnimeStaff, error) {
+	startTime := time.Now()
+
+	var staff []anime_staff.AnimeStaff
+	var err error
+	if loaders := dataloaders.For(ctx); loaders != nil && loaders.CharacterStaff != nil {
+		staff, err = loaders.CharacterStaff.Load(ctx, characterID)
+	} else {
+		var staffMap map[string][]anime_staff.AnimeStaff
+		staffMap, err = animeCharacterStaffLinkService.FindStaffByCharacterIds(ctx, []string{characterID})
+		staff = staffMap[characterID]
+	}
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "CharacterStaff", metrics.Error)
+		return nil, err
+	}
+
+	metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "CharacterStaff", metrics.Success)
+
+	result := make([]*model.AnimeStaff, 0, len(staff))
+	for _, animeStaff := range staff {
+		result = append(result, convertStaffToGraphql(animeStaff))
+	}
+	return result, nil
+}
+
+// loadAnime returns an anime, batched with the rest of the request when a loader is
+// installed, or nil when it no longer exists
+func loadAnime(ctx context.Context, animeService anime.AnimeServiceImpl, animeID string) (*model.Anime, error) {
+	var animeEntity *anime2.Anime
+	var err error
+	if loaders := dataloaders.For(ctx); loaders != nil && loaders.Anime != nil {
+		animeEntity, err = loaders.Anime.Load(ctx, animeID)
+	} else {
+		animeEntity, err = animeService.AnimeByID(ctx, animeID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			animeEntity, err = nil, nil
+		}
+	}
+	if err != nil || animeEntity == nil {
+		return nil, err
+	}
+	return transformAnimeToGraphQL(*animeEntity)
+}
+
+// StaffRoleAnime returns the anime a role's character appears in, or nil when it no
+// longer exists
+func StaffRoleAnime(ctx context.Context, animeService anime.AnimeServiceImpl, role *model.StaffRole) (*model.Anime, error) {
+	startTime := time.Now()
+
+	animeModel, err := loadAnime(ctx, animeService, role.Character.AnimeID)
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "StaffRoleAnime", metrics.Error)
+		return nil, err
+	}
+
+	metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "StaffRoleAnime", metrics.Success)
+	return animeModel, nil
+}
@@ -0,0 +1,148 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/weeb-vip/anime-api/graph/model"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_character"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_character_staff_link"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_staff"
+	anime_character_staff_link2 "github.com/weeb-vip/anime-api/internal/services/anime_character_staff_link"
+	anime_staff_service "github.com/weeb-vip/anime-api/internal/services/anime_staff"
+	"gorm.io/gorm"
+)
+
+// fakeAnimeStaffService serves fixed staff; the embedded interface panics on anything else
+type fakeAnimeStaffService struct {
+	anime_staff_service.AnimeStaffServiceImpl
+	staff     map[string]*anime_staff.AnimeStaff
+	lastLimit int
+}
+
+func (f *fakeAnimeStaffService) FindAnimeStaffById(ctx context.Context, id string) (*anime_staff.AnimeStaff, error) {
+	if staff, ok := f.staff[id]; ok {
+		return staff, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (f *fakeAnimeStaffService) SearchAnimeStaffByName(ctx context.Context, name string, limit int) ([]*anime_staff.AnimeStaff, error) {
+	f.lastLimit = limit
+	var found []*anime_staff.AnimeStaff
+	for _, staff := range f.staff {
+		found = append(found, staff)
+	}
+	return found, nil
+}
+
+// fakeCharacterStaffLinkService serves fixed links; the embedded interface panics on anything else
+type fakeCharacterStaffLinkService struct {
+	anime_character_staff_link2.AnimeCharacterStaffLinkImpl
+	characters map[string][]anime_character.AnimeCharacter
+	staff      map[string][]anime_staff.AnimeStaff
+	cast       []*anime_character_staff_link.AnimeCharacterWithStaff
+	lastFilter anime_character_staff_link.CharacterStaffFilter
+	shared     []*anime_character_staff_link.SharedCast
+	lastShared [2]int
+}
+
+func (f *fakeCharacterStaffLinkService) FindSharedCastByAnimeId(ctx context.Context, animeId string, minShared int, limit int) ([]*anime_character_staff_link.SharedCast, error) {
+	f.lastShared = [2]int{minShared, limit}
+	return f.shared, nil
+}
+
+func (f *fakeCharacterStaffLinkService) FindAnimeCharacterAndStaffByAnimeId(ctx context.Context, animeId string, filter anime_character_staff_link.CharacterStaffFilter) ([]*anime_character_staff_link.AnimeCharacterWithStaff, error) {
+	f.lastFilter = filter
+	return f.cast, nil
+}
+
+func (f *fakeCharacterStaffLinkService) FindCharactersByStaffIds(ctx context.Context, staffIds []string) (map[string][]anime_character.AnimeCharacter, error) {
+	return f.characters, nil
+}
+
+func (f *fakeCharacterStaffLinkService) FindStaffByCharacterIds(ctx context.Context, characterIds []string) (map[string][]anime_staff.AnimeStaff, error) {
+	return f.staff, nil
+}
+
+func TestStaffByID(t *testing.T) {
+	service := &fakeAnimeStaffService{staff: map[string]*anime_staff.AnimeStaff{
+		"s1": {ID: "s1", GivenName: "Kana", FamilyName: "Hanazawa", Language: "Japanese"},
+	}}
+
+	staff, err := StaffByID(context.Background(), service, "s1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if staff.FamilyName != "Hanazawa" || staff.Language == nil || *staff.Language != "Japanese" {
+		t.Errorf("unexpected staff: %+v", staff)
+	}
+
+	_, err = StaffByID(context.Background(), service, "missing")
+	gqlErr, ok := err.(*gqlerror.Error)
+	if !ok || gqlErr.Extensions["code"] != "NOT_FOUND" {
+		t.Errorf("expected a NOT_FOUND error, got %v", err)
+	}
+}
+
+func TestSearchStaff(t *testing.T) {
+	service := &fakeAnimeStaffService{staff: map[string]*anime_staff.AnimeStaff{
+		"s1": {ID: "s1", GivenName: "Kana", FamilyName: "Hanazawa"},
+	}}
+
+	if _, err := SearchStaff(context.Background(), service, "  ", nil); err == nil {
+		t.Error("expected an error for a blank name")
+	}
+	if _, err := SearchStaff(context.Background(), service, "kana", intPtr(101)); err == nil {
+		t.Error("expected an error for a limit over the maximum")
+	}
+
+	staff, err := SearchStaff(context.Background(), service, "kana", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(staff) != 1 || service.lastLimit != defaultSearchLimit {
+		t.Errorf("expected one result searched with the default limit, got %d with limit %d", len(staff), service.lastLimit)
+	}
+}
+
+func TestStaffRoles(t *testing.T) {
+	service := &fakeCharacterStaffLinkService{characters: map[string][]anime_character.AnimeCharacter{
+		"s1": {
+			{ID: "c1", AnimeID: "a1", Name: "Nadeko Sengoku"},
+			{ID: "c2", AnimeID: "a2", Name: "Mayuri Shiina"},
+		},
+	}}
+	language := "Japanese"
+
+	roles, err := StaffRoles(context.Background(), service, &model.AnimeStaff{ID: "s1", Language: &language})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(roles) != 2 {
+		t.Fatalf("expected 2 roles, got %d", len(roles))
+	}
+	if roles[1].Character.AnimeID != "a2" || roles[1].Character.Name != "Mayuri Shiina" || roles[1].Language == nil || *roles[1].Language != language {
+		t.Errorf("unexpected role: %+v", roles[1])
+	}
+
+	characters, err := StaffCharacters(context.Background(), service, "unknown")
+	if err != nil || len(characters) != 0 {
+		t.Errorf("expected no characters, got %v, %v", characters, err)
+	}
+}
+
+func TestCharacterStaff(t *testing.T) {
+	service := &fakeCharacterStaffLinkService{staff: map[string][]anime_staff.AnimeStaff{
+		"c1": {{ID: "s1", GivenName: "Kana", FamilyName: "Hanazawa"}},
+	}}
+
+	staff, err := CharacterStaff(context.Background(), service, "c1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(staff) != 1 || staff[0].ID != "s1" {
+		t.Errorf("unexpected staff: %+v", staff)
+	}
+}
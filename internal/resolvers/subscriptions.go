@@ -0,0 +1,64 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/weeb-vip/anime-api/graph/model"
+	"github.com/weeb-vip/anime-api/internal/services/airing"
+)
+
+// EpisodeAiring streams episode releases of animeIDs, or of every anime when it is empty
+func EpisodeAiring(ctx context.Context, events *airing.Events, animeIDs []string) (<-chan *model.EpisodeAiringEvent, error) {
+	return forwardEvents(ctx, events.SubscribeEpisodeAiring(ctx), animeFilter(animeIDs), func(event airing.EpisodeAiring) (string, *model.EpisodeAiringEvent) {
+		return event.AnimeID, &model.EpisodeAiringEvent{
+			AnimeID:       event.AnimeID,
+			EpisodeNumber: event.EpisodeNumber,
+			AirType:       model.AirType(strings.ToUpper(event.AirType)),
+			AirTime:       event.AirTime,
+		}
+	}), nil
+}
+
+// ScheduleChanged streams schedule changes of animeIDs, or of every anime when it is empty
+func ScheduleChanged(ctx context.Context, events *airing.Events, animeIDs []string) (<-chan *model.ScheduleChangedEvent, error) {
+	return forwardEvents(ctx, events.SubscribeScheduleChanged(ctx), animeFilter(animeIDs), func(event airing.ScheduleChanged) (string, *model.ScheduleChangedEvent) {
+		return event.AnimeID, &model.ScheduleChangedEvent{
+			AnimeID:   event.AnimeID,
+			ChangedAt: event.ChangedAt,
+		}
+	}), nil
+}
+
+// animeFilter returns the set of anime to forward events for, or nil for all of them
+func animeFilter(animeIDs []string) map[string]bool {
+	if len(animeIDs) == 0 {
+		return nil
+	}
+	wanted := make(map[string]bool, len(animeIDs))
+	for _, id := range animeIDs {
+		wanted[id] = true
+	}
+	return wanted
+}
+
+// forwardEvents converts the events of in for gqlgen, dropping those for anime not
+// in wanted. The returned channel closes when in does, which ends the subscription.
+func forwardEvents[E any, M any](ctx context.Context, in <-chan E, wanted map[string]bool, convert func(E) (string, *M)) <-chan *M {
+	out := make(chan *M, 1)
+	go func() {
+		defer close(out)
+		for event := range in {
+			animeID, converted := convert(event)
+			if wanted != nil && !wanted[animeID] {
+				continue
+			}
+			select {
+			case out <- converted:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,44 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/weeb-vip/anime-api/graph/model"
+	"github.com/weeb-vip/anime-api/internal/pubsub"
+	"github.com/weeb-vip/anime-api/internal/services/airing"
+)
+
+func TestEpisodeAiringFiltersByAnime(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	events := airing.NewEvents(pubsub.NewLocalBus())
+
+	stream, err := EpisodeAiring(ctx, events, []string{"wanted"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	airTime := time.Date(2024, 1, 10, 16, 29, 0, 0, time.UTC)
+	_ = events.PublishEpisodeAiring(ctx, airing.EpisodeAiring{AnimeID: "other", EpisodeNumber: 1, AirType: "raw", AirTime: airTime})
+	_ = events.PublishEpisodeAiring(ctx, airing.EpisodeAiring{AnimeID: "wanted", EpisodeNumber: 2, AirType: "sub", AirTime: airTime})
+
+	select {
+	case event := <-stream:
+		if event.AnimeID != "wanted" || event.EpisodeNumber != 2 || event.AirType != model.AirTypeSub || !event.AirTime.Equal(airTime) {
+			t.Errorf("unexpected event: %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the event")
+	}
+
+	cancel()
+	select {
+	case _, ok := <-stream:
+		if ok {
+			t.Error("expected the stream to close once the subscription ends")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("stream not closed after the subscription ended")
+	}
+}
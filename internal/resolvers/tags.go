@@ -0,0 +1,483 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/weeb-vip/anime-api/graph/model"
+	"github.com/weeb-vip/anime-api/internal/dataloaders"
+	anime2 "github.com/weeb-vip/anime-api/internal/db/repositories/anime"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_tag"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/tag"
+	"github.com/weeb-vip/anime-api/internal/services/anime"
+	"github.com/weeb-vip/anime-api/metrics"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultTagLimit = 50
+	maxTagLimit     = 500
+	// maxTagNameLength is the width of tags.name
+	maxTagNameLength = 100
+
+	// minTagWeight and maxTagWeight bound how relevant a tag is to an anime
+	minTagWeight = 1
+	maxTagWeight = 100
+)
+
+func convertTagToGraphql(tagWithCount tag.TagWithCount) *model.Tag {
+	tagModel := &model.Tag{
+		ID:         strconv.FormatInt(tagWithCount.ID, 10),
+		Name:       tagWithCount.Name,
+		AnimeCount: tagWithCount.AnimeCount,
+		IsSpoiler:  tagWithCount.IsSpoiler,
+	}
+	if tagWithCount.Category != nil {
+		category := model.TagCategory(strings.ToUpper(*tagWithCount.Category))
+		tagModel.Category = &category
+	}
+	if tagWithCount.ParentID != nil {
+		parentID := strconv.FormatInt(*tagWithCount.ParentID, 10)
+		tagModel.ParentID = &parentID
+	}
+	return tagModel
+}
+
+// Tags lists the tags starting with prefix, most used first
+func Tags(ctx context.Context, tagRepository tag.TagRepositoryImpl, prefix *string, category *model.TagCategory, includeSpoilers *bool, limit *int) ([]*model.Tag, error) {
+	startTime := time.Now()
+
+	size := defaultTagLimit
+	if limit != nil {
+		if *limit < 1 || *limit > maxTagLimit {
+			metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "Tags", metrics.Error)
+			return nil, inputError("limit", "limit must be between 1 and %d", maxTagLimit)
+		}
+		size = *limit
+	}
+	var filter tag.TagFilter
+	if prefix != nil {
+		filter.Prefix = strings.TrimSpace(*prefix)
+	}
+	if category != nil {
+		filter.Category = strings.ToLower(category.String())
+	}
+	if includeSpoilers != nil {
+		filter.IncludeSpoilers = *includeSpoilers
+	}
+
+	tags, err := tagRepository.ListWithCounts(filter, size)
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "Tags", metrics.Error)
+		return nil, err
+	}
+
+	metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "Tags", metrics.Success)
+
+	results := make([]*model.Tag, len(tags))
+	for i, tagWithCount := range tags {
+		results[i] = convertTagToGraphql(tagWithCount)
+	}
+	return results, nil
+}
+
+// AnimeByTag pages through the anime with all, or with any, of the given tags
+func AnimeByTag(ctx context.Context, animeService anime.AnimeServiceImpl, tags []string, match *model.TagMatch, first *int, after *string) (*model.AnimeConnection, error) {
+	names, err := tagNames("tags", tags)
+	if err == nil && len(names) == 0 {
+		err = inputError("tags", "at least one tag is required")
+	}
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(0, "AnimeByTag", metrics.Error)
+		return nil, err
+	}
+
+	filter := anime2.SearchFilter{Tags: names, Sort: anime2.SortByRanking}
+	if match != nil && *match == model.TagMatchAny {
+		filter = anime2.SearchFilter{AnyTags: names, Sort: anime2.SortByRanking}
+	}
+	return animeConnection(ctx, animeService, "AnimeByTag", filter, first, after)
+}
+
+// SetAnimeTags replaces the tags of an anime, creating the tags that do not exist yet
+func SetAnimeTags(ctx context.Context, animeService anime.AnimeServiceImpl, tagRepository tag.TagRepositoryImpl, animeTagRepository anime_tag.AnimeTagRepositoryImpl, animeID string, tags []string) (*model.Anime, error) {
+	startTime := time.Now()
+
+	animeModel, err := setAnimeTags(ctx, animeService, tagRepository, animeTagRepository, animeID, tags)
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "SetAnimeTags", metrics.Error)
+		return nil, err
+	}
+
+	metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "SetAnimeTags", metrics.Success)
+	return animeModel, nil
+}
+
+func setAnimeTags(ctx context.Context, animeService anime.AnimeServiceImpl, tagRepository tag.TagRepositoryImpl, animeTagRepository anime_tag.AnimeTagRepositoryImpl, animeID string, tags []string) (*model.Anime, error) {
+	names, err := tagNames("tags", tags)
+	if err != nil {
+		return nil, err
+	}
+
+	animeEntity, err := animeService.AnimeByID(ctx, animeID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, notFoundError("anime %s not found", animeID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// names differing only in case resolve to the same tag
+	tagIDs := make([]int64, 0, len(names))
+	tagged := make([]string, 0, len(names))
+	seen := make(map[int64]bool, len(names))
+	for _, name := range names {
+		found, err := tagRepository.FindOrCreate(name)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[found.ID] {
+			seen[found.ID] = true
+			tagIDs = append(tagIDs, found.ID)
+			tagged = append(tagged, found.Name)
+		}
+	}
+
+	if err := animeTagRepository.SetTagsForAnime(animeID, tagIDs); err != nil {
+		return nil, err
+	}
+
+	animeModel, err := transformAnimeToGraphQL(*animeEntity)
+	if err != nil {
+		return nil, err
+	}
+	animeModel.Tags = tagged
+	return animeModel, nil
+}
+
+// RenameTag renames a tag on every anime
+func RenameTag(ctx context.Context, tagRepository tag.TagRepositoryImpl, from string, to string) (*model.Tag, error) {
+	startTime := time.Now()
+
+	renamed, err := renameTag(tagRepository, from, to)
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "RenameTag", metrics.Error)
+		return nil, err
+	}
+
+	metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "RenameTag", metrics.Success)
+	return renamed, nil
+}
+
+func renameTag(tagRepository tag.TagRepositoryImpl, from string, to string) (*model.Tag, error) {
+	from = strings.TrimSpace(from)
+	if from == "" {
+		return nil, inputError("from", "from must not be blank")
+	}
+	to, err := tagName("to", to)
+	if err != nil {
+		return nil, err
+	}
+
+	renamed, err := tagRepository.Rename(from, to)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, notFoundError("tag %s not found", from)
+	}
+	if errors.Is(err, tag.ErrTagExists) {
+		return nil, inputError("to", "tag %s already exists; use mergeTags to combine the two tags", to)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return convertTagToGraphql(*renamed), nil
+}
+
+// MergeTags moves the anime of every source tag onto into and deletes the sources
+func MergeTags(ctx context.Context, tagRepository tag.TagRepositoryImpl, sources []string, into string) (*model.Tag, error) {
+	startTime := time.Now()
+
+	merged, err := mergeTags(tagRepository, sources, into)
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "MergeTags", metrics.Error)
+		return nil, err
+	}
+
+	metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "MergeTags", metrics.Success)
+	return merged, nil
+}
+
+func mergeTags(tagRepository tag.TagRepositoryImpl, sources []string, into string) (*model.Tag, error) {
+	names, err := tagNames("sources", sources)
+	if err == nil && len(names) == 0 {
+		err = inputError("sources", "at least one source tag is required")
+	}
+	if err != nil {
+		return nil, err
+	}
+	into, err = tagName("into", into)
+	if err != nil {
+		return nil, err
+	}
+
+	merged, err := tagRepository.Merge(names, into)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, notFoundError("none of the source tags exist apart from %s", into)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return convertTagToGraphql(*merged), nil
+}
+
+// UpdateTag changes the category, parent or spoiler flag of a tag
+func UpdateTag(ctx context.Context, tagRepository tag.TagRepositoryImpl, name string, input model.UpdateTagInput) (*model.Tag, error) {
+	startTime := time.Now()
+
+	updated, err := updateTag(tagRepository, name, input)
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "UpdateTag", metrics.Error)
+		return nil, err
+	}
+
+	metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "UpdateTag", metrics.Success)
+	return updated, nil
+}
+
+func updateTag(tagRepository tag.TagRepositoryImpl, name string, input model.UpdateTagInput) (*model.Tag, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, inputError("name", "name must not be blank")
+	}
+
+	var values tag.Tag
+	var columns []string
+	if category, ok := input.Category.ValueOK(); ok {
+		if category != nil {
+			if !category.IsValid() {
+				return nil, inputError("category", "%s is not a valid tag category", category.String())
+			}
+			value := strings.ToLower(category.String())
+			values.Category = &value
+		}
+		columns = append(columns, "category")
+	}
+	if parent, ok := input.Parent.ValueOK(); ok {
+		if parent != nil {
+			parentName, err := tagName("parent", *parent)
+			if err != nil {
+				return nil, err
+			}
+			parentTag, err := tagRepository.FindByName(parentName)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, notFoundError("tag %s not found", parentName)
+			}
+			if err != nil {
+				return nil, err
+			}
+			values.ParentID = &parentTag.ID
+		}
+		columns = append(columns, "parent_id")
+	}
+	if input.IsSpoiler != nil {
+		values.IsSpoiler = *input.IsSpoiler
+		columns = append(columns, "is_spoiler")
+	}
+
+	updated, err := tagRepository.Update(name, values, columns)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, notFoundError("tag %s not found", name)
+	}
+	if errors.Is(err, tag.ErrTagCycle) {
+		return nil, inputError("parent", "tag %s can not be put under itself or one of its children", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return convertTagToGraphql(*updated), nil
+}
+
+// SetAnimeTagWeight sets how relevant one of the tags of an anime is to it
+func SetAnimeTagWeight(ctx context.Context, tagRepository tag.TagRepositoryImpl, animeTagRepository anime_tag.AnimeTagRepositoryImpl, animeID string, name string, weight int) (*model.AnimeTag, error) {
+	startTime := time.Now()
+
+	animeTag, err := setAnimeTagWeight(tagRepository, animeTagRepository, animeID, name, weight)
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "SetAnimeTagWeight", metrics.Error)
+		return nil, err
+	}
+
+	metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "SetAnimeTagWeight", metrics.Success)
+	return animeTag, nil
+}
+
+func setAnimeTagWeight(tagRepository tag.TagRepositoryImpl, animeTagRepository anime_tag.AnimeTagRepositoryImpl, animeID string, name string, weight int) (*model.AnimeTag, error) {
+	if weight < minTagWeight || weight > maxTagWeight {
+		return nil, inputError("weight", "weight must be between %d and %d", minTagWeight, maxTagWeight)
+	}
+	name, err := tagName("tag", name)
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := tagRepository.FindByName(name)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, notFoundError("tag %s not found", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = animeTagRepository.SetTagWeight(animeID, found.ID, weight)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, notFoundError("anime %s is not tagged %s", animeID, found.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &model.AnimeTag{TagID: strconv.FormatInt(found.ID, 10), Weight: weight}, nil
+}
+
+// AnimeTagDetails returns the tags of an anime, most relevant first, leaving out
+// spoiler tags unless includeSpoilers is set
+func AnimeTagDetails(ctx context.Context, animeTagRepository anime_tag.AnimeTagRepositoryImpl, animeID string, includeSpoilers *bool) ([]*model.AnimeTag, error) {
+	var details []anime_tag.AnimeTagDetail
+	var err error
+	if loaders := dataloaders.For(ctx); loaders != nil && loaders.TagDetails != nil {
+		details, err = loaders.TagDetails.Load(ctx, animeID)
+	} else {
+		var detailMap map[string][]anime_tag.AnimeTagDetail
+		detailMap, err = animeTagRepository.GetTagDetailsForAnimeIDs([]string{animeID})
+		details = detailMap[animeID]
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	showSpoilers := includeSpoilers != nil && *includeSpoilers
+	animeTags := make([]*model.AnimeTag, 0, len(details))
+	for _, detail := range details {
+		if detail.IsSpoiler && !showSpoilers {
+			continue
+		}
+		animeTags = append(animeTags, &model.AnimeTag{
+			TagID:  strconv.FormatInt(detail.TagID, 10),
+			Weight: detail.Weight,
+		})
+	}
+	return animeTags, nil
+}
+
+// AnimeTagTag returns the tag of an anime's tag entry
+func AnimeTagTag(ctx context.Context, tagRepository tag.TagRepositoryImpl, obj *model.AnimeTag) (*model.Tag, error) {
+	if obj.Tag != nil {
+		return obj.Tag, nil
+	}
+	found, err := loadTag(ctx, tagRepository, obj.TagID)
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, notFoundError("tag %s not found", obj.TagID)
+	}
+	return found, nil
+}
+
+// TagParent returns the broader tag a tag belongs under, if any
+func TagParent(ctx context.Context, tagRepository tag.TagRepositoryImpl, obj *model.Tag) (*model.Tag, error) {
+	if obj.Parent != nil {
+		return obj.Parent, nil
+	}
+	if obj.ParentID == nil {
+		return nil, nil
+	}
+	return loadTag(ctx, tagRepository, *obj.ParentID)
+}
+
+// TagChildren returns the narrower tags under a tag
+func TagChildren(ctx context.Context, tagRepository tag.TagRepositoryImpl, obj *model.Tag) ([]*model.Tag, error) {
+	if obj.Children != nil {
+		return obj.Children, nil
+	}
+	id, err := strconv.ParseInt(obj.ID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	var children []tag.TagWithCount
+	if loaders := dataloaders.For(ctx); loaders != nil && loaders.TagChildren != nil {
+		children, err = loaders.TagChildren.Load(ctx, id)
+	} else {
+		var childMap map[int64][]tag.TagWithCount
+		childMap, err = tagRepository.FindChildren([]int64{id})
+		children = childMap[id]
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*model.Tag, len(children))
+	for i, child := range children {
+		results[i] = convertTagToGraphql(child)
+	}
+	return results, nil
+}
+
+// loadTag loads a tag by ID, batched with the rest of the request when a loader is
+// installed; it returns nil when there is no such tag
+func loadTag(ctx context.Context, tagRepository tag.TagRepositoryImpl, tagID string) (*model.Tag, error) {
+	id, err := strconv.ParseInt(tagID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	var found tag.TagWithCount
+	if loaders := dataloaders.For(ctx); loaders != nil && loaders.TagsByID != nil {
+		found, err = loaders.TagsByID.Load(ctx, id)
+	} else {
+		var tagMap map[int64]tag.TagWithCount
+		tagMap, err = tagRepository.FindWithCountsByIDs([]int64{id})
+		found = tagMap[id]
+	}
+	if err != nil {
+		return nil, err
+	}
+	if found.ID == 0 {
+		return nil, nil
+	}
+	return convertTagToGraphql(found), nil
+}
+
+// tagName trims a tag name and checks it fits the tags table
+func tagName(field string, name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", inputError(field, "%s must not be blank", field)
+	}
+	if len([]rune(name)) > maxTagNameLength {
+		return "", inputError(field, "%s must be at most %d characters", field, maxTagNameLength)
+	}
+	return name, nil
+}
+
+// tagNames trims and de-duplicates a list of tag names
+func tagNames(field string, names []string) ([]string, error) {
+	cleaned := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for i, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, inputError(field, "%s[%d] must not be blank", field, i)
+		}
+		if len([]rune(name)) > maxTagNameLength {
+			return nil, inputError(field, "%s[%d] must be at most %d characters", field, i, maxTagNameLength)
+		}
+		if !seen[name] {
+			seen[name] = true
+			cleaned = append(cleaned, name)
+		}
+	}
+	return cleaned, nil
+}
@@ -0,0 +1,376 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/weeb-vip/anime-api/graph/model"
+	anime_repo "github.com/weeb-vip/anime-api/internal/db/repositories/anime"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_tag"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/tag"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// fakeTagRepository keeps tags by name, matching names case-insensitively like the
+// tags table; the embedded interface panics on anything else
+type fakeTagRepository struct {
+	tag.TagRepositoryImpl
+	tags   map[string]*tag.Tag
+	counts map[int64]int
+	listed struct {
+		filter tag.TagFilter
+		limit  int
+	}
+}
+
+func (f *fakeTagRepository) FindOrCreate(name string) (*tag.Tag, error) {
+	if found, ok := f.tags[strings.ToLower(name)]; ok {
+		return found, nil
+	}
+	created := &tag.Tag{ID: int64(len(f.tags) + 1), Name: name}
+	f.tags[strings.ToLower(name)] = created
+	return created, nil
+}
+
+func (f *fakeTagRepository) ListWithCounts(filter tag.TagFilter, limit int) ([]tag.TagWithCount, error) {
+	f.listed.filter, f.listed.limit = filter, limit
+	var tags []tag.TagWithCount
+	for _, found := range f.tags {
+		tags = append(tags, tag.TagWithCount{Tag: *found, AnimeCount: f.counts[found.ID]})
+	}
+	return tags, nil
+}
+
+func (f *fakeTagRepository) Rename(from string, to string) (*tag.TagWithCount, error) {
+	found, ok := f.tags[strings.ToLower(from)]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	if taken, ok := f.tags[strings.ToLower(to)]; ok && taken.ID != found.ID {
+		return nil, tag.ErrTagExists
+	}
+	found.Name = to
+	return &tag.TagWithCount{Tag: *found, AnimeCount: f.counts[found.ID]}, nil
+}
+
+func (f *fakeTagRepository) Merge(sources []string, into string) (*tag.TagWithCount, error) {
+	target, _ := f.FindOrCreate(into)
+	merged := false
+	for _, source := range sources {
+		if found, ok := f.tags[strings.ToLower(source)]; ok && found.ID != target.ID {
+			f.counts[target.ID] += f.counts[found.ID]
+			delete(f.tags, strings.ToLower(source))
+			merged = true
+		}
+	}
+	if !merged {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &tag.TagWithCount{Tag: *target, AnimeCount: f.counts[target.ID]}, nil
+}
+
+func (f *fakeTagRepository) FindByName(name string) (*tag.Tag, error) {
+	if found, ok := f.tags[strings.ToLower(name)]; ok {
+		return found, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (f *fakeTagRepository) Update(name string, values tag.Tag, columns []string) (*tag.TagWithCount, error) {
+	found, ok := f.tags[strings.ToLower(name)]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	for _, column := range columns {
+		switch column {
+		case "category":
+			found.Category = values.Category
+		case "parent_id":
+			if values.ParentID != nil && *values.ParentID == found.ID {
+				return nil, tag.ErrTagCycle
+			}
+			found.ParentID = values.ParentID
+		case "is_spoiler":
+			found.IsSpoiler = values.IsSpoiler
+		}
+	}
+	return &tag.TagWithCount{Tag: *found, AnimeCount: f.counts[found.ID]}, nil
+}
+
+// fakeAnimeTagRepository records the tags set on each anime
+type fakeAnimeTagRepository struct {
+	anime_tag.AnimeTagRepositoryImpl
+	set     map[string][]int64
+	details map[string][]anime_tag.AnimeTagDetail
+	weights map[string]int
+}
+
+func (f *fakeAnimeTagRepository) SetTagsForAnime(animeID string, tagIDs []int64) error {
+	f.set[animeID] = tagIDs
+	return nil
+}
+
+func (f *fakeAnimeTagRepository) GetTagDetailsForAnimeIDs(animeIDs []string) (map[string][]anime_tag.AnimeTagDetail, error) {
+	return f.details, nil
+}
+
+func (f *fakeAnimeTagRepository) SetTagWeight(animeID string, tagID int64, weight int) error {
+	for _, tagged := range f.set[animeID] {
+		if tagged == tagID {
+			f.weights[animeID] = weight
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+func TestTags(t *testing.T) {
+	ctx := context.Background()
+	repository := &fakeTagRepository{
+		tags:   map[string]*tag.Tag{"action": {ID: 7, Name: "Action"}},
+		counts: map[int64]int{7: 120},
+	}
+
+	prefix := "  Act "
+	tags, err := Tags(ctx, repository, &prefix, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repository.listed.filter != (tag.TagFilter{Prefix: "Act"}) || repository.listed.limit != defaultTagLimit {
+		t.Errorf("listed with %+v", repository.listed)
+	}
+	if len(tags) != 1 || tags[0].ID != "7" || tags[0].Name != "Action" || tags[0].AnimeCount != 120 {
+		t.Errorf("unexpected tags: %+v", tags)
+	}
+
+	tooMany := maxTagLimit + 1
+	if _, err := Tags(ctx, repository, nil, nil, nil, &tooMany); errorCode(err) != "BAD_USER_INPUT" {
+		t.Errorf("expected BAD_USER_INPUT for limit, got %v", err)
+	}
+}
+
+func TestAnimeByTag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAnimeService := NewMockAnimeService(ctrl)
+	ctx := context.Background()
+	page := []*anime_repo.Anime{{ID: "a"}}
+
+	t.Run("matches every tag by default", func(t *testing.T) {
+		filter := anime_repo.SearchFilter{Tags: []string{"Action", "Comedy"}, Sort: anime_repo.SortByRanking}
+		mockAnimeService.EXPECT().AnimePage(ctx, filter, nil, defaultConnectionSize+1, false).Return(page, nil)
+
+		connection, err := AnimeByTag(ctx, mockAnimeService, []string{" Action", "Comedy", "Action"}, nil, nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(connection.Edges) != 1 || connection.PageInfo.HasNextPage {
+			t.Errorf("unexpected connection: %+v %+v", connection.Edges, connection.PageInfo)
+		}
+	})
+
+	t.Run("matches any tag", func(t *testing.T) {
+		filter := anime_repo.SearchFilter{AnyTags: []string{"Action", "Comedy"}, Sort: anime_repo.SortByRanking}
+		mockAnimeService.EXPECT().AnimePage(ctx, filter, nil, defaultConnectionSize+1, false).Return(page, nil)
+
+		match := model.TagMatchAny
+		if _, err := AnimeByTag(ctx, mockAnimeService, []string{"Action", "Comedy"}, &match, nil, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("requires a tag", func(t *testing.T) {
+		if _, err := AnimeByTag(ctx, mockAnimeService, nil, nil, nil, nil); errorCode(err) != "BAD_USER_INPUT" {
+			t.Errorf("expected BAD_USER_INPUT, got %v", err)
+		}
+		if _, err := AnimeByTag(ctx, mockAnimeService, []string{" "}, nil, nil, nil); errorCode(err) != "BAD_USER_INPUT" {
+			t.Errorf("expected BAD_USER_INPUT for a blank tag, got %v", err)
+		}
+	})
+}
+
+func TestSetAnimeTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAnimeService := NewMockAnimeService(ctrl)
+	ctx := context.Background()
+
+	t.Run("creates missing tags and sets them once each", func(t *testing.T) {
+		tagRepository := &fakeTagRepository{tags: map[string]*tag.Tag{"action": {ID: 1, Name: "Action"}}}
+		animeTagRepository := &fakeAnimeTagRepository{set: map[string][]int64{}}
+		mockAnimeService.EXPECT().AnimeByID(ctx, "anime-1").Return(&anime_repo.Anime{ID: "anime-1", TitleEn: stringPtr("Frieren")}, nil)
+
+		animeModel, err := SetAnimeTags(ctx, mockAnimeService, tagRepository, animeTagRepository, "anime-1", []string{"action", "Fantasy", "Action "})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := animeTagRepository.set["anime-1"]; len(got) != 2 || got[0] != 1 || got[1] != 2 {
+			t.Errorf("set tag ids %v", got)
+		}
+		if len(animeModel.Tags) != 2 || animeModel.Tags[0] != "Action" || animeModel.Tags[1] != "Fantasy" {
+			t.Errorf("unexpected tags on anime: %v", animeModel.Tags)
+		}
+	})
+
+	t.Run("an empty list clears the tags", func(t *testing.T) {
+		animeTagRepository := &fakeAnimeTagRepository{set: map[string][]int64{}}
+		mockAnimeService.EXPECT().AnimeByID(ctx, "anime-1").Return(&anime_repo.Anime{ID: "anime-1"}, nil)
+
+		if _, err := SetAnimeTags(ctx, mockAnimeService, &fakeTagRepository{tags: map[string]*tag.Tag{}}, animeTagRepository, "anime-1", nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got, ok := animeTagRepository.set["anime-1"]; !ok || len(got) != 0 {
+			t.Errorf("expected tags to be cleared, got %v", got)
+		}
+	})
+
+	t.Run("unknown anime", func(t *testing.T) {
+		mockAnimeService.EXPECT().AnimeByID(ctx, "missing").Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := SetAnimeTags(ctx, mockAnimeService, &fakeTagRepository{tags: map[string]*tag.Tag{}}, &fakeAnimeTagRepository{set: map[string][]int64{}}, "missing", []string{"Action"})
+		if errorCode(err) != "NOT_FOUND" {
+			t.Errorf("expected NOT_FOUND, got %v", err)
+		}
+	})
+
+	t.Run("rejects overlong tags", func(t *testing.T) {
+		_, err := SetAnimeTags(ctx, mockAnimeService, &fakeTagRepository{tags: map[string]*tag.Tag{}}, &fakeAnimeTagRepository{set: map[string][]int64{}}, "anime-1", []string{strings.Repeat("a", maxTagNameLength+1)})
+		if errorCode(err) != "BAD_USER_INPUT" {
+			t.Errorf("expected BAD_USER_INPUT, got %v", err)
+		}
+	})
+}
+
+func TestRenameTag(t *testing.T) {
+	ctx := context.Background()
+	repository := &fakeTagRepository{
+		tags:   map[string]*tag.Tag{"sci fi": {ID: 1, Name: "Sci Fi"}, "drama": {ID: 2, Name: "Drama"}},
+		counts: map[int64]int{1: 3},
+	}
+
+	renamed, err := RenameTag(ctx, repository, "Sci Fi", " Sci-Fi ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if renamed.ID != "1" || renamed.Name != "Sci-Fi" || renamed.AnimeCount != 3 {
+		t.Errorf("unexpected tag: %+v", renamed)
+	}
+
+	if _, err := RenameTag(ctx, repository, "Sci Fi", "Drama"); errorCode(err) != "BAD_USER_INPUT" || !strings.Contains(err.Error(), "mergeTags") {
+		t.Errorf("expected BAD_USER_INPUT pointing at mergeTags, got %v", err)
+	}
+	if _, err := RenameTag(ctx, repository, "Missing", "Other"); errorCode(err) != "NOT_FOUND" {
+		t.Errorf("expected NOT_FOUND, got %v", err)
+	}
+	if _, err := RenameTag(ctx, repository, "Drama", " "); errorCode(err) != "BAD_USER_INPUT" {
+		t.Errorf("expected BAD_USER_INPUT for a blank name, got %v", err)
+	}
+}
+
+func TestMergeTags(t *testing.T) {
+	ctx := context.Background()
+	repository := &fakeTagRepository{
+		tags:   map[string]*tag.Tag{"scifi": {ID: 1, Name: "SciFi"}, "sci fi": {ID: 2, Name: "Sci Fi"}, "sci-fi": {ID: 3, Name: "Sci-Fi"}},
+		counts: map[int64]int{1: 2, 2: 3, 3: 5},
+	}
+
+	merged, err := MergeTags(ctx, repository, []string{"SciFi", "Sci Fi"}, "Sci-Fi")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if merged.ID != "3" || merged.AnimeCount != 10 {
+		t.Errorf("unexpected tag: %+v", merged)
+	}
+
+	if _, err := MergeTags(ctx, repository, []string{"SciFi"}, "Sci-Fi"); errorCode(err) != "NOT_FOUND" {
+		t.Errorf("expected NOT_FOUND once the sources are gone, got %v", err)
+	}
+	if _, err := MergeTags(ctx, repository, nil, "Sci-Fi"); errorCode(err) != "BAD_USER_INPUT" {
+		t.Errorf("expected BAD_USER_INPUT without sources, got %v", err)
+	}
+}
+
+func TestUpdateTag(t *testing.T) {
+	ctx := context.Background()
+	repository := &fakeTagRepository{tags: map[string]*tag.Tag{
+		"fantasy": {ID: 1, Name: "Fantasy"},
+		"isekai":  {ID: 2, Name: "Isekai", IsSpoiler: true},
+	}}
+
+	theme := model.TagCategoryTheme
+	updated, err := UpdateTag(ctx, repository, "Isekai", model.UpdateTagInput{
+		Category: graphql.OmittableOf(&theme),
+		Parent:   graphql.OmittableOf(stringPtr("Fantasy")),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.Category == nil || *updated.Category != model.TagCategoryTheme || updated.ParentID == nil || *updated.ParentID != "1" {
+		t.Errorf("unexpected tag: %+v", updated)
+	}
+	// omitted fields are left as they are
+	if !updated.IsSpoiler {
+		t.Errorf("expected isSpoiler to be left set")
+	}
+
+	cleared, err := UpdateTag(ctx, repository, "Isekai", model.UpdateTagInput{Parent: graphql.OmittableOf[*string](nil)})
+	if err != nil || cleared.ParentID != nil || cleared.Category == nil {
+		t.Errorf("expected only the parent to be cleared, got %+v, %v", cleared, err)
+	}
+
+	if _, err := UpdateTag(ctx, repository, "Fantasy", model.UpdateTagInput{Parent: graphql.OmittableOf(stringPtr("Fantasy"))}); errorCode(err) != "BAD_USER_INPUT" {
+		t.Errorf("expected BAD_USER_INPUT for a cycle, got %v", err)
+	}
+	if _, err := UpdateTag(ctx, repository, "Isekai", model.UpdateTagInput{Parent: graphql.OmittableOf(stringPtr("Missing"))}); errorCode(err) != "NOT_FOUND" {
+		t.Errorf("expected NOT_FOUND for an unknown parent, got %v", err)
+	}
+}
+
+func TestSetAnimeTagWeight(t *testing.T) {
+	ctx := context.Background()
+	tagRepository := &fakeTagRepository{tags: map[string]*tag.Tag{"action": {ID: 1, Name: "Action"}}}
+	animeTagRepository := &fakeAnimeTagRepository{set: map[string][]int64{"anime-1": {1}}, weights: map[string]int{}}
+
+	animeTag, err := SetAnimeTagWeight(ctx, tagRepository, animeTagRepository, "anime-1", "action", 60)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if animeTag.TagID != "1" || animeTag.Weight != 60 || animeTagRepository.weights["anime-1"] != 60 {
+		t.Errorf("unexpected anime tag: %+v", animeTag)
+	}
+
+	if _, err := SetAnimeTagWeight(ctx, tagRepository, animeTagRepository, "anime-2", "Action", 60); errorCode(err) != "NOT_FOUND" {
+		t.Errorf("expected NOT_FOUND for an untagged anime, got %v", err)
+	}
+	if _, err := SetAnimeTagWeight(ctx, tagRepository, animeTagRepository, "anime-1", "Action", maxTagWeight+1); errorCode(err) != "BAD_USER_INPUT" {
+		t.Errorf("expected BAD_USER_INPUT for weight, got %v", err)
+	}
+}
+
+func TestAnimeTagDetails(t *testing.T) {
+	ctx := context.Background()
+	repository := &fakeAnimeTagRepository{details: map[string][]anime_tag.AnimeTagDetail{
+		"anime-1": {
+			{AnimeID: "anime-1", TagID: 1, Name: "Fantasy", Weight: 100},
+			{AnimeID: "anime-1", TagID: 2, Name: "Time Loop", Weight: 80, IsSpoiler: true},
+		},
+	}}
+
+	details, err := AnimeTagDetails(ctx, repository, "anime-1", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(details) != 1 || details[0].TagID != "1" || details[0].Weight != 100 {
+		t.Errorf("expected spoilers to be hidden, got %+v", details)
+	}
+
+	includeSpoilers := true
+	if details, err := AnimeTagDetails(ctx, repository, "anime-1", &includeSpoilers); err != nil || len(details) != 2 {
+		t.Errorf("expected spoilers to be included, got %+v, %v", details, err)
+	}
+}
@@ -1,6 +1,7 @@
 package resolvers
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -27,4 +28,18 @@ func convertToJapanTime(t *time.Time) *time.Time {
 	// we assume it's already in Japan local time and just assign the timezone
 	japanTime := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), jst)
 	return &japanTime
-}
\ No newline at end of file
+}
+
+// loadViewerTimezone loads the IANA timezone a viewer asked for, such as
+// Europe/Berlin. Names are case sensitive; "Local" is refused since it means the
+// server's zone, not the viewer's.
+func loadViewerTimezone(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, fmt.Errorf("unknown timezone %q", name)
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q", name)
+	}
+	return location, nil
+}
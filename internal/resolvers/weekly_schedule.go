@@ -0,0 +1,139 @@
+package resolvers
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/weeb-vip/anime-api/graph/model"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/episode_air_time"
+	"github.com/weeb-vip/anime-api/internal/services/airing"
+	"github.com/weeb-vip/anime-api/internal/services/anime"
+	"github.com/weeb-vip/anime-api/metrics"
+)
+
+const (
+	scheduleDays = 7
+	dateLayout   = "2006-01-02"
+)
+
+// airTypeOrder lists releases of the same moment raw first
+var airTypeOrder = map[model.AirType]int{model.AirTypeRaw: 0, model.AirTypeSub: 1, model.AirTypeDub: 2}
+
+func WeeklySchedule(ctx context.Context, animeService anime.AnimeServiceImpl, episodeAirTimeRepository episode_air_time.EpisodeAirTimeRepositoryImpl, weekStart string, timezone string, airType *model.AirType) (*model.WeeklySchedule, error) {
+	startTime := time.Now()
+
+	schedule, err := weeklySchedule(ctx, animeService, episodeAirTimeRepository, weekStart, timezone, airType)
+	if err != nil {
+		metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "WeeklySchedule", metrics.Error)
+		return nil, err
+	}
+
+	metrics.GetAppMetrics().ResolverMetric(float64(time.Since(startTime).Milliseconds()), "WeeklySchedule", metrics.Success)
+
+	return schedule, nil
+}
+
+// weeklySchedule buckets the releases of the week by their date in timezone. Days
+// are told apart by date rather than by 24 hour steps, so a DST change makes one day
+// 23 or 25 hours long instead of shifting the rest of the week. Late night slots such
+// as "25:30 (JST)" are already placed on the following day by ParseAirTime.
+func weeklySchedule(ctx context.Context, animeService anime.AnimeServiceImpl, episodeAirTimeRepository episode_air_time.EpisodeAirTimeRepositoryImpl, weekStart string, timezone string, airType *model.AirType) (*model.WeeklySchedule, error) {
+	location, err := loadViewerTimezone(timezone)
+	if err != nil {
+		return nil, inputError("timezone", "timezone must be an IANA zone such as Asia/Tokyo")
+	}
+	start, err := time.ParseInLocation(dateLayout, weekStart, location)
+	if err != nil {
+		return nil, inputError("weekStart", "weekStart must be a date such as 2024-04-01")
+	}
+
+	schedule := &model.WeeklySchedule{
+		WeekStart: start.Format(dateLayout),
+		Timezone:  location.String(),
+		Days:      make([]*model.ScheduleDay, 0, scheduleDays),
+	}
+	dayIndex := make(map[string]int, scheduleDays)
+	for i := 0; i < scheduleDays; i++ {
+		day := start.AddDate(0, 0, i)
+		dayIndex[day.Format(dateLayout)] = i
+		schedule.Days = append(schedule.Days, &model.ScheduleDay{
+			Date:     day.Format(dateLayout),
+			Weekday:  day.Weekday().String(),
+			Episodes: []*model.ScheduledEpisode{},
+		})
+	}
+
+	// A broadcast slot or the viewer's offset can move a release a day away from
+	// its air date, so episodes are loaded a day either side of the week
+	from := start.AddDate(0, 0, -1)
+	to := start.AddDate(0, 0, scheduleDays+1)
+	animes, err := animeService.AiringAnimeWithEpisodes(ctx, &from, &to, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(animes))
+	for _, animeEntity := range animes {
+		ids = append(ids, animeEntity.ID)
+	}
+	airTimes, err := episodeAirTimeRepository.FindByAnimeIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, animeEntity := range animes {
+		animeModel, err := transformAnimeToGraphQL(*animeEntity)
+		if err != nil {
+			return nil, err
+		}
+		episodesByNumber := make(map[int]*model.Episode, len(animeModel.Episodes))
+		for _, episode := range animeModel.Episodes {
+			if episode.EpisodeNumber != nil {
+				episodesByNumber[*episode.EpisodeNumber] = episode
+			}
+		}
+
+		for _, release := range airing.Releases(animeEntity, airTimes[animeEntity.ID]) {
+			releaseType := model.AirType(strings.ToUpper(release.AirType))
+			if airType != nil && releaseType != *airType {
+				continue
+			}
+			local := release.AirTime.In(location)
+			i, ok := dayIndex[local.Format(dateLayout)]
+			if !ok {
+				continue
+			}
+			schedule.Days[i].Episodes = append(schedule.Days[i].Episodes, &model.ScheduledEpisode{
+				Anime:         animeModel,
+				Episode:       episodesByNumber[release.EpisodeNumber],
+				EpisodeNumber: release.EpisodeNumber,
+				AirType:       releaseType,
+				AirTime:       release.AirTime,
+				LocalTime:     local.Format("15:04"),
+			})
+		}
+	}
+
+	for _, day := range schedule.Days {
+		sortScheduledEpisodes(day.Episodes)
+	}
+	return schedule, nil
+}
+
+func sortScheduledEpisodes(episodes []*model.ScheduledEpisode) {
+	sort.SliceStable(episodes, func(i, j int) bool {
+		a, b := episodes[i], episodes[j]
+		if !a.AirTime.Equal(b.AirTime) {
+			return a.AirTime.Before(b.AirTime)
+		}
+		if a.Anime.ID != b.Anime.ID {
+			return a.Anime.ID < b.Anime.ID
+		}
+		if a.EpisodeNumber != b.EpisodeNumber {
+			return a.EpisodeNumber < b.EpisodeNumber
+		}
+		return airTypeOrder[a.AirType] < airTypeOrder[b.AirType]
+	})
+}
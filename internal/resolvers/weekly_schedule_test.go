@@ -0,0 +1,132 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/weeb-vip/anime-api/graph/model"
+	anime_repo "github.com/weeb-vip/anime-api/internal/db/repositories/anime"
+	anime_episode "github.com/weeb-vip/anime-api/internal/db/repositories/anime_episode"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/episode_air_time"
+	"go.uber.org/mock/gomock"
+)
+
+// fakeAirTimeRepository serves fixed air times; the embedded interface panics on anything else
+type fakeAirTimeRepository struct {
+	episode_air_time.EpisodeAirTimeRepositoryImpl
+	airTimes map[string][]episode_air_time.EpisodeAirTime
+}
+
+func (f *fakeAirTimeRepository) FindByAnimeIDs(animeIDs []string) (map[string][]episode_air_time.EpisodeAirTime, error) {
+	return f.airTimes, nil
+}
+
+// weeklyScheduleFixture airs around the end of US daylight saving time on
+// 2026-11-01: a 25:30 JST broadcast and precise times either side of the switch
+func weeklyScheduleFixture() ([]*anime_repo.Anime, *fakeAirTimeRepository) {
+	lateID := "late-night"
+	aired := time.Date(2026, 10, 30, 0, 0, 0, 0, time.UTC)
+	animes := []*anime_repo.Anime{
+		{
+			ID:        lateID,
+			TitleEn:   stringPtr("Late Night"),
+			Broadcast: stringPtr("Fridays at 25:30 (JST)"),
+			AnimeEpisodes: []*anime_episode.AnimeEpisode{
+				{ID: "ep-4", AnimeID: &lateID, Episode: intPtr(4), Aired: &aired},
+			},
+		},
+		{ID: "precise", TitleEn: stringPtr("Precise")},
+	}
+	repository := &fakeAirTimeRepository{airTimes: map[string][]episode_air_time.EpisodeAirTime{
+		lateID: {
+			{AnimeID: lateID, EpisodeNumber: 4, AirType: "sub", AirDatetime: time.Date(2026, 11, 1, 6, 30, 0, 0, time.UTC)},
+			{AnimeID: lateID, EpisodeNumber: 4, AirType: "dub", AirDatetime: time.Date(2026, 11, 2, 6, 0, 0, 0, time.UTC)},
+		},
+		"precise": {
+			{AnimeID: "precise", EpisodeNumber: 1, AirType: "raw", AirDatetime: time.Date(2026, 11, 1, 5, 30, 0, 0, time.UTC)},
+		},
+	}}
+	return animes, repository
+}
+
+func TestWeeklySchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAnimeService := NewMockAnimeService(ctrl)
+	ctx := context.Background()
+	animes, repository := weeklyScheduleFixture()
+	mockAnimeService.EXPECT().AiringAnimeWithEpisodes(ctx, gomock.Any(), gomock.Any(), nil).Return(animes, nil).AnyTimes()
+
+	t.Run("groups by day in the viewer's timezone across a DST change", func(t *testing.T) {
+		schedule, err := WeeklySchedule(ctx, mockAnimeService, repository, "2026-10-26", "America/New_York", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(schedule.Days) != 7 || schedule.Days[0].Weekday != "Monday" || schedule.Days[6].Date != "2026-11-01" {
+			t.Fatalf("unexpected days: %+v", schedule.Days)
+		}
+
+		friday := schedule.Days[4].Episodes
+		if len(friday) != 1 || friday[0].Anime.ID != "late-night" || friday[0].AirType != model.AirTypeRaw || friday[0].LocalTime != "12:30" {
+			t.Errorf("unexpected Friday: %+v", friday)
+		}
+		if friday[0].Episode == nil || friday[0].Episode.ID != "ep-4" {
+			t.Errorf("expected the listed episode, got %+v", friday[0].Episode)
+		}
+
+		// 01:30 happens twice that night: first in EDT, then in EST; the dub falls
+		// on the following Monday
+		sunday := schedule.Days[6].Episodes
+		if len(sunday) != 2 {
+			t.Fatalf("expected two Sunday releases, got %+v", sunday)
+		}
+		if sunday[0].Anime.ID != "precise" || sunday[0].LocalTime != "01:30" || sunday[1].AirType != model.AirTypeSub || sunday[1].LocalTime != "01:30" {
+			t.Errorf("unexpected Sunday order: %+v, %+v", sunday[0], sunday[1])
+		}
+		if sunday[1].Episode == nil || sunday[1].EpisodeNumber != 4 {
+			t.Errorf("expected episode 4 on the sub release, got %+v", sunday[1])
+		}
+	})
+
+	t.Run("puts a 25:30 JST slot on the next day in Tokyo", func(t *testing.T) {
+		schedule, err := WeeklySchedule(ctx, mockAnimeService, repository, "2026-10-26", "Asia/Tokyo", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		saturday := schedule.Days[5]
+		if saturday.Weekday != "Saturday" || len(saturday.Episodes) != 1 || saturday.Episodes[0].LocalTime != "01:30" {
+			t.Errorf("unexpected Saturday: %+v", saturday)
+		}
+	})
+
+	t.Run("filters by air type", func(t *testing.T) {
+		sub := model.AirTypeSub
+		schedule, err := WeeklySchedule(ctx, mockAnimeService, repository, "2026-10-26", "America/New_York", &sub)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		count := 0
+		for _, day := range schedule.Days {
+			for _, episode := range day.Episodes {
+				count++
+				if episode.AirType != model.AirTypeSub {
+					t.Errorf("unexpected air type %s", episode.AirType)
+				}
+			}
+		}
+		if count != 1 {
+			t.Errorf("expected one sub release, got %d", count)
+		}
+	})
+
+	t.Run("rejects bad arguments", func(t *testing.T) {
+		if _, err := WeeklySchedule(ctx, mockAnimeService, repository, "2026-10-26", "Mars/Olympus", nil); errorCode(err) != "BAD_USER_INPUT" {
+			t.Errorf("unknown timezone: got %v", err)
+		}
+		if _, err := WeeklySchedule(ctx, mockAnimeService, repository, "26/10/2026", "UTC", nil); errorCode(err) != "BAD_USER_INPUT" {
+			t.Errorf("bad weekStart: got %v", err)
+		}
+	})
+}
@@ -0,0 +1,289 @@
+// Package search is an in-process fuzzy title index. Titles are normalized (see
+// Normalize) and split into trigrams; a query matches titles sharing enough of its
+// trigrams and is scored by trigram similarity plus exact, prefix and substring bonuses.
+// PrefixIndex serves typeahead from the same normalized titles.
+package search
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// minMatch is the share of a query's trigrams a title must contain to match
+	minMatch = 0.3
+	// synonymWeight scales scores of matches on synonyms below those on titles
+	synonymWeight = 0.8
+	// shortQueryRunes is the query length below which trigrams are too coarse, so
+	// titles are also scanned for the query as a substring
+	shortQueryRunes = 3
+)
+
+// Document holds the titles of one anime
+type Document struct {
+	ID       string
+	Titles   []string
+	Synonyms []string
+}
+
+// Hit is a matching document and its relevance; higher scores are better
+type Hit struct {
+	ID    string
+	Score float64
+}
+
+type field struct {
+	text   string
+	grams  map[string]struct{}
+	weight float64
+}
+
+type entry struct {
+	id     string
+	fields []field
+}
+
+// Index is safe for concurrent use. The zero value is not usable; call NewIndex.
+type Index struct {
+	mu      sync.RWMutex
+	entries map[string]*entry
+	// postings maps each trigram to the documents containing it
+	postings map[string]map[string]struct{}
+	ready    bool
+}
+
+func NewIndex() *Index {
+	return &Index{
+		entries:  make(map[string]*entry),
+		postings: make(map[string]map[string]struct{}),
+	}
+}
+
+// Replace swaps the whole index for docs and marks it ready
+func (i *Index) Replace(docs []Document) {
+	entries := make(map[string]*entry, len(docs))
+	postings := make(map[string]map[string]struct{})
+	for _, doc := range docs {
+		e := newEntry(doc)
+		entries[doc.ID] = e
+		addPostings(postings, e)
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.entries = entries
+	i.postings = postings
+	i.ready = true
+}
+
+// Upsert adds a document or replaces the stored one with the same ID
+func (i *Index) Upsert(doc Document) {
+	e := newEntry(doc)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.remove(doc.ID)
+	i.entries[doc.ID] = e
+	addPostings(i.postings, e)
+}
+
+// Remove drops a document; unknown IDs are ignored
+func (i *Index) Remove(id string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.remove(id)
+}
+
+func (i *Index) remove(id string) {
+	e, ok := i.entries[id]
+	if !ok {
+		return
+	}
+	for _, f := range e.fields {
+		for gram := range f.grams {
+			if ids := i.postings[gram]; ids != nil {
+				delete(ids, id)
+				if len(ids) == 0 {
+					delete(i.postings, gram)
+				}
+			}
+		}
+	}
+	delete(i.entries, id)
+}
+
+// Ready reports whether the index has been filled by Replace
+func (i *Index) Ready() bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.ready
+}
+
+// Len returns the number of indexed documents
+func (i *Index) Len() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return len(i.entries)
+}
+
+// IDs returns the IDs of all indexed documents
+func (i *Index) IDs() []string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	ids := make([]string, 0, len(i.entries))
+	for id := range i.entries {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Search returns up to limit documents matching query, best first. Ties are
+// ordered by ID so results are stable.
+func (i *Index) Search(query string, limit int) []Hit {
+	normalized := compact(Normalize(query))
+	if normalized == "" || limit <= 0 {
+		return nil
+	}
+	queryGrams := trigrams(normalized)
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	shared := make(map[string]int)
+	for gram := range queryGrams {
+		for id := range i.postings[gram] {
+			shared[id]++
+		}
+	}
+
+	var hits []Hit
+	minShared := minMatch * float64(len(queryGrams))
+	for id, count := range shared {
+		if float64(count) < minShared {
+			continue
+		}
+		if score := scoreEntry(i.entries[id], normalized, queryGrams); score > 0 {
+			hits = append(hits, Hit{ID: id, Score: score})
+		}
+	}
+
+	if len([]rune(normalized)) < shortQueryRunes {
+		for id, e := range i.entries {
+			if _, seen := shared[id]; seen {
+				continue
+			}
+			if score := scoreEntry(e, normalized, queryGrams); score > 0 {
+				hits = append(hits, Hit{ID: id, Score: score})
+			}
+		}
+	}
+
+	sort.Slice(hits, func(a, b int) bool {
+		if hits[a].Score != hits[b].Score {
+			return hits[a].Score > hits[b].Score
+		}
+		return hits[a].ID < hits[b].ID
+	})
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits
+}
+
+// Score rates doc against query the way Search does, or returns 0 when it does not
+// match. It lets results found elsewhere be ranked without an index.
+func Score(query string, doc Document) float64 {
+	normalized := compact(Normalize(query))
+	if normalized == "" {
+		return 0
+	}
+	return scoreEntry(newEntry(doc), normalized, trigrams(normalized))
+}
+
+func scoreEntry(e *entry, query string, queryGrams map[string]struct{}) float64 {
+	best := 0.0
+	for _, f := range e.fields {
+		if score := scoreField(f, query, queryGrams); score > best {
+			best = score
+		}
+	}
+	return best
+}
+
+// scoreField blends how much of the query the title contains with how similar the
+// two are overall, then rewards exact, prefix and substring matches
+func scoreField(f field, query string, queryGrams map[string]struct{}) float64 {
+	common := 0
+	for gram := range queryGrams {
+		if _, ok := f.grams[gram]; ok {
+			common++
+		}
+	}
+
+	contained := float64(common) / float64(len(queryGrams))
+	substring := strings.Contains(f.text, query)
+	if contained < minMatch && !substring {
+		return 0
+	}
+	similarity := float64(common) / float64(len(queryGrams)+len(f.grams)-common)
+
+	score := 0.7*contained + 0.3*similarity
+	switch {
+	case f.text == query:
+		score += 1
+	case strings.HasPrefix(f.text, query):
+		score += 0.5
+	case substring:
+		score += 0.25
+	}
+	return score * f.weight
+}
+
+func newEntry(doc Document) *entry {
+	e := &entry{id: doc.ID}
+	seen := make(map[string]bool)
+	add := func(title string, weight float64) {
+		text := compact(Normalize(title))
+		if text == "" || seen[text] {
+			return
+		}
+		seen[text] = true
+		e.fields = append(e.fields, field{text: text, grams: trigrams(text), weight: weight})
+	}
+	for _, title := range doc.Titles {
+		add(title, 1)
+	}
+	for _, synonym := range doc.Synonyms {
+		add(synonym, synonymWeight)
+	}
+	return e
+}
+
+func addPostings(postings map[string]map[string]struct{}, e *entry) {
+	for _, f := range e.fields {
+		for gram := range f.grams {
+			ids := postings[gram]
+			if ids == nil {
+				ids = make(map[string]struct{})
+				postings[gram] = ids
+			}
+			ids[e.id] = struct{}{}
+		}
+	}
+}
+
+// compact drops the spaces Normalize keeps, since kana titles have none
+func compact(normalized string) string {
+	return strings.ReplaceAll(normalized, " ", "")
+}
+
+// trigrams returns the padded trigrams of text, so its start and end count too
+func trigrams(text string) map[string]struct{} {
+	runes := []rune("  " + text + " ")
+	grams := make(map[string]struct{}, len(runes))
+	for i := 0; i+3 <= len(runes); i++ {
+		grams[string(runes[i:i+3])] = struct{}{}
+	}
+	return grams
+}
@@ -0,0 +1,102 @@
+package search
+
+import "testing"
+
+func testIndex() *Index {
+	index := NewIndex()
+	index.Replace([]Document{
+		{ID: "frieren", Titles: []string{"Frieren: Beyond Journey's End", "Sousou no Frieren", "葬送のフリーレン"}},
+		{ID: "frieren-mini", Titles: []string{"Sousou no Frieren: ●Mini Anime"}},
+		{ID: "aot", Titles: []string{"Attack on Titan", "Shingeki no Kyojin", "進撃の巨人"}, Synonyms: []string{"AoT"}},
+		{ID: "fire-force", Titles: []string{"Fire Force", "Enen no Shouboutai"}},
+	})
+	return index
+}
+
+func hitIDs(hits []Hit) []string {
+	ids := make([]string, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.ID
+	}
+	return ids
+}
+
+func TestIndexSearchRanksByRelevance(t *testing.T) {
+	hits := testIndex().Search("frieren", 10)
+	ids := hitIDs(hits)
+	if len(ids) != 2 || ids[0] != "frieren" || ids[1] != "frieren-mini" {
+		t.Fatalf("Search(frieren) = %v, want frieren then frieren-mini", ids)
+	}
+	if hits[0].Score <= hits[1].Score {
+		t.Errorf("scores %v, want the closer title first", hits)
+	}
+}
+
+func TestIndexSearchToleratesTyposAndScripts(t *testing.T) {
+	index := testIndex()
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{"freiren", "frieren"},
+		{"atack on titan", "aot"},
+		{"しんげきのきょじん", "aot"},
+		{"Shōbōtai", "fire-force"},
+		{"進撃", "aot"},
+		{"aot", "aot"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			hits := index.Search(tt.query, 1)
+			if len(hits) == 0 || hits[0].ID != tt.expected {
+				t.Errorf("Search(%q) = %v, want %s first", tt.query, hits, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIndexUpsertAndRemove(t *testing.T) {
+	index := testIndex()
+
+	index.Upsert(Document{ID: "aot", Titles: []string{"Renamed Show"}})
+	if hits := index.Search("titan", 10); len(hits) != 0 {
+		t.Errorf("old titles still match after upsert: %v", hits)
+	}
+	if hits := index.Search("renamed show", 10); len(hits) != 1 || hits[0].ID != "aot" {
+		t.Errorf("new title does not match after upsert: %v", hits)
+	}
+
+	index.Remove("aot")
+	if hits := index.Search("renamed show", 10); len(hits) != 0 {
+		t.Errorf("removed document still matches: %v", hits)
+	}
+	if index.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", index.Len())
+	}
+}
+
+func TestIndexSearchEdgeCases(t *testing.T) {
+	index := testIndex()
+	if hits := index.Search("  !!  ", 10); hits != nil {
+		t.Errorf("punctuation-only query matched %v", hits)
+	}
+	if hits := index.Search("frieren", 0); hits != nil {
+		t.Errorf("limit 0 returned %v", hits)
+	}
+	if hits := index.Search("zzzzqqqq", 10); len(hits) != 0 {
+		t.Errorf("unrelated query matched %v", hits)
+	}
+	if NewIndex().Ready() || !index.Ready() {
+		t.Error("Ready should be false until Replace and true after")
+	}
+}
+
+func TestScoreMatchesSearch(t *testing.T) {
+	doc := Document{ID: "frieren", Titles: []string{"Sousou no Frieren"}}
+	if Score("frieren", doc) <= 0 {
+		t.Error("Score should match a contained title")
+	}
+	if Score("gundam", doc) != 0 {
+		t.Error("Score should be 0 for an unrelated title")
+	}
+}
@@ -0,0 +1,179 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// kanaRomaji maps hiragana to Hepburn romaji. Katakana is shifted into this range first.
+var kanaRomaji = map[rune]string{
+	'あ': "a", 'い': "i", 'う': "u", 'え': "e", 'お': "o",
+	'か': "ka", 'き': "ki", 'く': "ku", 'け': "ke", 'こ': "ko",
+	'が': "ga", 'ぎ': "gi", 'ぐ': "gu", 'げ': "ge", 'ご': "go",
+	'さ': "sa", 'し': "shi", 'す': "su", 'せ': "se", 'そ': "so",
+	'ざ': "za", 'じ': "ji", 'ず': "zu", 'ぜ': "ze", 'ぞ': "zo",
+	'た': "ta", 'ち': "chi", 'つ': "tsu", 'て': "te", 'と': "to",
+	'だ': "da", 'ぢ': "ji", 'づ': "zu", 'で': "de", 'ど': "do",
+	'な': "na", 'に': "ni", 'ぬ': "nu", 'ね': "ne", 'の': "no",
+	'は': "ha", 'ひ': "hi", 'ふ': "fu", 'へ': "he", 'ほ': "ho",
+	'ば': "ba", 'び': "bi", 'ぶ': "bu", 'べ': "be", 'ぼ': "bo",
+	'ぱ': "pa", 'ぴ': "pi", 'ぷ': "pu", 'ぺ': "pe", 'ぽ': "po",
+	'ま': "ma", 'み': "mi", 'む': "mu", 'め': "me", 'も': "mo",
+	'や': "ya", 'ゆ': "yu", 'よ': "yo",
+	'ら': "ra", 'り': "ri", 'る': "ru", 'れ': "re", 'ろ': "ro",
+	'わ': "wa", 'ゐ': "i", 'ゑ': "e", 'を': "o", 'ん': "n", 'ゔ': "vu",
+}
+
+// smallKana combine with the kana before them: きゃ is kya, ふぁ is fa
+var smallKana = map[rune]string{
+	'ぁ': "a", 'ぃ': "i", 'ぅ': "u", 'ぇ': "e", 'ぉ': "o",
+	'ゃ': "a", 'ゅ': "u", 'ょ': "o", 'ゎ': "a",
+}
+
+const (
+	sokuon     = 'っ' // doubles the next consonant
+	longVowel  = 'ー' // lengthens the previous vowel, which normalization drops anyway
+	kanaOffset = 'ア' - 'あ'
+)
+
+type scriptClass int
+
+const (
+	scriptNone scriptClass = iota
+	scriptHan
+	scriptOther
+)
+
+// Normalize folds a title or query into the form the index compares: width and
+// case folded, diacritics and punctuation removed, kana romanized, long vowels
+// shortened, and a space between kanji and other scripts. Kana carries no word
+// breaks, so "Sōsō no Furīren" gives "soso no furiren" and "そうそうのフリーレン"
+// gives "sosonofuriren"; the index compares both without spaces.
+func Normalize(value string) string {
+	var b strings.Builder
+	var last scriptClass
+	doubleNext := false
+	// romaji of the kana written last, so small kana can rewrite it
+	pending := ""
+
+	flush := func() {
+		if pending == "" {
+			return
+		}
+		if doubleNext && isConsonant(pending[0]) {
+			if strings.HasPrefix(pending, "ch") {
+				b.WriteByte('t')
+			} else {
+				b.WriteByte(pending[0])
+			}
+		}
+		doubleNext = false
+		b.WriteString(pending)
+		pending = ""
+	}
+	boundary := func(class scriptClass) {
+		if last != scriptNone && last != class {
+			b.WriteByte(' ')
+		}
+		last = class
+	}
+
+	for _, r := range norm.NFKC.String(value) {
+		if r >= 'ァ' && r <= 'ヶ' {
+			r -= kanaOffset
+		}
+
+		if romaji, ok := kanaRomaji[r]; ok {
+			flush()
+			boundary(scriptOther)
+			pending = romaji
+			continue
+		}
+		if vowel, ok := smallKana[r]; ok {
+			pending = combineSmallKana(pending, r, vowel)
+			continue
+		}
+		if r == sokuon {
+			flush()
+			boundary(scriptOther)
+			doubleNext = true
+			continue
+		}
+		if r == longVowel {
+			continue
+		}
+
+		flush()
+		doubleNext = false
+		switch {
+		case unicode.Is(unicode.Han, r):
+			boundary(scriptHan)
+			b.WriteRune(r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			boundary(scriptOther)
+			for _, folded := range norm.NFD.String(string(r)) {
+				if !unicode.Is(unicode.Mn, folded) {
+					b.WriteRune(unicode.ToLower(folded))
+				}
+			}
+		default:
+			if last != scriptNone {
+				b.WriteByte(' ')
+			}
+			last = scriptNone
+		}
+	}
+	flush()
+
+	return shortenVowels(strings.Join(strings.Fields(b.String()), " "))
+}
+
+// combineSmallKana merges a small kana into the romaji before it
+func combineSmallKana(previous string, small rune, vowel string) string {
+	if previous == "" {
+		return vowel
+	}
+	stem := previous[:len(previous)-1]
+	switch small {
+	case 'ゃ', 'ゅ', 'ょ':
+		// きゃ kya, but しゃ sha, ちゃ cha, じゃ ja
+		if !strings.HasSuffix(previous, "i") {
+			return previous + "y" + vowel
+		}
+		if stem == "sh" || stem == "ch" || stem == "j" {
+			return stem + vowel
+		}
+		return stem + "y" + vowel
+	default:
+		// ふぁ fa, ティ ti, ヴァ va
+		if stem == "" {
+			return previous + vowel
+		}
+		return stem + vowel
+	}
+}
+
+// shortenVowels writes long vowels as one, so ō, ou, oo and ー spellings compare equal
+func shortenVowels(value string) string {
+	value = strings.ReplaceAll(value, "ou", "o")
+	var b strings.Builder
+	var previous rune
+	for _, r := range value {
+		if r == previous && isVowel(r) {
+			continue
+		}
+		b.WriteRune(r)
+		previous = r
+	}
+	return b.String()
+}
+
+func isVowel(r rune) bool {
+	return r == 'a' || r == 'e' || r == 'i' || r == 'o' || r == 'u'
+}
+
+func isConsonant(c byte) bool {
+	return c >= 'a' && c <= 'z' && !isVowel(rune(c)) && c != 'n'
+}
@@ -0,0 +1,30 @@
+package search
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Sousou no Frieren", "soso no frieren"},
+		{"Sōsō no Furīren", "soso no furiren"},
+		{"そうそうのフリーレン", "sosonofuriren"},
+		{"ＦＵＬＬＷＩＤＴＨ！", "fullwidth"},
+		{"Shingeki no Kyojin: The Final Season", "shingeki no kyojin the final season"},
+		{"キャッチ", "kyatchi"},
+		{"ジョジョ", "jojo"},
+		{"ファイアフォース", "faiafosu"},
+		{"葬送のフリーレン", "葬送 nofuriren"},
+		{"  Pokémon  ", "pokemon"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := Normalize(tt.input); got != tt.expected {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
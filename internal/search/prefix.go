@@ -0,0 +1,193 @@
+package search
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Match kinds, best first: a title starting with the prefix beats one with a later
+// word starting with it, and titles beat synonyms
+const (
+	matchTitle = iota
+	matchTitleWord
+	matchSynonym
+	matchSynonymWord
+)
+
+// SuggestDocument is a Document with the fields a suggestion shows
+type SuggestDocument struct {
+	Document
+	ImageURL *string
+	Year     *int
+}
+
+// Suggestion is an anime whose title starts with a typed prefix
+type Suggestion struct {
+	ID string
+	// Title is the title or synonym that matched the prefix
+	Title    string
+	ImageURL *string
+	Year     *int
+}
+
+type prefixKey struct {
+	// key is the normalized title, without spaces, from one word start to its end
+	key   string
+	id    string
+	title string
+	kind  int
+	// length is the length of the whole normalized title, so shorter titles, which
+	// the prefix covers more of, come first
+	length int
+}
+
+// PrefixIndex finds anime by the start of any word of their titles and synonyms. It
+// is safe for concurrent use; the zero value is not usable, call NewPrefixIndex.
+type PrefixIndex struct {
+	mu   sync.RWMutex
+	docs map[string]SuggestDocument
+	// keys is sorted by key so a prefix is a contiguous run
+	keys  []prefixKey
+	ready bool
+}
+
+func NewPrefixIndex() *PrefixIndex {
+	return &PrefixIndex{docs: make(map[string]SuggestDocument)}
+}
+
+// Replace swaps the whole index for docs and marks it ready
+func (p *PrefixIndex) Replace(docs []SuggestDocument) {
+	byID := make(map[string]SuggestDocument, len(docs))
+	var keys []prefixKey
+	for _, doc := range docs {
+		byID[doc.ID] = doc
+		keys = append(keys, prefixKeys(doc.Document)...)
+	}
+	sort.Slice(keys, func(a, b int) bool { return keys[a].key < keys[b].key })
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.docs = byID
+	p.keys = keys
+	p.ready = true
+}
+
+// Upsert adds a document or replaces the stored one with the same ID
+func (p *PrefixIndex) Upsert(doc SuggestDocument) {
+	keys := prefixKeys(doc.Document)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.remove(doc.ID)
+	p.docs[doc.ID] = doc
+	for _, k := range keys {
+		at := sort.Search(len(p.keys), func(i int) bool { return p.keys[i].key >= k.key })
+		p.keys = slices.Insert(p.keys, at, k)
+	}
+}
+
+// Remove drops a document; unknown IDs are ignored
+func (p *PrefixIndex) Remove(id string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.remove(id)
+}
+
+func (p *PrefixIndex) remove(id string) {
+	if _, ok := p.docs[id]; !ok {
+		return
+	}
+	delete(p.docs, id)
+	p.keys = slices.DeleteFunc(p.keys, func(k prefixKey) bool { return k.id == id })
+}
+
+// Ready reports whether the index has been filled by Replace
+func (p *PrefixIndex) Ready() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.ready
+}
+
+// Suggest returns up to limit anime with a title word starting with prefix, each
+// with its best matching title. Whole titles come before later words, titles before
+// synonyms and shorter titles before longer ones.
+func (p *PrefixIndex) Suggest(prefix string, limit int) []Suggestion {
+	normalized := compact(Normalize(prefix))
+	if normalized == "" || limit <= 0 {
+		return nil
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	best := make(map[string]prefixKey)
+	start := sort.Search(len(p.keys), func(i int) bool { return p.keys[i].key >= normalized })
+	for _, k := range p.keys[start:] {
+		if !strings.HasPrefix(k.key, normalized) {
+			break
+		}
+		if current, ok := best[k.id]; !ok || betterMatch(k, current) {
+			best[k.id] = k
+		}
+	}
+
+	matches := make([]prefixKey, 0, len(best))
+	for _, k := range best {
+		matches = append(matches, k)
+	}
+	sort.Slice(matches, func(a, b int) bool { return betterMatch(matches[a], matches[b]) })
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	suggestions := make([]Suggestion, 0, len(matches))
+	for _, k := range matches {
+		doc := p.docs[k.id]
+		suggestions = append(suggestions, Suggestion{ID: k.id, Title: k.title, ImageURL: doc.ImageURL, Year: doc.Year})
+	}
+	return suggestions
+}
+
+func betterMatch(a prefixKey, b prefixKey) bool {
+	if a.kind != b.kind {
+		return a.kind < b.kind
+	}
+	if a.length != b.length {
+		return a.length < b.length
+	}
+	if a.title != b.title {
+		return a.title < b.title
+	}
+	return a.id < b.id
+}
+
+// prefixKeys returns a key per word start of each title and synonym of doc
+func prefixKeys(doc Document) []prefixKey {
+	var keys []prefixKey
+	seen := make(map[string]bool)
+	add := func(title string, kind int) {
+		words := strings.Fields(Normalize(title))
+		length := len([]rune(strings.Join(words, "")))
+		for i := range words {
+			key := strings.Join(words[i:], "")
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			wordKind := kind
+			if i > 0 {
+				wordKind++
+			}
+			keys = append(keys, prefixKey{key: key, id: doc.ID, title: title, kind: wordKind, length: length})
+		}
+	}
+	for _, title := range doc.Titles {
+		add(title, matchTitle)
+	}
+	for _, synonym := range doc.Synonyms {
+		add(synonym, matchSynonym)
+	}
+	return keys
+}
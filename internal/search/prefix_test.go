@@ -0,0 +1,81 @@
+package search
+
+import "testing"
+
+func testPrefixIndex() *PrefixIndex {
+	year := 2023
+	image := "frieren.jpg"
+	index := NewPrefixIndex()
+	index.Replace([]SuggestDocument{
+		{Document: Document{ID: "frieren", Titles: []string{"Frieren: Beyond Journey's End", "Sousou no Frieren", "葬送のフリーレン"}}, ImageURL: &image, Year: &year},
+		{Document: Document{ID: "frieren-mini", Titles: []string{"Sousou no Frieren: ●Mini Anime"}}},
+		{Document: Document{ID: "aot", Titles: []string{"Attack on Titan", "Shingeki no Kyojin"}, Synonyms: []string{"AoT"}}},
+		{Document: Document{ID: "fire-force", Titles: []string{"Fire Force", "Enen no Shouboutai"}}},
+	})
+	return index
+}
+
+func suggestionIDs(suggestions []Suggestion) []string {
+	ids := make([]string, len(suggestions))
+	for i, suggestion := range suggestions {
+		ids[i] = suggestion.ID
+	}
+	return ids
+}
+
+func TestPrefixIndexSuggestOrdersMatches(t *testing.T) {
+	suggestions := testPrefixIndex().Suggest("Fri", 10)
+	ids := suggestionIDs(suggestions)
+	if len(ids) != 2 || ids[0] != "frieren" || ids[1] != "frieren-mini" {
+		t.Fatalf("Suggest(Fri) = %v, want frieren then frieren-mini", ids)
+	}
+	if suggestions[0].Title != "Frieren: Beyond Journey's End" || suggestions[0].Year == nil || *suggestions[0].Year != 2023 {
+		t.Errorf("unexpected first suggestion: %+v", suggestions[0])
+	}
+	if suggestions[1].Title != "Sousou no Frieren: ●Mini Anime" {
+		t.Errorf("expected the matching later word's title, got %q", suggestions[1].Title)
+	}
+}
+
+func TestPrefixIndexSuggestMatchesVariants(t *testing.T) {
+	index := testPrefixIndex()
+	tests := []struct {
+		prefix   string
+		expected string
+		title    string
+	}{
+		{"shingeki no", "aot", "Shingeki no Kyojin"},
+		{"titan", "aot", "Attack on Titan"},
+		{"ao", "aot", "AoT"},
+		{"そうそう", "frieren", "Sousou no Frieren"},
+		{"shōbō", "fire-force", "Enen no Shouboutai"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			suggestions := index.Suggest(tt.prefix, 1)
+			if len(suggestions) == 0 || suggestions[0].ID != tt.expected || suggestions[0].Title != tt.title {
+				t.Errorf("Suggest(%q) = %+v, want %s via %q", tt.prefix, suggestions, tt.expected, tt.title)
+			}
+		})
+	}
+}
+
+func TestPrefixIndexUpsertAndRemove(t *testing.T) {
+	index := testPrefixIndex()
+
+	index.Upsert(SuggestDocument{Document: Document{ID: "aot", Titles: []string{"Renamed Show"}}})
+	if suggestions := index.Suggest("attack", 10); len(suggestions) != 0 {
+		t.Errorf("old titles still match after upsert: %+v", suggestions)
+	}
+	if suggestions := index.Suggest("ren", 10); len(suggestions) != 1 || suggestions[0].ID != "aot" {
+		t.Errorf("new title does not match after upsert: %+v", suggestions)
+	}
+
+	index.Remove("aot")
+	if suggestions := index.Suggest("ren", 10); len(suggestions) != 0 {
+		t.Errorf("removed document still matches: %+v", suggestions)
+	}
+	if suggestions := index.Suggest("", 10); suggestions != nil {
+		t.Errorf("blank prefix matched: %+v", suggestions)
+	}
+}
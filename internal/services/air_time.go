@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/weeb-vip/anime-api/graph/model"
+	broadcastpkg "github.com/weeb-vip/anime-api/internal/broadcast"
 )
 
 // Episode represents an anime episode for air time calculations
@@ -18,6 +19,7 @@ type Episode struct {
 	TitleJp       *string
 	AirDate       *time.Time
 	Synopsis      *string
+	Delay         *model.EpisodeDelay
 }
 
 // NextEpisodeResult represents the result of finding the next episode
@@ -33,92 +35,26 @@ type AirTimeDisplayInfo struct {
 	Variant string // countdown, scheduled, aired, airing
 }
 
-// ParseAirTime parses broadcast time and creates accurate air time in UTC
-// Based on the frontend airTimeUtils.js parseAirTime function
-// Returns time in UTC timezone for consistent API responses
+// ParseAirTime returns when an episode aired on airDate airs in the anime's
+// broadcast slot, in UTC. The air date is returned unchanged when the broadcast
+// names no slot or cannot be read.
 func ParseAirTime(airDate *time.Time, broadcast *string) *time.Time {
 	if airDate == nil || broadcast == nil {
 		return airDate
 	}
 
-	// Parse broadcast time (e.g., "Wednesdays at 01:29 (JST)")
-	broadcastStr := *broadcast
-	if !strings.Contains(broadcastStr, ":") {
+	slot, err := broadcastpkg.Parse(*broadcast)
+	if err != nil {
 		return airDate
 	}
+	result := slot.On(*airDate).UTC()
+	return &result
+}
 
-	// Extract time and timezone from broadcast string
-	var hours, minutes int
-	var timezone string
-
-	// Look for time pattern HH:MM using regex-like approach
-	// Find "at HH:MM" pattern
-	atIndex := strings.Index(broadcastStr, " at ")
-	if atIndex != -1 {
-		timeStr := broadcastStr[atIndex+4:] // Skip " at "
-		// Find the time pattern before any parentheses
-		parenIndex := strings.Index(timeStr, " (")
-		if parenIndex != -1 {
-			timeStr = timeStr[:parenIndex]
-		}
-
-		n, err := fmt.Sscanf(timeStr, "%d:%d", &hours, &minutes)
-		if err != nil || n != 2 {
-			// If we can't parse the time, just return the original air date
-			return airDate
-		}
-	} else {
-		// Try to find just HH:MM pattern
-		n, err := fmt.Sscanf(broadcastStr, "%d:%d", &hours, &minutes)
-		if err != nil || n != 2 {
-			// If we can't parse the time, just return the original air date
-			return airDate
-		}
-	}
-
-	// Extract timezone (JST, UTC, etc.)
-	if strings.Contains(broadcastStr, "(JST)") {
-		timezone = "JST"
-	} else if strings.Contains(broadcastStr, "(UTC)") {
-		timezone = "UTC"
-	} else {
-		timezone = "JST" // Default to JST for Japanese anime
-	}
-
-	// Create a date object from the air date
-	parsedAirDate := *airDate
-
-	if timezone == "JST" {
-		// JST is UTC+9, so to get UTC time we subtract 9 hours
-		utcHours := hours - 9
-		utcDay := parsedAirDate.Day()
-		utcMonth := parsedAirDate.Month()
-		utcYear := parsedAirDate.Year()
-
-		if utcHours < 0 {
-			// Previous day case
-			utcHours += 24
-			// Go back one day
-			tempDate := time.Date(utcYear, utcMonth, utcDay, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
-			utcYear = tempDate.Year()
-			utcMonth = tempDate.Month()
-			utcDay = tempDate.Day()
-		}
-
-		result := time.Date(utcYear, utcMonth, utcDay, utcHours, minutes, 0, 0, time.UTC)
-		return &result
-	} else {
-		// For other timezones, assume UTC
-		result := time.Date(
-			parsedAirDate.Year(),
-			parsedAirDate.Month(),
-			parsedAirDate.Day(),
-			hours,
-			minutes,
-			0, 0, time.UTC,
-		)
-		return &result
-	}
+// EpisodeDuration returns how long one episode runs given an anime's duration,
+// such as "24 min per episode"
+func EpisodeDuration(duration *string) time.Duration {
+	return time.Duration(parseDurationToMinutes(duration)) * time.Minute
 }
 
 // parseDurationToMinutes parses duration string to minutes
@@ -138,16 +74,7 @@ func parseDurationToMinutes(duration *string) int {
 }
 
 // isCurrentlyAiring checks if the anime is currently airing
-func isCurrentlyAiring(airDate *time.Time, broadcast *string, durationMinutes int, currentTime time.Time) bool {
-	if airDate == nil {
-		return false
-	}
-
-	airTime := ParseAirTime(airDate, broadcast)
-	if airTime == nil {
-		return false
-	}
-
+func isCurrentlyAiring(airTime time.Time, durationMinutes int, currentTime time.Time) bool {
 	airStartMs := airTime.UnixMilli()
 	currentMs := currentTime.UnixMilli()
 	episodeDurationMs := int64(durationMinutes * 60 * 1000)
@@ -158,16 +85,7 @@ func isCurrentlyAiring(airDate *time.Time, broadcast *string, durationMinutes in
 }
 
 // hasAlreadyAired checks if the anime has already aired
-func hasAlreadyAired(airDate *time.Time, broadcast *string, durationMinutes int, currentTime time.Time) bool {
-	if airDate == nil {
-		return false
-	}
-
-	airTime := ParseAirTime(airDate, broadcast)
-	if airTime == nil {
-		return false
-	}
-
+func hasAlreadyAired(airTime time.Time, durationMinutes int, currentTime time.Time) bool {
 	currentMs := currentTime.UnixMilli()
 	airStartMs := airTime.UnixMilli()
 	episodeDurationMs := int64(durationMinutes * 60 * 1000)
@@ -179,18 +97,9 @@ func hasAlreadyAired(airDate *time.Time, broadcast *string, durationMinutes int,
 }
 
 // calculateCountdown calculates countdown string or current airing status
-func calculateCountdown(airDate *time.Time, broadcast *string, durationMinutes int, currentTime time.Time) string {
-	if airDate == nil {
-		return ""
-	}
-
-	airTime := ParseAirTime(airDate, broadcast)
-	if airTime == nil {
-		return ""
-	}
-
+func calculateCountdown(airTime time.Time, durationMinutes int, currentTime time.Time) string {
 	// Check if currently airing
-	if isCurrentlyAiring(airDate, broadcast, durationMinutes, currentTime) {
+	if isCurrentlyAiring(airTime, durationMinutes, currentTime) {
 		airStartMs := airTime.UnixMilli()
 		currentMs := currentTime.UnixMilli()
 		episodeDurationMs := int64(durationMinutes * 60 * 1000)
@@ -228,6 +137,69 @@ func calculateCountdown(airDate *time.Time, broadcast *string, durationMinutes i
 	return ""
 }
 
+// episodeBreak is a delayed episode that pauses the broadcast for weeks
+type episodeBreak struct {
+	episodeNumber int
+	weeks         int
+	resumesAt     time.Time
+}
+
+// delaySchedule applies the delays set on an anime's episodes to their listed air
+// times. Listed air dates are the original schedule: an episode after a break airs
+// that many weeks later, and nothing airs after an indefinitely delayed episode.
+type delaySchedule struct {
+	haltedFrom *int
+	breaks     []episodeBreak
+}
+
+func newDelaySchedule(episodes []*model.Episode) delaySchedule {
+	var schedule delaySchedule
+	for _, episode := range episodes {
+		if episode.Delay == nil || episode.EpisodeNumber == nil {
+			continue
+		}
+		number := *episode.EpisodeNumber
+		if episode.Delay.RescheduledAirTime == nil {
+			if schedule.haltedFrom == nil || number < *schedule.haltedFrom {
+				schedule.haltedFrom = &number
+			}
+			continue
+		}
+		if episode.Delay.BreakWeeks > 0 {
+			schedule.breaks = append(schedule.breaks, episodeBreak{
+				episodeNumber: number,
+				weeks:         episode.Delay.BreakWeeks,
+				resumesAt:     *episode.Delay.RescheduledAirTime,
+			})
+		}
+	}
+	return schedule
+}
+
+// airTime returns when an episode airs once delays are applied, or nil when it has
+// no air time or is held back by an indefinite delay
+func (d delaySchedule) airTime(episode *model.Episode, broadcast *string) *time.Time {
+	if episode.Delay != nil {
+		return episode.Delay.RescheduledAirTime
+	}
+	if episode.EpisodeNumber != nil && d.haltedFrom != nil && *episode.EpisodeNumber > *d.haltedFrom {
+		return nil
+	}
+
+	airTime := ParseAirTime(episode.AirDate, broadcast)
+	if airTime == nil || episode.EpisodeNumber == nil {
+		return airTime
+	}
+	shifted := *airTime
+	for _, pause := range d.breaks {
+		// a listed time already past the resumed broadcast has been moved by the sync
+		if pause.episodeNumber < *episode.EpisodeNumber && !airTime.After(pause.resumesAt) {
+			shifted = shifted.AddDate(0, 0, 7*pause.weeks)
+		}
+	}
+	return &shifted
+}
+
 // findNextEpisode finds the next episode from an episodes array within the query date range
 func findNextEpisode(episodes []*model.Episode, broadcast *string, currentTime time.Time, queryStartDate *time.Time, queryEndDate *time.Time) *NextEpisodeResult {
 	if len(episodes) == 0 {
@@ -235,10 +207,11 @@ func findNextEpisode(episodes []*model.Episode, broadcast *string, currentTime t
 	}
 
 	var closestEpisode *NextEpisodeResult
+	delays := newDelaySchedule(episodes)
 
 	for _, episode := range episodes {
-		if episode.AirDate != nil {
-			airTime := ParseAirTime(episode.AirDate, broadcast)
+		if episode.AirDate != nil || episode.Delay != nil {
+			airTime := delays.airTime(episode, broadcast)
 			if airTime != nil {
 				// Check if episode is within the query date range
 				// Note: airTime is in UTC, and queryStartDate/queryEndDate should also be in UTC
@@ -268,6 +241,7 @@ func findNextEpisode(episodes []*model.Episode, broadcast *string, currentTime t
 							TitleJp:       episode.TitleJp,
 							AirDate:       episode.AirDate,
 							Synopsis:      episode.Synopsis,
+							Delay:         episode.Delay,
 						},
 						AirTime: *airTime,
 					}
@@ -307,15 +281,20 @@ func absTimeDiff(t1, t2 time.Time) time.Duration {
 
 // GetAirTimeDisplay gets air time display configuration for AnimeCard component
 func GetAirTimeDisplay(airDate *time.Time, broadcast *string, duration *string, currentTime time.Time) *AirTimeDisplayInfo {
-	if airDate == nil {
+	airTime := ParseAirTime(airDate, broadcast)
+	if airTime == nil {
 		return nil
 	}
+	return airTimeDisplay(*airTime, duration, currentTime)
+}
 
+// airTimeDisplay gets the display configuration for an episode airing at airTime
+func airTimeDisplay(airTime time.Time, duration *string, currentTime time.Time) *AirTimeDisplayInfo {
 	durationMinutes := parseDurationToMinutes(duration)
 
 	// Check if currently airing first
-	if isCurrentlyAiring(airDate, broadcast, durationMinutes, currentTime) {
-		countdown := calculateCountdown(airDate, broadcast, durationMinutes, currentTime)
+	if isCurrentlyAiring(airTime, durationMinutes, currentTime) {
+		countdown := calculateCountdown(airTime, durationMinutes, currentTime)
 		text := "Airing"
 		if countdown != "" && countdown != "AIRING NOW" {
 			text = fmt.Sprintf("Airing (%s)", countdown)
@@ -327,18 +306,13 @@ func GetAirTimeDisplay(airDate *time.Time, broadcast *string, duration *string,
 		}
 	}
 
-	airTime := ParseAirTime(airDate, broadcast)
-	if airTime == nil {
-		return nil
-	}
-
 	// Check if airing today
 	timeDiff := airTime.UnixMilli() - currentTime.UnixMilli()
 	dayMs := int64(24 * 60 * 60 * 1000)
 	isAiringToday := timeDiff > 0 && timeDiff <= dayMs
 
 	if isAiringToday {
-		countdown := calculateCountdown(airDate, broadcast, durationMinutes, currentTime)
+		countdown := calculateCountdown(airTime, durationMinutes, currentTime)
 		if countdown != "" {
 			isJustAired := countdown == "JUST AIRED"
 			text := "Just aired"
@@ -362,7 +336,7 @@ func GetAirTimeDisplay(airDate *time.Time, broadcast *string, duration *string,
 		}
 	}
 
-	if hasAlreadyAired(airDate, broadcast, durationMinutes, currentTime) {
+	if hasAlreadyAired(airTime, durationMinutes, currentTime) {
 		return &AirTimeDisplayInfo{
 			Show:    true,
 			Text:    "Recently aired",
@@ -415,8 +389,8 @@ func ProcessCurrentlyAiring(animes []*model.Anime, limit int, currentTime time.T
 			continue
 		}
 
-		// Generate air time display info
-		airTimeInfo := GetAirTimeDisplay(nextEpisodeResult.Episode.AirDate, anime.Broadcast, anime.Duration, currentTime)
+		// Generate air time display info from the air time with any delay applied
+		airTimeInfo := airTimeDisplay(nextEpisodeResult.AirTime, anime.Duration, currentTime)
 		if airTimeInfo == nil {
 			continue
 		}
@@ -451,13 +425,8 @@ func ProcessCurrentlyAiring(animes []*model.Anime, limit int, currentTime time.T
 	// Convert back to []*model.Anime
 	var finalResult []*model.Anime
 	for _, processedItem := range result {
-		// Calculate the air time with timezone conversion
-		var airTime *time.Time
-		if processedItem.NextEpisode.AirDate != nil {
-			// Use the broadcast info from the anime to calculate the proper air time
-			calculatedAirTime := ParseAirTime(processedItem.NextEpisode.AirDate, processedItem.Anime.Broadcast)
-			airTime = calculatedAirTime
-		}
+		// The air time with timezone conversion and any delay applied
+		airTime := processedItem.NextEpisodeDate
 
 		// Update the anime with the next episode information
 		processedItem.Anime.NextEpisode = &model.Episode{
@@ -467,8 +436,9 @@ func ProcessCurrentlyAiring(animes []*model.Anime, limit int, currentTime time.T
 			TitleEn:       processedItem.NextEpisode.TitleEn,
 			TitleJp:       processedItem.NextEpisode.TitleJp,
 			AirDate:       processedItem.NextEpisode.AirDate,
-			AirTime:       airTime,
+			AirTime:       &airTime,
 			Synopsis:      processedItem.NextEpisode.Synopsis,
+			Delay:         processedItem.NextEpisode.Delay,
 			CreatedAt:     time.Now().Format("2006-01-02 15:04:05"),
 			UpdatedAt:     time.Now().Format("2006-01-02 15:04:05"),
 		}
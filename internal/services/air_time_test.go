@@ -16,6 +16,8 @@ func TestProcessCurrentlyAiring(t *testing.T) {
 	future2 := time.Date(2023, 12, 15, 12, 0, 0, 0, time.UTC) // 2 hours from now
 	recent := time.Date(2023, 12, 15, 9, 45, 0, 0, time.UTC)  // 15 minutes ago (within 30min window)
 	old := time.Date(2023, 12, 15, 8, 0, 0, 0, time.UTC)      // 2 hours ago (outside window)
+	// the currently airing window starts 30 minutes back
+	windowStart := now.Add(-30 * time.Minute)
 
 	// Create test data with UTC broadcasts for simplicity
 	animes := []*model.Anime{
@@ -78,7 +80,7 @@ func TestProcessCurrentlyAiring(t *testing.T) {
 	}
 
 	// Test with limit of 10
-	result := ProcessCurrentlyAiring(animes, 10, now)
+	result := ProcessCurrentlyAiring(animes, 10, now, &windowStart, nil)
 
 	// Should return episodes from 30 minutes ago onwards in chronological order
 	// Should include Anime 3 (15 min ago), Anime 1 (1h future), Anime 2 (2h future)
@@ -112,7 +114,7 @@ func TestProcessCurrentlyAiring(t *testing.T) {
 	}
 
 	// Test with limit of 2 - should get first 2 in chronological order
-	limitedResult := ProcessCurrentlyAiring(animes, 2, now)
+	limitedResult := ProcessCurrentlyAiring(animes, 2, now, &windowStart, nil)
 	if len(limitedResult) != 2 {
 		t.Errorf("Expected 2 results with limit, got %d", len(limitedResult))
 	}
@@ -136,6 +138,7 @@ func TestProcessCurrentlyAiring30MinuteWindow(t *testing.T) {
 	recent := time.Date(2023, 12, 15, 9, 55, 0, 0, time.UTC)    // 5 minutes ago (included)
 	future1 := time.Date(2023, 12, 15, 10, 30, 0, 0, time.UTC)  // 30 minutes future (included)
 	future2 := time.Date(2023, 12, 15, 11, 0, 0, 0, time.UTC)   // 1 hour future (included)
+	windowStart := now.Add(-30 * time.Minute)
 
 	animes := []*model.Anime{
 		{
@@ -160,7 +163,7 @@ func TestProcessCurrentlyAiring30MinuteWindow(t *testing.T) {
 		},
 	}
 
-	result := ProcessCurrentlyAiring(animes, 10, now)
+	result := ProcessCurrentlyAiring(animes, 10, now, &windowStart, nil)
 
 	// Should exclude "too-old" (40 min ago) but include the other 4
 	expectedIDs := []string{"just-in-window", "recent", "future1", "future2"}
@@ -207,7 +210,7 @@ func TestCalculateCountdown(t *testing.T) {
 	future := time.Date(2023, 12, 15, 11, 0, 0, 0, time.UTC)
 	broadcast := "Fridays at 11:00 (UTC)"
 
-	countdown := calculateCountdown(&future, &broadcast, 24, now)
+	countdown := calculateCountdown(*ParseAirTime(&future, &broadcast), 24, now)
 	if countdown != "1h" {
 		t.Errorf("Expected '1h', got '%s'", countdown)
 	}
@@ -215,12 +218,104 @@ func TestCalculateCountdown(t *testing.T) {
 	// Test just aired (30 minutes ago, past the 24 min episode duration)
 	recent := time.Date(2023, 12, 15, 9, 30, 0, 0, time.UTC)
 	recentBroadcast := "Fridays at 09:30 (UTC)"
-	countdown = calculateCountdown(&recent, &recentBroadcast, 24, now)
+	countdown = calculateCountdown(*ParseAirTime(&recent, &recentBroadcast), 24, now)
 	if countdown != "JUST AIRED" {
 		t.Errorf("Expected 'JUST AIRED', got '%s'", countdown)
 	}
 }
 
+func TestProcessCurrentlyAiringDelays(t *testing.T) {
+	now := time.Date(2023, 12, 15, 10, 0, 0, 0, time.UTC)
+	windowStart := now.Add(-30 * time.Minute)
+	lastWeek := time.Date(2023, 12, 8, 0, 0, 0, 0, time.UTC)
+	today := time.Date(2023, 12, 15, 0, 0, 0, 0, time.UTC)
+	nextWeek := time.Date(2023, 12, 22, 0, 0, 0, 0, time.UTC)
+	originalAirTime := time.Date(2023, 12, 15, 11, 0, 0, 0, time.UTC)
+	rescheduled := time.Date(2023, 12, 29, 11, 0, 0, 0, time.UTC)
+
+	animes := []*model.Anime{
+		{
+			ID:        "rescheduled",
+			Duration:  stringPtr("24 min"),
+			Broadcast: stringPtr("Fridays at 11:00 (UTC)"),
+			Episodes: []*model.Episode{
+				{ID: "r4", AnimeID: stringPtr("rescheduled"), EpisodeNumber: intPtr(4), AirDate: &lastWeek},
+				{ID: "r5", AnimeID: stringPtr("rescheduled"), EpisodeNumber: intPtr(5), AirDate: &today, Delay: &model.EpisodeDelay{
+					AirType:            model.AirTypeRaw,
+					OriginalAirTime:    &originalAirTime,
+					RescheduledAirTime: &rescheduled,
+					BreakWeeks:         2,
+				}},
+				{ID: "r6", AnimeID: stringPtr("rescheduled"), EpisodeNumber: intPtr(6), AirDate: &nextWeek},
+			},
+		},
+		{
+			ID:        "indefinite",
+			Duration:  stringPtr("24 min"),
+			Broadcast: stringPtr("Fridays at 12:00 (UTC)"),
+			Episodes: []*model.Episode{
+				{ID: "i5", AnimeID: stringPtr("indefinite"), EpisodeNumber: intPtr(5), AirDate: &today, Delay: &model.EpisodeDelay{
+					AirType: model.AirTypeRaw,
+				}},
+				{ID: "i6", AnimeID: stringPtr("indefinite"), EpisodeNumber: intPtr(6), AirDate: &nextWeek},
+			},
+		},
+		{
+			ID:        "on-time",
+			Duration:  stringPtr("24 min"),
+			Broadcast: stringPtr("Fridays at 13:00 (UTC)"),
+			Episodes: []*model.Episode{
+				{ID: "o1", AnimeID: stringPtr("on-time"), EpisodeNumber: intPtr(1), AirDate: &today},
+			},
+		},
+	}
+
+	result := ProcessCurrentlyAiring(animes, 10, now, &windowStart, nil)
+
+	// the indefinitely delayed anime has no next episode; the rescheduled one comes
+	// after the anime airing on time
+	if len(result) != 2 || result[0].ID != "on-time" || result[1].ID != "rescheduled" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	next := result[1].NextEpisode
+	if next.ID != "r5" || next.AirTime == nil || !next.AirTime.Equal(rescheduled) {
+		t.Errorf("expected episode 5 at its rescheduled time, got %s at %v", next.ID, next.AirTime)
+	}
+	if next.Delay == nil || next.Delay.BreakWeeks != 2 {
+		t.Errorf("expected the delay to be kept on the next episode, got %+v", next.Delay)
+	}
+}
+
+func TestDelayScheduleAirTime(t *testing.T) {
+	broadcast := stringPtr("Fridays at 11:00 (UTC)")
+	today := time.Date(2023, 12, 15, 0, 0, 0, 0, time.UTC)
+	nextWeek := time.Date(2023, 12, 22, 0, 0, 0, 0, time.UTC)
+	rescheduled := time.Date(2023, 12, 29, 11, 0, 0, 0, time.UTC)
+
+	delayed := &model.Episode{EpisodeNumber: intPtr(5), AirDate: &today, Delay: &model.EpisodeDelay{RescheduledAirTime: &rescheduled, BreakWeeks: 2}}
+	following := &model.Episode{EpisodeNumber: intPtr(6), AirDate: &nextWeek}
+	schedule := newDelaySchedule([]*model.Episode{delayed, following})
+
+	// the episode after a two week break airs two weeks after its listed date
+	if got, want := schedule.airTime(following, broadcast), time.Date(2024, 1, 5, 11, 0, 0, 0, time.UTC); got == nil || !got.Equal(want) {
+		t.Errorf("following episode airs at %v, want %v", got, want)
+	}
+
+	// a listed date already moved past the break is left alone
+	moved := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+	following.AirDate = &moved
+	if got, want := schedule.airTime(following, broadcast), time.Date(2024, 1, 5, 11, 0, 0, 0, time.UTC); got == nil || !got.Equal(want) {
+		t.Errorf("moved episode airs at %v, want %v", got, want)
+	}
+
+	delayed.Delay.RescheduledAirTime = nil
+	schedule = newDelaySchedule([]*model.Episode{delayed, following})
+	if got := schedule.airTime(following, broadcast); got != nil {
+		t.Errorf("expected no air time after an indefinite delay, got %v", got)
+	}
+}
+
 // Helper functions
 func stringPtr(s string) *string {
 	return &s
@@ -0,0 +1,85 @@
+package airing
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/weeb-vip/anime-api/internal/pubsub"
+)
+
+const (
+	episodeAiringChannel   = "episode-airing"
+	scheduleChangedChannel = "schedule-changed"
+)
+
+// EpisodeAiring is sent when the raw, sub or dub release time of an episode passes
+type EpisodeAiring struct {
+	AnimeID       string `json:"animeId"`
+	EpisodeNumber int    `json:"episodeNumber"`
+	// AirType is raw, sub or dub, as stored in episode_air_time
+	AirType string    `json:"airType"`
+	AirTime time.Time `json:"airTime"`
+}
+
+// ScheduleChanged is sent when the episodes or air times of an anime were written
+type ScheduleChanged struct {
+	AnimeID   string    `json:"animeId"`
+	ChangedAt time.Time `json:"changedAt"`
+}
+
+// Events publishes and subscribes to airing events over a pubsub.Bus
+type Events struct {
+	Bus pubsub.Bus
+}
+
+func NewEvents(bus pubsub.Bus) *Events {
+	return &Events{Bus: bus}
+}
+
+func (e *Events) PublishEpisodeAiring(ctx context.Context, event EpisodeAiring) error {
+	return publish(ctx, e.Bus, episodeAiringChannel, event)
+}
+
+func (e *Events) PublishScheduleChanged(ctx context.Context, event ScheduleChanged) error {
+	return publish(ctx, e.Bus, scheduleChangedChannel, event)
+}
+
+// SubscribeEpisodeAiring returns episode airing events until ctx is done
+func (e *Events) SubscribeEpisodeAiring(ctx context.Context) <-chan EpisodeAiring {
+	return subscribe[EpisodeAiring](ctx, e.Bus, episodeAiringChannel)
+}
+
+// SubscribeScheduleChanged returns schedule change events until ctx is done
+func (e *Events) SubscribeScheduleChanged(ctx context.Context) <-chan ScheduleChanged {
+	return subscribe[ScheduleChanged](ctx, e.Bus, scheduleChangedChannel)
+}
+
+func publish(ctx context.Context, bus pubsub.Bus, channel string, event interface{}) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return bus.Publish(ctx, channel, payload)
+}
+
+// subscribe decodes the messages of channel, skipping any that are malformed
+func subscribe[T any](ctx context.Context, bus pubsub.Bus, channel string) <-chan T {
+	messages := bus.Subscribe(ctx, channel)
+	events := make(chan T)
+	go func() {
+		defer close(events)
+		for payload := range messages {
+			var event T
+			if err := json.Unmarshal(payload, &event); err != nil {
+				continue
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events
+}
@@ -0,0 +1,42 @@
+package airing
+
+import (
+	"strings"
+
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/episode_air_time"
+	"github.com/weeb-vip/anime-api/internal/services"
+)
+
+// Releases returns when the loaded episodes of an anime air: the precise raw, sub
+// and dub times of airTimes, then for each aired episode without a precise raw time,
+// its air date at the anime's broadcast slot
+func Releases(animeEntity *anime.Anime, airTimes []episode_air_time.EpisodeAirTime) []EpisodeAiring {
+	var releases []EpisodeAiring
+	hasRawTime := make(map[int]bool)
+	for _, airTime := range airTimes {
+		airType := strings.ToLower(airTime.AirType)
+		if airType == "raw" {
+			hasRawTime[airTime.EpisodeNumber] = true
+		}
+		releases = append(releases, EpisodeAiring{
+			AnimeID:       animeEntity.ID,
+			EpisodeNumber: airTime.EpisodeNumber,
+			AirType:       airType,
+			AirTime:       airTime.AirDatetime,
+		})
+	}
+
+	for _, episode := range animeEntity.AnimeEpisodes {
+		if episode == nil || episode.Episode == nil || episode.Aired == nil || hasRawTime[*episode.Episode] {
+			continue
+		}
+		releases = append(releases, EpisodeAiring{
+			AnimeID:       animeEntity.ID,
+			EpisodeNumber: *episode.Episode,
+			AirType:       "raw",
+			AirTime:       *services.ParseAirTime(episode.Aired, animeEntity.Broadcast),
+		})
+	}
+	return releases
+}
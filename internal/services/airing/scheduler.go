@@ -0,0 +1,225 @@
+package airing
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/weeb-vip/anime-api/internal/cache"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime"
+	anime_episode "github.com/weeb-vip/anime-api/internal/db/repositories/anime_episode"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/episode_air_time"
+	"github.com/weeb-vip/anime-api/internal/logger"
+)
+
+// claimTTL outlasts any poll interval, so a replica polling late still finds the
+// claim of the replica that sent an event
+const claimTTL = time.Hour
+
+// Scheduler polls for episodes whose release time passed and for schedules written
+// since the last poll, and publishes them as events
+type Scheduler struct {
+	EpisodeRepository        anime_episode.AnimeEpisodeRepositoryImpl
+	EpisodeAirTimeRepository episode_air_time.EpisodeAirTimeRepositoryImpl
+	Events                   *Events
+	// Claims makes one replica send each event when replicas share a Redis bus;
+	// nil sends every event this replica finds
+	Claims     cache.Cache
+	keyBuilder *cache.CacheKeyBuilder
+	// last is the end of the previous poll window
+	last time.Time
+}
+
+func NewScheduler(episodeRepository anime_episode.AnimeEpisodeRepositoryImpl, episodeAirTimeRepository episode_air_time.EpisodeAirTimeRepositoryImpl, events *Events, claims cache.Cache) *Scheduler {
+	return &Scheduler{
+		EpisodeRepository:        episodeRepository,
+		EpisodeAirTimeRepository: episodeAirTimeRepository,
+		Events:                   events,
+		Claims:                   claims,
+		keyBuilder:               cache.GetKeyBuilder(),
+	}
+}
+
+// Run polls every interval until ctx is done, starting from now. A non-positive
+// interval disables the scheduler.
+func (s *Scheduler) Run(ctx context.Context, interval time.Duration) {
+	log := logger.FromCtx(ctx)
+	if interval <= 0 {
+		log.Warn().Msg("Airing scheduler disabled, episodeAiring and scheduleChanged will only carry mutation events")
+		return
+	}
+
+	s.last = time.Now()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.Tick(ctx, now); err != nil {
+				log.Warn().Err(err).Msg("Failed to poll for airing episodes")
+			}
+		}
+	}
+}
+
+// Tick publishes the events between the previous tick and now. The first tick only
+// starts the window. When loading fails the window is kept, so the next tick
+// covers it again.
+func (s *Scheduler) Tick(ctx context.Context, now time.Time) error {
+	if s.last.IsZero() {
+		s.last = now
+		return nil
+	}
+	from := s.last
+	if !now.After(from) {
+		return nil
+	}
+
+	airings, err := s.airingBetween(ctx, from, now)
+	if err != nil {
+		return err
+	}
+	changes, err := s.changedBetween(ctx, from, now)
+	if err != nil {
+		return err
+	}
+	s.last = now
+
+	for _, event := range airings {
+		key := fmt.Sprintf("airing:%s:%d:%s:%d", event.AnimeID, event.EpisodeNumber, event.AirType, event.AirTime.Unix())
+		if !s.claim(ctx, key) {
+			continue
+		}
+		if err := s.Events.PublishEpisodeAiring(ctx, event); err != nil {
+			return err
+		}
+	}
+	for _, event := range changes {
+		key := fmt.Sprintf("schedule:%s:%d", event.AnimeID, event.ChangedAt.Unix())
+		if !s.claim(ctx, key) {
+			continue
+		}
+		if err := s.Events.PublishScheduleChanged(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// airingBetween returns the releases in [from, to), worked out by Releases for each
+// anime with a precise air time or a broadcast air date in the window
+func (s *Scheduler) airingBetween(ctx context.Context, from time.Time, to time.Time) ([]EpisodeAiring, error) {
+	airTimes, err := s.EpisodeAirTimeRepository.FindAiringBetween(from, to)
+	if err != nil {
+		return nil, err
+	}
+	animeByID := make(map[string]*anime.Anime)
+	entityFor := func(animeID string) *anime.Anime {
+		if animeByID[animeID] == nil {
+			animeByID[animeID] = &anime.Anime{ID: animeID}
+		}
+		return animeByID[animeID]
+	}
+	for _, airTime := range airTimes {
+		entityFor(airTime.AnimeID)
+	}
+
+	// ParseAirTime can move an air date a day either way when converting the
+	// broadcast slot to UTC
+	episodes, err := s.EpisodeRepository.FindAiredBetween(ctx, from.AddDate(0, 0, -1), to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	for _, episode := range episodes {
+		if episode.AnimeID == nil {
+			continue
+		}
+		animeEntity := entityFor(*episode.AnimeID)
+		animeEntity.Broadcast = episode.Broadcast
+		animeEntity.AnimeEpisodes = append(animeEntity.AnimeEpisodes, &episode.AnimeEpisode)
+	}
+	if len(animeByID) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, 0, len(animeByID))
+	for id := range animeByID {
+		ids = append(ids, id)
+	}
+	precise, err := s.EpisodeAirTimeRepository.FindByAnimeIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []EpisodeAiring
+	for id, animeEntity := range animeByID {
+		for _, release := range Releases(animeEntity, precise[id]) {
+			if !release.AirTime.Before(from) && release.AirTime.Before(to) {
+				events = append(events, release)
+			}
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].AirTime.Equal(events[j].AirTime) {
+			return events[i].AirTime.Before(events[j].AirTime)
+		}
+		return events[i].AnimeID < events[j].AnimeID
+	})
+	return events, nil
+}
+
+// changedBetween returns one event per anime whose episodes or air times were
+// written in [from, to), stamped with the latest write
+func (s *Scheduler) changedBetween(ctx context.Context, from time.Time, to time.Time) ([]ScheduleChanged, error) {
+	latest := make(map[string]time.Time)
+	touch := func(animeID string, at time.Time) {
+		if at.After(latest[animeID]) {
+			latest[animeID] = at
+		}
+	}
+
+	airTimes, err := s.EpisodeAirTimeRepository.FindUpdatedBetween(from, to)
+	if err != nil {
+		return nil, err
+	}
+	for _, airTime := range airTimes {
+		touch(airTime.AnimeID, airTime.UpdatedAt)
+	}
+
+	episodes, err := s.EpisodeRepository.FindUpdatedBetween(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	for _, episode := range episodes {
+		if episode.AnimeID != nil {
+			touch(*episode.AnimeID, episode.UpdatedAt)
+		}
+	}
+
+	changes := make([]ScheduleChanged, 0, len(latest))
+	for animeID, changedAt := range latest {
+		changes = append(changes, ScheduleChanged{AnimeID: animeID, ChangedAt: changedAt})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].AnimeID < changes[j].AnimeID
+	})
+	return changes, nil
+}
+
+// claim reports whether this replica should send the event identified by key
+func (s *Scheduler) claim(ctx context.Context, key string) bool {
+	if s.Claims == nil {
+		return true
+	}
+	claimed, err := s.Claims.SetNX(ctx, s.keyBuilder.AiringEventClaim(key), []byte("1"), claimTTL)
+	if err != nil {
+		// Sending twice beats not sending at all
+		return true
+	}
+	return claimed
+}
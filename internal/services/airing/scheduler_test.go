@@ -0,0 +1,144 @@
+package airing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	anime_episode "github.com/weeb-vip/anime-api/internal/db/repositories/anime_episode"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/episode_air_time"
+	"github.com/weeb-vip/anime-api/internal/pubsub"
+)
+
+// The embedded interfaces panic on the queries the scheduler does not make
+type fakeEpisodeRepository struct {
+	anime_episode.AnimeEpisodeRepositoryImpl
+	aired   []*anime_episode.EpisodeBroadcast
+	updated []*anime_episode.AnimeEpisode
+}
+
+func (f *fakeEpisodeRepository) FindAiredBetween(ctx context.Context, from time.Time, to time.Time) ([]*anime_episode.EpisodeBroadcast, error) {
+	return f.aired, nil
+}
+
+func (f *fakeEpisodeRepository) FindUpdatedBetween(ctx context.Context, from time.Time, to time.Time) ([]*anime_episode.AnimeEpisode, error) {
+	return f.updated, nil
+}
+
+type fakeAirTimeRepository struct {
+	episode_air_time.EpisodeAirTimeRepositoryImpl
+	airTimes []episode_air_time.EpisodeAirTime
+}
+
+func (f *fakeAirTimeRepository) FindAiringBetween(from time.Time, to time.Time) ([]episode_air_time.EpisodeAirTime, error) {
+	var result []episode_air_time.EpisodeAirTime
+	for _, airTime := range f.airTimes {
+		if !airTime.AirDatetime.Before(from) && airTime.AirDatetime.Before(to) {
+			result = append(result, airTime)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeAirTimeRepository) FindUpdatedBetween(from time.Time, to time.Time) ([]episode_air_time.EpisodeAirTime, error) {
+	var result []episode_air_time.EpisodeAirTime
+	for _, airTime := range f.airTimes {
+		if !airTime.UpdatedAt.Before(from) && airTime.UpdatedAt.Before(to) {
+			result = append(result, airTime)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeAirTimeRepository) FindByAnimeIDs(animeIDs []string) (map[string][]episode_air_time.EpisodeAirTime, error) {
+	result := make(map[string][]episode_air_time.EpisodeAirTime)
+	for _, airTime := range f.airTimes {
+		result[airTime.AnimeID] = append(result[airTime.AnimeID], airTime)
+	}
+	return result, nil
+}
+
+func stringPtr(s string) *string { return &s }
+func intPtr(i int) *int          { return &i }
+
+func drain[T any](events <-chan T) []T {
+	var result []T
+	for {
+		select {
+		case event := <-events:
+			result = append(result, event)
+		case <-time.After(50 * time.Millisecond):
+			return result
+		}
+	}
+}
+
+func TestSchedulerPublishesReleasesInTheWindow(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	start := time.Date(2024, 1, 10, 15, 0, 0, 0, time.UTC)
+	// 01:29 JST on the 11th is 16:29 UTC on the 10th
+	aired := time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)
+	episodes := &fakeEpisodeRepository{aired: []*anime_episode.EpisodeBroadcast{
+		{AnimeEpisode: anime_episode.AnimeEpisode{AnimeID: stringPtr("broadcast-only"), Episode: intPtr(3), Aired: &aired}, Broadcast: stringPtr("Thursdays at 01:29 (JST)")},
+		{AnimeEpisode: anime_episode.AnimeEpisode{AnimeID: stringPtr("precise"), Episode: intPtr(5), Aired: &aired}, Broadcast: stringPtr("Thursdays at 01:29 (JST)")},
+	}}
+	airTimes := &fakeAirTimeRepository{airTimes: []episode_air_time.EpisodeAirTime{
+		{AnimeID: "precise", EpisodeNumber: 5, AirType: "raw", AirDatetime: start.Add(80 * time.Minute)},
+		{AnimeID: "precise", EpisodeNumber: 5, AirType: "sub", AirDatetime: start.Add(3 * time.Hour)},
+	}}
+
+	events := NewEvents(pubsub.NewLocalBus())
+	airings := events.SubscribeEpisodeAiring(ctx)
+	scheduler := NewScheduler(episodes, airTimes, events, nil)
+
+	if err := scheduler.Tick(ctx, start); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := scheduler.Tick(ctx, start.Add(2*time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := drain(airings)
+	if len(got) != 2 {
+		t.Fatalf("got %d events, want 2: %+v", len(got), got)
+	}
+	if got[0].AnimeID != "precise" || got[0].AirType != "raw" {
+		t.Errorf("first event = %+v, want the precise raw time", got[0])
+	}
+	if got[1].AnimeID != "broadcast-only" || got[1].AirType != "raw" || !got[1].AirTime.Equal(time.Date(2024, 1, 10, 16, 29, 0, 0, time.UTC)) {
+		t.Errorf("second event = %+v, want the broadcast slot of broadcast-only", got[1])
+	}
+}
+
+func TestSchedulerPublishesScheduleChanges(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	start := time.Date(2024, 1, 10, 15, 0, 0, 0, time.UTC)
+	episodes := &fakeEpisodeRepository{updated: []*anime_episode.AnimeEpisode{
+		{AnimeID: stringPtr("a"), UpdatedAt: start.Add(time.Minute)},
+		{AnimeID: stringPtr("a"), UpdatedAt: start.Add(2 * time.Minute)},
+	}}
+	airTimes := &fakeAirTimeRepository{airTimes: []episode_air_time.EpisodeAirTime{
+		{AnimeID: "b", EpisodeNumber: 1, AirType: "sub", AirDatetime: start.AddDate(0, 0, 7), UpdatedAt: start.Add(time.Minute)},
+	}}
+
+	events := NewEvents(pubsub.NewLocalBus())
+	changes := events.SubscribeScheduleChanged(ctx)
+	scheduler := NewScheduler(episodes, airTimes, events, nil)
+
+	_ = scheduler.Tick(ctx, start)
+	if err := scheduler.Tick(ctx, start.Add(5*time.Minute)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := drain(changes)
+	if len(got) != 2 || got[0].AnimeID != "a" || got[1].AnimeID != "b" {
+		t.Fatalf("got %+v, want one change each for a and b", got)
+	}
+	if !got[0].ChangedAt.Equal(start.Add(2 * time.Minute)) {
+		t.Errorf("changedAt = %v, want the latest write", got[0].ChangedAt)
+	}
+}
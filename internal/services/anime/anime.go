@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/weeb-vip/anime-api/internal/db/repositories/anime"
+	"github.com/weeb-vip/anime-api/internal/search"
 	"github.com/weeb-vip/anime-api/tracing"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -25,16 +26,28 @@ type AnimeServiceImpl interface {
 	AiringAnimeWithEpisodes(ctx context.Context, startDate *time.Time, endDate *time.Time, days *int) ([]*anime.Anime, error)
 	SearchedAnime(ctx context.Context, query string, page int, limit int) ([]*anime.Anime, error)
 	SearchedAnimeWithEpisodes(ctx context.Context, query string, page int, limit int) ([]*anime.Anime, error)
+	SearchedAnimeFilteredWithEpisodes(ctx context.Context, filter anime.SearchFilter, page int, limit int) ([]*anime.Anime, error)
+	AnimePage(ctx context.Context, filter anime.SearchFilter, after *anime.Cursor, limit int, withEpisodes bool) ([]*anime.Anime, error)
+	CountAnime(ctx context.Context, filter anime.SearchFilter) (int64, error)
+	RankedSearch(ctx context.Context, query string, limit int) ([]RankedAnime, error)
+	SearchSuggestions(ctx context.Context, prefix string, limit int) ([]search.Suggestion, bool, error)
 	AnimeBySeasonWithEpisodes(ctx context.Context, season string) ([]*anime.Anime, error)
 	AnimeBySeasonWithEpisodesOptimized(ctx context.Context, season string) ([]*anime.Anime, error)
 	AnimeBySeasonWithIndexHints(ctx context.Context, season string) ([]*anime.Anime, error)
 	AnimeBySeasonBatched(ctx context.Context, season string) ([]*anime.Anime, error)
 	AnimeBySeasonOptimized(ctx context.Context, season string) ([]*anime.Anime, error)
 	AnimeBySeasonWithFieldSelection(ctx context.Context, season string, fields *anime.FieldSelection, limit int) ([]*anime.Anime, error)
+	CreateAnime(ctx context.Context, animeEntity *anime.Anime, seasons []anime.Season) error
+	UpdateAnime(ctx context.Context, animeEntity *anime.Anime, columns []string, seasons []anime.Season) error
+	DeleteAnime(ctx context.Context, id string) error
 }
 
 type AnimeService struct {
 	Repository anime.AnimeRepositoryImpl
+	// SearchIndex ranks title searches once ready; nil keeps the LIKE search
+	SearchIndex *search.Index
+	// Suggestions serves typeahead once ready; before that it falls back to LIKE
+	Suggestions *search.PrefixIndex
 }
 
 // startServiceSpan starts a new OpenTelemetry span for service operations
@@ -115,6 +128,9 @@ func (a *AnimeService) SearchedAnime(ctx context.Context, query string, page int
 	)
 	defer span.End()
 
+	if hits, ok := a.searchHits(query, maxSearchHits); ok {
+		return a.findRanked(ctx, hitIDs(hits), page, limit, false)
+	}
 	return a.Repository.SearchAnime(ctx, query, page, limit)
 }
 
@@ -156,9 +172,57 @@ func (a *AnimeService) SearchedAnimeWithEpisodes(ctx context.Context, query stri
 	)
 	defer span.End()
 
+	if hits, ok := a.searchHits(query, maxSearchHits); ok {
+		return a.findRanked(ctx, hitIDs(hits), page, limit, true)
+	}
 	return a.Repository.SearchAnimeWithEpisodes(ctx, query, page, limit)
 }
 
+func (a *AnimeService) SearchedAnimeFilteredWithEpisodes(ctx context.Context, filter anime.SearchFilter, page int, limit int) ([]*anime.Anime, error) {
+	ctx, span := a.startServiceSpan(ctx, "SearchedAnimeFilteredWithEpisodes")
+	span.SetAttributes(
+		attribute.String("search.query", filter.Query),
+		attribute.StringSlice("search.tags", filter.Tags),
+		attribute.StringSlice("search.studios", filter.Studios),
+		attribute.StringSlice("search.statuses", filter.Statuses),
+		attribute.Int("pagination.page", page),
+		attribute.Int("pagination.limit", limit),
+	)
+	defer span.End()
+
+	// Without an explicit sort, title matches are ordered by relevance
+	if filter.Sort.IsZero() {
+		if hits, ok := a.searchHits(filter.Query, maxSearchHits); ok {
+			ids, err := a.filterRanked(ctx, hitIDs(hits), filter)
+			if err != nil {
+				return nil, err
+			}
+			return a.findRanked(ctx, ids, page, limit, true)
+		}
+	}
+	return a.Repository.SearchAnimeFilteredWithEpisodes(ctx, filter, page, limit)
+}
+
+func (a *AnimeService) AnimePage(ctx context.Context, filter anime.SearchFilter, after *anime.Cursor, limit int, withEpisodes bool) ([]*anime.Anime, error) {
+	ctx, span := a.startServiceSpan(ctx, "AnimePage")
+	span.SetAttributes(
+		attribute.String("search.query", filter.Query),
+		attribute.String("search.season", filter.Season),
+		attribute.Bool("pagination.after", after != nil),
+		attribute.Int("pagination.limit", limit),
+	)
+	defer span.End()
+
+	return a.Repository.SearchAnimePage(ctx, filter, after, limit, withEpisodes)
+}
+
+func (a *AnimeService) CountAnime(ctx context.Context, filter anime.SearchFilter) (int64, error) {
+	ctx, span := a.startServiceSpan(ctx, "CountAnime")
+	defer span.End()
+
+	return a.Repository.CountAnime(ctx, filter)
+}
+
 func (a *AnimeService) AiringAnimeWithEpisodes(ctx context.Context, startDate *time.Time, endDate *time.Time, days *int) ([]*anime.Anime, error) {
 	tracer := tracing.GetTracer(ctx)
 	ctx, span := tracer.Start(ctx, "AnimeService.AiringAnimeWithEpisodes",
@@ -0,0 +1,202 @@
+package anime
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime"
+	"github.com/weeb-vip/anime-api/internal/search"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// maxSearchHits bounds how many ranked hits a title search pages through
+const maxSearchHits = 1000
+
+// RankedAnime is a search result and its relevance; higher scores are better
+type RankedAnime struct {
+	Anime *anime.Anime
+	Score float64
+}
+
+// NewAnimeServiceWithSearch returns a service that ranks title searches with index
+// and suggests titles with suggestions once they are ready, and falls back to the
+// repository's LIKE search before that
+func NewAnimeServiceWithSearch(animeRepository anime.AnimeRepositoryImpl, index *search.Index, suggestions *search.PrefixIndex) AnimeServiceImpl {
+	return &AnimeService{
+		Repository:  animeRepository,
+		SearchIndex: index,
+		Suggestions: suggestions,
+	}
+}
+
+// RankedSearch returns up to limit anime whose titles match query, best first
+func (a *AnimeService) RankedSearch(ctx context.Context, query string, limit int) ([]RankedAnime, error) {
+	ctx, span := a.startServiceSpan(ctx, "RankedSearch")
+	span.SetAttributes(
+		attribute.String("search.query", query),
+		attribute.Int("pagination.limit", limit),
+	)
+	defer span.End()
+
+	hits, ok := a.searchHits(query, limit)
+	span.SetAttributes(attribute.Bool("search.index", ok))
+	if !ok {
+		return a.rankLikeSearch(ctx, query, limit)
+	}
+
+	animes, err := a.Repository.FindByIDs(ctx, hitIDs(hits))
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*anime.Anime, len(animes))
+	for _, animeEntity := range animes {
+		byID[animeEntity.ID] = animeEntity
+	}
+
+	results := make([]RankedAnime, 0, len(hits))
+	for _, hit := range hits {
+		// Anime deleted since the last index refresh are skipped
+		if animeEntity, ok := byID[hit.ID]; ok {
+			results = append(results, RankedAnime{Anime: animeEntity, Score: hit.Score})
+		}
+	}
+	return results, nil
+}
+
+// SearchSuggestions returns up to limit anime with a title word starting with prefix,
+// and whether they came from the prefix index rather than the LIKE fallback
+func (a *AnimeService) SearchSuggestions(ctx context.Context, prefix string, limit int) ([]search.Suggestion, bool, error) {
+	ctx, span := a.startServiceSpan(ctx, "SearchSuggestions")
+	span.SetAttributes(
+		attribute.String("search.prefix", prefix),
+		attribute.Int("pagination.limit", limit),
+	)
+	defer span.End()
+
+	ready := a.Suggestions != nil && a.Suggestions.Ready()
+	span.SetAttributes(attribute.Bool("search.index", ready))
+	if ready {
+		return a.Suggestions.Suggest(prefix, limit), true, nil
+	}
+
+	// Index the LIKE matches on the fly, which finds the same anime for prefixes of
+	// whole titles but misses kana/romaji variants
+	animes, err := a.Repository.SearchAnime(ctx, strings.TrimSpace(prefix), 1, limit)
+	if err != nil {
+		return nil, false, err
+	}
+	docs := make([]search.SuggestDocument, 0, len(animes))
+	for _, animeEntity := range animes {
+		titles, synonyms := animeEntity.Titles()
+		docs = append(docs, search.SuggestDocument{
+			Document: search.Document{ID: animeEntity.ID, Titles: titles, Synonyms: synonyms},
+			ImageURL: animeEntity.ImageURL,
+			Year:     animeEntity.StartYear(),
+		})
+	}
+	matches := search.NewPrefixIndex()
+	matches.Replace(docs)
+	return matches.Suggest(prefix, limit), false, nil
+}
+
+// rankLikeSearch scores the repository's unranked LIKE matches, for use while the
+// index is still being built
+func (a *AnimeService) rankLikeSearch(ctx context.Context, query string, limit int) ([]RankedAnime, error) {
+	animes, err := a.Repository.SearchAnime(ctx, query, 1, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]RankedAnime, 0, len(animes))
+	for _, animeEntity := range animes {
+		titles, synonyms := animeEntity.Titles()
+		score := search.Score(query, search.Document{ID: animeEntity.ID, Titles: titles, Synonyms: synonyms})
+		results = append(results, RankedAnime{Anime: animeEntity, Score: score})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	return results, nil
+}
+
+// searchHits ranks query with the index, reporting false when there is no usable index
+func (a *AnimeService) searchHits(query string, limit int) ([]search.Hit, bool) {
+	if a.SearchIndex == nil || !a.SearchIndex.Ready() || strings.TrimSpace(query) == "" {
+		return nil, false
+	}
+	return a.SearchIndex.Search(query, limit), true
+}
+
+// findRanked loads one page of ids, keeping their order
+func (a *AnimeService) findRanked(ctx context.Context, ids []string, page int, limit int, withEpisodes bool) ([]*anime.Anime, error) {
+	if page < 1 {
+		page = 1
+	}
+	start := (page - 1) * limit
+	if start >= len(ids) || limit <= 0 {
+		return []*anime.Anime{}, nil
+	}
+	end := start + limit
+	if end > len(ids) {
+		end = len(ids)
+	}
+	ids = ids[start:end]
+
+	var animes []*anime.Anime
+	var err error
+	if withEpisodes {
+		animes, err = a.Repository.FindByIDsWithEpisodes(ctx, ids)
+	} else {
+		animes, err = a.Repository.FindByIDs(ctx, ids)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*anime.Anime, len(animes))
+	for _, animeEntity := range animes {
+		byID[animeEntity.ID] = animeEntity
+	}
+	ordered := make([]*anime.Anime, 0, len(animes))
+	for _, id := range ids {
+		if animeEntity, ok := byID[id]; ok {
+			ordered = append(ordered, animeEntity)
+		}
+	}
+	return ordered, nil
+}
+
+// filterRanked applies the facets of filter to ranked ids, keeping their order
+func (a *AnimeService) filterRanked(ctx context.Context, ids []string, filter anime.SearchFilter) ([]string, error) {
+	if len(filter.Tags) == 0 && len(filter.AnyTags) == 0 && len(filter.Studios) == 0 && len(filter.Statuses) == 0 && filter.Season == "" {
+		return ids, nil
+	}
+
+	filter.Query = ""
+	filter.IDs = ids
+	matched, err := a.Repository.FilterAnimeIDs(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	keep := make(map[string]bool, len(matched))
+	for _, id := range matched {
+		keep[id] = true
+	}
+	filtered := make([]string, 0, len(matched))
+	for _, id := range ids {
+		if keep[id] {
+			filtered = append(filtered, id)
+		}
+	}
+	return filtered, nil
+}
+
+func hitIDs(hits []search.Hit) []string {
+	ids := make([]string, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.ID
+	}
+	return ids
+}
@@ -0,0 +1,39 @@
+package anime
+
+import (
+	"context"
+
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// CreateAnime stores a new anime with its seasons
+func (a *AnimeService) CreateAnime(ctx context.Context, animeEntity *anime.Anime, seasons []anime.Season) error {
+	ctx, span := a.startServiceSpan(ctx, "CreateAnime")
+	defer span.End()
+
+	err := a.Repository.Create(ctx, animeEntity, seasons)
+	span.SetAttributes(attribute.String("anime.id", animeEntity.ID))
+	return err
+}
+
+// UpdateAnime writes the given columns of an anime and, when seasons is non-nil, replaces its seasons
+func (a *AnimeService) UpdateAnime(ctx context.Context, animeEntity *anime.Anime, columns []string, seasons []anime.Season) error {
+	ctx, span := a.startServiceSpan(ctx, "UpdateAnime")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("anime.id", animeEntity.ID),
+		attribute.StringSlice("anime.columns", columns),
+	)
+
+	return a.Repository.Update(ctx, animeEntity, columns, seasons)
+}
+
+// DeleteAnime removes an anime and the rows that belong to it
+func (a *AnimeService) DeleteAnime(ctx context.Context, id string) error {
+	ctx, span := a.startServiceSpan(ctx, "DeleteAnime")
+	defer span.End()
+	span.SetAttributes(attribute.String("anime.id", id))
+
+	return a.Repository.Delete(ctx, id)
+}
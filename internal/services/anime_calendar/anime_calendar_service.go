@@ -0,0 +1,178 @@
+package anime_calendar
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/weeb-vip/anime-api/internal/calendar"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/episode_air_time"
+	"github.com/weeb-vip/anime-api/internal/services"
+	"github.com/weeb-vip/anime-api/internal/services/airing"
+	anime_service "github.com/weeb-vip/anime-api/internal/services/anime"
+	"github.com/weeb-vip/anime-api/tracing"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+)
+
+// uidDomain ends every event UID, so UIDs stay unique next to other feeds
+const uidDomain = "anime-api.weeb.vip"
+
+type AnimeCalendarServiceImpl interface {
+	SeasonCalendar(ctx context.Context, season string) (*calendar.Calendar, error)
+	AnimeCalendar(ctx context.Context, animeIDs []string) (*calendar.Calendar, error)
+	AiringCalendar(ctx context.Context, from time.Time, days int) (*calendar.Calendar, error)
+}
+
+// AnimeCalendarService turns episode schedules into calendar events, one per
+// release as airing.Releases finds them
+type AnimeCalendarService struct {
+	AnimeService             anime_service.AnimeServiceImpl
+	EpisodeAirTimeRepository episode_air_time.EpisodeAirTimeRepositoryImpl
+}
+
+func NewAnimeCalendarService(animeService anime_service.AnimeServiceImpl, episodeAirTimeRepository episode_air_time.EpisodeAirTimeRepositoryImpl) AnimeCalendarServiceImpl {
+	return &AnimeCalendarService{
+		AnimeService:             animeService,
+		EpisodeAirTimeRepository: episodeAirTimeRepository,
+	}
+}
+
+// SeasonCalendar returns every scheduled episode of the anime in season
+func (s *AnimeCalendarService) SeasonCalendar(ctx context.Context, season string) (*calendar.Calendar, error) {
+	span, spanCtx := startSpan(ctx, "SeasonCalendar")
+	defer span.Finish()
+
+	animes, err := s.AnimeService.AnimeBySeasonWithEpisodes(spanCtx, season)
+	if err != nil {
+		return nil, err
+	}
+	return s.build(fmt.Sprintf("Anime %s", seasonName(season)), animes, nil)
+}
+
+// AnimeCalendar returns every scheduled episode of the given anime
+func (s *AnimeCalendarService) AnimeCalendar(ctx context.Context, animeIDs []string) (*calendar.Calendar, error) {
+	span, spanCtx := startSpan(ctx, "AnimeCalendar")
+	defer span.Finish()
+
+	animes, err := s.AnimeService.AnimeByIDsWithEpisodes(spanCtx, animeIDs)
+	if err != nil {
+		return nil, err
+	}
+	name := "Anime schedule"
+	if len(animes) == 1 {
+		name = displayTitle(animes[0])
+	}
+	return s.build(name, animes, nil)
+}
+
+// AiringCalendar returns the episodes airing in the days after from, the window
+// currentlyAiring covers
+func (s *AnimeCalendarService) AiringCalendar(ctx context.Context, from time.Time, days int) (*calendar.Calendar, error) {
+	span, spanCtx := startSpan(ctx, "AiringCalendar")
+	defer span.Finish()
+
+	animes, err := s.AnimeService.AiringAnimeWithEpisodes(spanCtx, &from, nil, &days)
+	if err != nil {
+		return nil, err
+	}
+	to := from.AddDate(0, 0, days)
+	return s.build("Currently airing anime", animes, func(start time.Time) bool {
+		return !start.Before(from) && start.Before(to)
+	})
+}
+
+// build collects the events of animes, keeping those whose start passes keep when
+// it is set, ordered by start
+func (s *AnimeCalendarService) build(name string, animes []*anime.Anime, keep func(time.Time) bool) (*calendar.Calendar, error) {
+	ids := make([]string, 0, len(animes))
+	for _, animeEntity := range animes {
+		ids = append(ids, animeEntity.ID)
+	}
+	airTimes, err := s.EpisodeAirTimeRepository.FindByAnimeIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	cal := &calendar.Calendar{Name: name}
+	for _, animeEntity := range animes {
+		for _, event := range animeEvents(animeEntity, airTimes[animeEntity.ID]) {
+			if keep == nil || keep(event.Start) {
+				cal.Events = append(cal.Events, event)
+			}
+		}
+	}
+	sort.SliceStable(cal.Events, func(i, j int) bool {
+		return cal.Events[i].Start.Before(cal.Events[j].Start)
+	})
+	return cal, nil
+}
+
+// animeEvents returns an event per release of the loaded episodes of an anime
+func animeEvents(animeEntity *anime.Anime, airTimes []episode_air_time.EpisodeAirTime) []calendar.Event {
+	title := displayTitle(animeEntity)
+	duration := services.EpisodeDuration(animeEntity.Duration)
+
+	episodeTitles := make(map[int]*string)
+	for _, episode := range animeEntity.AnimeEpisodes {
+		if episode != nil && episode.Episode != nil {
+			episodeTitles[*episode.Episode] = episode.TitleEn
+		}
+	}
+
+	releases := airing.Releases(animeEntity, airTimes)
+	events := make([]calendar.Event, 0, len(releases))
+	for _, release := range releases {
+		events = append(events, episodeEvent(animeEntity.ID, title, release.EpisodeNumber, episodeTitles[release.EpisodeNumber], release.AirType, release.AirTime, duration))
+	}
+	return events
+}
+
+// episodeEvent builds the event for one release of an episode. Its UID names the
+// anime, episode and air type only, so a rescheduled release updates the event
+// clients already have.
+func episodeEvent(animeID string, title string, episodeNumber int, episodeTitle *string, airType string, start time.Time, duration time.Duration) calendar.Event {
+	summary := fmt.Sprintf("%s - Episode %d", title, episodeNumber)
+	if airType != "raw" {
+		summary = fmt.Sprintf("%s (%s)", summary, strings.ToUpper(airType))
+	}
+	description := ""
+	if episodeTitle != nil {
+		description = *episodeTitle
+	}
+	return calendar.Event{
+		UID:         fmt.Sprintf("%s-%d-%s@%s", animeID, episodeNumber, airType, uidDomain),
+		Summary:     summary,
+		Description: description,
+		Start:       start,
+		End:         start.Add(duration),
+	}
+}
+
+func displayTitle(animeEntity *anime.Anime) string {
+	for _, title := range []*string{animeEntity.TitleEn, animeEntity.TitleRomaji, animeEntity.TitleJp, animeEntity.TitleKanji} {
+		if title != nil && *title != "" {
+			return *title
+		}
+	}
+	return animeEntity.ID
+}
+
+// seasonName turns SPRING_2024 into Spring 2024
+func seasonName(season string) string {
+	name, year, found := strings.Cut(season, "_")
+	if !found || name == "" {
+		return season
+	}
+	return strings.ToUpper(name[:1]) + strings.ToLower(name[1:]) + " " + year
+}
+
+func startSpan(ctx context.Context, operationName string) (tracer.Span, context.Context) {
+	span, spanCtx := tracer.StartSpanFromContext(ctx, operationName)
+	span.SetTag("service", "anime_calendar")
+	span.SetTag("type", "service")
+	span.SetTag("environment", tracing.GetEnvironmentTag())
+	return span, spanCtx
+}
@@ -0,0 +1,107 @@
+package anime_calendar
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime"
+	anime_episode "github.com/weeb-vip/anime-api/internal/db/repositories/anime_episode"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/episode_air_time"
+	anime_service "github.com/weeb-vip/anime-api/internal/services/anime"
+)
+
+// fakeAnimeService serves fixed anime; the embedded interface panics on anything else
+type fakeAnimeService struct {
+	anime_service.AnimeServiceImpl
+	animes []*anime.Anime
+}
+
+func (f *fakeAnimeService) AnimeByIDsWithEpisodes(ctx context.Context, ids []string) ([]*anime.Anime, error) {
+	return f.animes, nil
+}
+
+func (f *fakeAnimeService) AiringAnimeWithEpisodes(ctx context.Context, startDate *time.Time, endDate *time.Time, days *int) ([]*anime.Anime, error) {
+	return f.animes, nil
+}
+
+type fakeEpisodeAirTimeRepository struct {
+	episode_air_time.EpisodeAirTimeRepositoryImpl
+	airTimes map[string][]episode_air_time.EpisodeAirTime
+}
+
+func (f *fakeEpisodeAirTimeRepository) FindByAnimeIDs(animeIDs []string) (map[string][]episode_air_time.EpisodeAirTime, error) {
+	return f.airTimes, nil
+}
+
+func stringPtr(s string) *string { return &s }
+
+func intPtr(i int) *int { return &i }
+
+func calendarFixture() (*fakeAnimeService, *fakeEpisodeAirTimeRepository) {
+	animeID := "frieren"
+	aired := func(day int) *time.Time {
+		t := time.Date(2026, 10, day, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+	animes := []*anime.Anime{{
+		ID:        animeID,
+		TitleEn:   stringPtr("Frieren"),
+		Duration:  stringPtr("24 min per episode"),
+		Broadcast: stringPtr("Fridays at 23:00 (JST)"),
+		AnimeEpisodes: []*anime_episode.AnimeEpisode{
+			{AnimeID: &animeID, Episode: intPtr(1), TitleEn: stringPtr("The Journey's End"), Aired: aired(2)},
+			{AnimeID: &animeID, Episode: intPtr(2), Aired: aired(9)},
+		},
+	}}
+	airTimes := map[string][]episode_air_time.EpisodeAirTime{
+		animeID: {
+			{AnimeID: animeID, EpisodeNumber: 1, AirType: "raw", AirDatetime: time.Date(2026, 10, 2, 14, 5, 0, 0, time.UTC)},
+			{AnimeID: animeID, EpisodeNumber: 1, AirType: "sub", AirDatetime: time.Date(2026, 10, 2, 16, 0, 0, 0, time.UTC)},
+		},
+	}
+	return &fakeAnimeService{animes: animes}, &fakeEpisodeAirTimeRepository{airTimes: airTimes}
+}
+
+func TestAnimeCalendar_UsesPreciseTimesThenBroadcast(t *testing.T) {
+	animeService, airTimeRepository := calendarFixture()
+	service := NewAnimeCalendarService(animeService, airTimeRepository)
+
+	cal, err := service.AnimeCalendar(context.Background(), []string{"frieren"})
+	require.NoError(t, err)
+	require.Len(t, cal.Events, 3)
+	assert.Equal(t, "Frieren", cal.Name)
+
+	raw := cal.Events[0]
+	assert.Equal(t, "frieren-1-raw@anime-api.weeb.vip", raw.UID)
+	assert.Equal(t, "Frieren - Episode 1", raw.Summary)
+	assert.Equal(t, "The Journey's End", raw.Description)
+	assert.Equal(t, time.Date(2026, 10, 2, 14, 5, 0, 0, time.UTC), raw.Start)
+	assert.Equal(t, 24*time.Minute, raw.End.Sub(raw.Start))
+
+	sub := cal.Events[1]
+	assert.Equal(t, "frieren-1-sub@anime-api.weeb.vip", sub.UID)
+	assert.Equal(t, "Frieren - Episode 1 (SUB)", sub.Summary)
+
+	// episode 2 has no precise time, so it airs at the broadcast slot
+	broadcast := cal.Events[2]
+	assert.Equal(t, "frieren-2-raw@anime-api.weeb.vip", broadcast.UID)
+	assert.Equal(t, time.Date(2026, 10, 9, 14, 0, 0, 0, time.UTC), broadcast.Start.UTC())
+}
+
+func TestAiringCalendar_KeepsEventsInWindow(t *testing.T) {
+	animeService, airTimeRepository := calendarFixture()
+	service := NewAnimeCalendarService(animeService, airTimeRepository)
+
+	cal, err := service.AiringCalendar(context.Background(), time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC), 7)
+	require.NoError(t, err)
+	require.Len(t, cal.Events, 1)
+	assert.Equal(t, "frieren-2-raw@anime-api.weeb.vip", cal.Events[0].UID)
+}
+
+func TestSeasonName(t *testing.T) {
+	assert.Equal(t, "Fall 2026", seasonName("FALL_2026"))
+	assert.Equal(t, "weird", seasonName("weird"))
+}
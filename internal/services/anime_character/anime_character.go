@@ -1,10 +1,13 @@
 package anime_character
 
 import (
+	"context"
+
 	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_character"
 )
 
 type AnimeCharacterServiceImpl interface {
+	FindAnimeCharacterById(ctx context.Context, id string) (*anime_character.AnimeCharacter, error)
 }
 
 type AnimeCharacterService struct {
@@ -16,3 +19,7 @@ func NewAnimeCharacterService(repository anime_character.AnimeCharacterRepositor
 		Repository: repository,
 	}
 }
+
+func (a *AnimeCharacterService) FindAnimeCharacterById(ctx context.Context, id string) (*anime_character.AnimeCharacter, error) {
+	return a.Repository.FindAnimeCharacterById(ctx, id)
+}
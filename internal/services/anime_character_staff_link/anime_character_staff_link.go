@@ -2,11 +2,16 @@ package anime_character_staff_link
 
 import (
 	"context"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_character"
 	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_character_staff_link"
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_staff"
 )
 
 type AnimeCharacterStaffLinkImpl interface {
-	FindAnimeCharacterAndStaffByAnimeId(ctx context.Context, animeId string) ([]*anime_character_staff_link.AnimeCharacterWithStaff, error)
+	FindAnimeCharacterAndStaffByAnimeId(ctx context.Context, animeId string, filter anime_character_staff_link.CharacterStaffFilter) ([]*anime_character_staff_link.AnimeCharacterWithStaff, error)
+	FindCharactersByStaffIds(ctx context.Context, staffIds []string) (map[string][]anime_character.AnimeCharacter, error)
+	FindStaffByCharacterIds(ctx context.Context, characterIds []string) (map[string][]anime_staff.AnimeStaff, error)
+	FindSharedCastByAnimeId(ctx context.Context, animeId string, minShared int, limit int) ([]*anime_character_staff_link.SharedCast, error)
 }
 
 type AnimeCharacterStaffLinkService struct {
@@ -19,10 +24,22 @@ func NewAnimeCharacterStaffLinkService(repository anime_character_staff_link.Ani
 	}
 }
 
-func (a *AnimeCharacterStaffLinkService) FindAnimeCharacterAndStaffByAnimeId(ctx context.Context, animeId string) ([]*anime_character_staff_link.AnimeCharacterWithStaff, error) {
-	animeCharacters, err := a.Repository.FindAnimeCharacterAndStaffByAnimeId(ctx, animeId)
+func (a *AnimeCharacterStaffLinkService) FindAnimeCharacterAndStaffByAnimeId(ctx context.Context, animeId string, filter anime_character_staff_link.CharacterStaffFilter) ([]*anime_character_staff_link.AnimeCharacterWithStaff, error) {
+	animeCharacters, err := a.Repository.FindAnimeCharacterAndStaffByAnimeId(ctx, animeId, filter)
 	if err != nil {
 		return nil, err
 	}
 	return animeCharacters, nil
 }
+
+func (a *AnimeCharacterStaffLinkService) FindCharactersByStaffIds(ctx context.Context, staffIds []string) (map[string][]anime_character.AnimeCharacter, error) {
+	return a.Repository.FindCharactersByStaffIds(ctx, staffIds)
+}
+
+func (a *AnimeCharacterStaffLinkService) FindStaffByCharacterIds(ctx context.Context, characterIds []string) (map[string][]anime_staff.AnimeStaff, error) {
+	return a.Repository.FindStaffByCharacterIds(ctx, characterIds)
+}
+
+func (a *AnimeCharacterStaffLinkService) FindSharedCastByAnimeId(ctx context.Context, animeId string, minShared int, limit int) ([]*anime_character_staff_link.SharedCast, error) {
+	return a.Repository.FindSharedCastByAnimeId(ctx, animeId, minShared, limit)
+}
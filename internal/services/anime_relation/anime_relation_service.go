@@ -0,0 +1,123 @@
+package anime_relation
+
+import (
+	"context"
+
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_relation"
+	"github.com/weeb-vip/anime-api/tracing"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+)
+
+const (
+	// DefaultFranchiseDepth is how many relation hops Franchise follows when no depth is given
+	DefaultFranchiseDepth = 10
+	// MaxFranchiseDepth caps the depth a caller can ask for
+	MaxFranchiseDepth = 25
+)
+
+// franchiseRelationTypes are the relations that keep a walk inside one franchise.
+// Character and other relations often point at unrelated shows, so they are not followed.
+var franchiseRelationTypes = map[string]bool{
+	anime_relation.RelationSequel:             true,
+	anime_relation.RelationPrequel:            true,
+	anime_relation.RelationSideStory:          true,
+	anime_relation.RelationParentStory:        true,
+	anime_relation.RelationAlternativeSetting: true,
+	anime_relation.RelationAlternativeVersion: true,
+	anime_relation.RelationSpinOff:            true,
+	anime_relation.RelationSummary:            true,
+	anime_relation.RelationFullStory:          true,
+}
+
+// FranchiseEntry is an anime reached while walking a franchise and how many hops away it is
+type FranchiseEntry struct {
+	AnimeID string
+	Depth   int
+}
+
+type AnimeRelationServiceImpl interface {
+	FindByAnimeID(ctx context.Context, animeID string) ([]*anime_relation.AnimeRelation, error)
+	FindByAnimeIDs(ctx context.Context, animeIDs []string) (map[string][]*anime_relation.AnimeRelation, error)
+	Franchise(ctx context.Context, animeID string, maxDepth int) ([]FranchiseEntry, error)
+}
+
+type AnimeRelationService struct {
+	Repository anime_relation.AnimeRelationRepositoryImpl
+}
+
+func NewAnimeRelationService(repository anime_relation.AnimeRelationRepositoryImpl) AnimeRelationServiceImpl {
+	return &AnimeRelationService{
+		Repository: repository,
+	}
+}
+
+func (s *AnimeRelationService) FindByAnimeID(ctx context.Context, animeID string) ([]*anime_relation.AnimeRelation, error) {
+	span, spanCtx := tracer.StartSpanFromContext(ctx, "FindByAnimeID")
+	span.SetTag("service", "anime_relation")
+	span.SetTag("type", "service")
+	span.SetTag("environment", tracing.GetEnvironmentTag())
+	defer span.Finish()
+
+	return s.Repository.FindByAnimeID(spanCtx, animeID)
+}
+
+func (s *AnimeRelationService) FindByAnimeIDs(ctx context.Context, animeIDs []string) (map[string][]*anime_relation.AnimeRelation, error) {
+	span, spanCtx := tracer.StartSpanFromContext(ctx, "FindByAnimeIDs")
+	span.SetTag("service", "anime_relation")
+	span.SetTag("type", "service")
+	span.SetTag("environment", tracing.GetEnvironmentTag())
+	span.SetTag("anime.ids.count", len(animeIDs))
+	defer span.Finish()
+
+	return s.Repository.FindByAnimeIDs(spanCtx, animeIDs)
+}
+
+// Franchise walks the relation graph outward from animeID, one query per hop, and
+// returns every anime reached including the starting one. Each anime is visited
+// once so cycles terminate, and the walk stops after maxDepth hops, which is
+// clamped to 1..MaxFranchiseDepth (DefaultFranchiseDepth when not positive).
+func (s *AnimeRelationService) Franchise(ctx context.Context, animeID string, maxDepth int) ([]FranchiseEntry, error) {
+	span, spanCtx := tracer.StartSpanFromContext(ctx, "Franchise")
+	span.SetTag("service", "anime_relation")
+	span.SetTag("type", "service")
+	span.SetTag("environment", tracing.GetEnvironmentTag())
+	span.SetTag("anime.id", animeID)
+	defer span.Finish()
+
+	if maxDepth <= 0 {
+		maxDepth = DefaultFranchiseDepth
+	}
+	if maxDepth > MaxFranchiseDepth {
+		maxDepth = MaxFranchiseDepth
+	}
+
+	entries := []FranchiseEntry{{AnimeID: animeID, Depth: 0}}
+	visited := map[string]bool{animeID: true}
+	frontier := []string{animeID}
+
+	for depth := 1; depth <= maxDepth && len(frontier) > 0; depth++ {
+		relations, err := s.Repository.FindTouching(spanCtx, frontier)
+		if err != nil {
+			return nil, err
+		}
+
+		var next []string
+		for _, relation := range relations {
+			if !franchiseRelationTypes[relation.NormalizedType()] {
+				continue
+			}
+			for _, id := range []string{relation.AnimeID, relation.RelatedAnimeID} {
+				if id == "" || visited[id] {
+					continue
+				}
+				visited[id] = true
+				entries = append(entries, FranchiseEntry{AnimeID: id, Depth: depth})
+				next = append(next, id)
+			}
+		}
+		frontier = next
+	}
+
+	span.SetTag("franchise.size", len(entries))
+	return entries, nil
+}
@@ -0,0 +1,102 @@
+package anime_relation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_relation"
+)
+
+type fakeRelationRepository struct {
+	relations []*anime_relation.AnimeRelation
+	queries   int
+}
+
+func (f *fakeRelationRepository) FindByAnimeID(ctx context.Context, animeID string) ([]*anime_relation.AnimeRelation, error) {
+	return nil, nil
+}
+
+func (f *fakeRelationRepository) FindByAnimeIDs(ctx context.Context, animeIDs []string) (map[string][]*anime_relation.AnimeRelation, error) {
+	return nil, nil
+}
+
+func (f *fakeRelationRepository) FindTouching(ctx context.Context, animeIDs []string) ([]*anime_relation.AnimeRelation, error) {
+	f.queries++
+	wanted := make(map[string]bool, len(animeIDs))
+	for _, id := range animeIDs {
+		wanted[id] = true
+	}
+	var result []*anime_relation.AnimeRelation
+	for _, relation := range f.relations {
+		if wanted[relation.AnimeID] || wanted[relation.RelatedAnimeID] {
+			result = append(result, relation)
+		}
+	}
+	return result, nil
+}
+
+func relation(from, to, relationType string) *anime_relation.AnimeRelation {
+	return &anime_relation.AnimeRelation{AnimeID: from, RelatedAnimeID: to, RelationType: &relationType}
+}
+
+func franchiseDepths(entries []FranchiseEntry) map[string]int {
+	depths := make(map[string]int, len(entries))
+	for _, entry := range entries {
+		depths[entry.AnimeID] = entry.Depth
+	}
+	return depths
+}
+
+func TestFranchiseWalksBothDirectionsAndStopsOnCycles(t *testing.T) {
+	repo := &fakeRelationRepository{relations: []*anime_relation.AnimeRelation{
+		relation("s1", "s2", "Sequel"),
+		relation("s2", "s1", "prequel"),
+		relation("s3", "s2", "Prequel"),
+		relation("s3", "s1", "parent story"),
+		relation("ova", "s1", "side-story"),
+		relation("s1", "crossover", "character"),
+	}}
+	service := NewAnimeRelationService(repo)
+
+	entries, err := service.Franchise(context.Background(), "s2", 0)
+	if err != nil {
+		t.Fatalf("Franchise returned error: %v", err)
+	}
+
+	expected := map[string]int{"s2": 0, "s1": 1, "s3": 1, "ova": 2}
+	depths := franchiseDepths(entries)
+	if len(depths) != len(entries) {
+		t.Errorf("Expected each anime once, got %+v", entries)
+	}
+	if len(depths) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, depths)
+	}
+	for id, depth := range expected {
+		if got, ok := depths[id]; !ok || got != depth {
+			t.Errorf("Expected %s at depth %d, got %d (present=%v)", id, depth, got, ok)
+		}
+	}
+}
+
+func TestFranchiseRespectsDepthCap(t *testing.T) {
+	var relations []*anime_relation.AnimeRelation
+	chain := []string{"a", "b", "c", "d", "e"}
+	for i := 0; i+1 < len(chain); i++ {
+		relations = append(relations, relation(chain[i], chain[i+1], "sequel"))
+	}
+	repo := &fakeRelationRepository{relations: relations}
+	service := NewAnimeRelationService(repo)
+
+	entries, err := service.Franchise(context.Background(), "a", 2)
+	if err != nil {
+		t.Fatalf("Franchise returned error: %v", err)
+	}
+
+	depths := franchiseDepths(entries)
+	if len(depths) != 3 || depths["c"] != 2 {
+		t.Errorf("Expected a, b and c only, got %v", depths)
+	}
+	if repo.queries != 2 {
+		t.Errorf("Expected one query per hop (2), got %d", repo.queries)
+	}
+}
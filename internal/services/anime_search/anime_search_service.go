@@ -0,0 +1,140 @@
+package anime_search
+
+import (
+	"context"
+	"time"
+
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime"
+	"github.com/weeb-vip/anime-api/internal/logger"
+	"github.com/weeb-vip/anime-api/internal/search"
+)
+
+// AnimeSearchIndexer keeps the title search and suggestion indexes in step with the
+// anime table
+type AnimeSearchIndexer struct {
+	Repository  anime.AnimeRepositoryImpl
+	Index       *search.Index
+	Suggestions *search.PrefixIndex
+	// watermark is the newest updated_at loaded so far
+	watermark time.Time
+}
+
+func NewAnimeSearchIndexer(repository anime.AnimeRepositoryImpl, index *search.Index, suggestions *search.PrefixIndex) *AnimeSearchIndexer {
+	return &AnimeSearchIndexer{
+		Repository:  repository,
+		Index:       index,
+		Suggestions: suggestions,
+	}
+}
+
+// Rebuild loads the titles of every anime and replaces both indexes with them
+func (s *AnimeSearchIndexer) Rebuild(ctx context.Context) error {
+	animes, err := s.Repository.FindSearchTitles(ctx, time.Time{})
+	if err != nil {
+		return err
+	}
+
+	docs := make([]search.Document, 0, len(animes))
+	suggestDocs := make([]search.SuggestDocument, 0, len(animes))
+	watermark := time.Time{}
+	for _, animeEntity := range animes {
+		doc := suggestDocument(animeEntity)
+		docs = append(docs, doc.Document)
+		suggestDocs = append(suggestDocs, doc)
+		if animeEntity.UpdatedAt.After(watermark) {
+			watermark = animeEntity.UpdatedAt
+		}
+	}
+	s.Index.Replace(docs)
+	s.Suggestions.Replace(suggestDocs)
+	s.watermark = watermark
+	return nil
+}
+
+// Refresh indexes anime updated since the last load and drops deleted ones. Deletes
+// leave no trace to query, so the full ID list is only read when the anime count
+// and the index size disagree.
+func (s *AnimeSearchIndexer) Refresh(ctx context.Context) error {
+	if !s.Index.Ready() {
+		return s.Rebuild(ctx)
+	}
+
+	animes, err := s.Repository.FindSearchTitles(ctx, s.watermark)
+	if err != nil {
+		return err
+	}
+	for _, animeEntity := range animes {
+		doc := suggestDocument(animeEntity)
+		s.Index.Upsert(doc.Document)
+		s.Suggestions.Upsert(doc)
+		if animeEntity.UpdatedAt.After(s.watermark) {
+			s.watermark = animeEntity.UpdatedAt
+		}
+	}
+
+	count, err := s.Repository.CountAnime(ctx, anime.SearchFilter{})
+	if err != nil {
+		return err
+	}
+	if int(count) == s.Index.Len() {
+		return nil
+	}
+
+	ids, err := s.Repository.FindAllIDs(ctx)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		existing[id] = true
+	}
+	for _, id := range s.Index.IDs() {
+		if !existing[id] {
+			s.Index.Remove(id)
+			s.Suggestions.Remove(id)
+		}
+	}
+	return nil
+}
+
+// Run builds the index, then refreshes it every interval until ctx is done; a
+// non-positive interval disables refreshing. Failed loads are logged and retried on
+// the next tick, and searches fall back to LIKE until the first build succeeds.
+func (s *AnimeSearchIndexer) Run(ctx context.Context, interval time.Duration) {
+	log := logger.FromCtx(ctx)
+
+	startTime := time.Now()
+	if err := s.Rebuild(ctx); err != nil {
+		log.Error().Err(err).Msg("Failed to build search index")
+	} else {
+		log.Info().
+			Int("documents", s.Index.Len()).
+			Dur("duration", time.Since(startTime)).
+			Msg("Search index built")
+	}
+
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Refresh(ctx); err != nil {
+				log.Warn().Err(err).Msg("Failed to refresh search index")
+			}
+		}
+	}
+}
+
+func suggestDocument(animeEntity *anime.Anime) search.SuggestDocument {
+	titles, synonyms := animeEntity.Titles()
+	return search.SuggestDocument{
+		Document: search.Document{ID: animeEntity.ID, Titles: titles, Synonyms: synonyms},
+		ImageURL: animeEntity.ImageURL,
+		Year:     animeEntity.StartYear(),
+	}
+}
@@ -0,0 +1,105 @@
+package anime_search
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime"
+	"github.com/weeb-vip/anime-api/internal/search"
+)
+
+// fakeAnimeRepository serves the queries the indexer makes from a slice; the
+// embedded interface panics on anything else
+type fakeAnimeRepository struct {
+	anime.AnimeRepositoryImpl
+	animes   []*anime.Anime
+	idScans  int
+	sinceLog []time.Time
+}
+
+func (f *fakeAnimeRepository) FindSearchTitles(ctx context.Context, since time.Time) ([]*anime.Anime, error) {
+	f.sinceLog = append(f.sinceLog, since)
+	var result []*anime.Anime
+	for _, animeEntity := range f.animes {
+		if !animeEntity.UpdatedAt.Before(since) {
+			result = append(result, animeEntity)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeAnimeRepository) CountAnime(ctx context.Context, filter anime.SearchFilter) (int64, error) {
+	return int64(len(f.animes)), nil
+}
+
+func (f *fakeAnimeRepository) FindAllIDs(ctx context.Context) ([]string, error) {
+	f.idScans++
+	ids := make([]string, 0, len(f.animes))
+	for _, animeEntity := range f.animes {
+		ids = append(ids, animeEntity.ID)
+	}
+	return ids, nil
+}
+
+func titled(id string, title string, updatedAt time.Time) *anime.Anime {
+	return &anime.Anime{ID: id, TitleEn: &title, UpdatedAt: updatedAt}
+}
+
+func TestIndexerRebuildsThenRefreshesChanges(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	repository := &fakeAnimeRepository{animes: []*anime.Anime{
+		titled("a", "Frieren", base),
+		titled("b", "Dungeon Meshi", base.Add(time.Hour)),
+	}}
+	index := search.NewIndex()
+	suggestions := search.NewPrefixIndex()
+	indexer := NewAnimeSearchIndexer(repository, index, suggestions)
+
+	if err := indexer.Refresh(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !index.Ready() || index.Len() != 2 {
+		t.Fatalf("refresh before a build should rebuild, got ready=%v len=%d", index.Ready(), index.Len())
+	}
+
+	// b is renamed, c is added and a is deleted
+	repository.animes = []*anime.Anime{
+		titled("b", "Delicious in Dungeon", base.Add(2*time.Hour)),
+		titled("c", "Kusuriya no Hitorigoto", base.Add(3*time.Hour)),
+	}
+	if err := indexer.Refresh(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if since := repository.sinceLog[len(repository.sinceLog)-1]; !since.Equal(base.Add(time.Hour)) {
+		t.Errorf("refresh loaded changes since %v, want the newest updated_at", since)
+	}
+	if repository.idScans != 1 {
+		t.Errorf("expected one ID scan after the count changed, got %d", repository.idScans)
+	}
+	if hits := index.Search("delicious in dungeon", 5); len(hits) == 0 || hits[0].ID != "b" {
+		t.Errorf("renamed anime not found: %+v", hits)
+	}
+	if hits := index.Search("frieren", 5); len(hits) != 0 {
+		t.Errorf("deleted anime still indexed: %+v", hits)
+	}
+	if found := suggestions.Suggest("fri", 5); len(found) != 0 {
+		t.Errorf("deleted anime still suggested: %+v", found)
+	}
+	if found := suggestions.Suggest("deli", 5); len(found) != 1 || found[0].ID != "b" {
+		t.Errorf("renamed anime not suggested: %+v", found)
+	}
+	if index.Len() != 2 {
+		t.Errorf("index has %d documents, want 2", index.Len())
+	}
+
+	// Nothing changed, so the IDs are not scanned again
+	if err := indexer.Refresh(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repository.idScans != 1 {
+		t.Errorf("unchanged count should skip the ID scan, got %d scans", repository.idScans)
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 type AnimeSeasonServiceImpl interface {
 	FindByAnimeID(ctx context.Context, animeID string) ([]*anime_season.AnimeSeason, error)
+	FindByAnimeIDs(ctx context.Context, animeIDs []string) (map[string][]*anime_season.AnimeSeason, error)
 	FindBySeason(ctx context.Context, season string) ([]*anime_season.AnimeSeason, error)
 	Create(ctx context.Context, animeSeason *anime_season.AnimeSeason) error
 	Update(ctx context.Context, animeSeason *anime_season.AnimeSeason) error
@@ -35,6 +36,17 @@ func (s *AnimeSeasonService) FindByAnimeID(ctx context.Context, animeID string)
 	return s.Repository.FindByAnimeID(spanCtx, animeID)
 }
 
+func (s *AnimeSeasonService) FindByAnimeIDs(ctx context.Context, animeIDs []string) (map[string][]*anime_season.AnimeSeason, error) {
+	span, spanCtx := tracer.StartSpanFromContext(ctx, "FindByAnimeIDs")
+	span.SetTag("service", "anime_season")
+	span.SetTag("type", "service")
+	span.SetTag("environment", tracing.GetEnvironmentTag())
+	span.SetTag("anime.ids.count", len(animeIDs))
+	defer span.Finish()
+
+	return s.Repository.FindByAnimeIDs(spanCtx, animeIDs)
+}
+
 func (s *AnimeSeasonService) FindBySeason(ctx context.Context, season string) ([]*anime_season.AnimeSeason, error) {
 	span, spanCtx := tracer.StartSpanFromContext(ctx, "FindBySeason")
 	span.SetTag("service", "anime_season")
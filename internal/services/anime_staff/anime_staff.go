@@ -0,0 +1,30 @@
+package anime_staff
+
+import (
+	"context"
+
+	"github.com/weeb-vip/anime-api/internal/db/repositories/anime_staff"
+)
+
+type AnimeStaffServiceImpl interface {
+	FindAnimeStaffById(ctx context.Context, id string) (*anime_staff.AnimeStaff, error)
+	SearchAnimeStaffByName(ctx context.Context, name string, limit int) ([]*anime_staff.AnimeStaff, error)
+}
+
+type AnimeStaffService struct {
+	Repository anime_staff.AnimeStaffRepositoryImpl
+}
+
+func NewAnimeStaffService(repository anime_staff.AnimeStaffRepositoryImpl) AnimeStaffServiceImpl {
+	return &AnimeStaffService{
+		Repository: repository,
+	}
+}
+
+func (a *AnimeStaffService) FindAnimeStaffById(ctx context.Context, id string) (*anime_staff.AnimeStaff, error) {
+	return a.Repository.FindAnimeStaffById(ctx, id)
+}
+
+func (a *AnimeStaffService) SearchAnimeStaffByName(ctx context.Context, name string, limit int) ([]*anime_staff.AnimeStaff, error) {
+	return a.Repository.SearchAnimeStaffByName(ctx, name, limit)
+}
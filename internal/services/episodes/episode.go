@@ -3,16 +3,24 @@ package episodes
 import (
 	"context"
 	animeEpisode "github.com/weeb-vip/anime-api/internal/db/repositories/anime_episode"
+	"github.com/weeb-vip/anime-api/internal/logger"
+	"github.com/weeb-vip/anime-api/internal/services/airing"
 	"time"
 )
 
 type AnimeEpisodeServiceImpl interface {
 	GetEpisodesByAnimeID(ctx context.Context, animeID string) ([]*animeEpisode.AnimeEpisode, error)
 	GetNextEpisode(ctx context.Context, animeID string) (*animeEpisode.AnimeEpisode, error)
+	GetEpisodeByID(ctx context.Context, id string) (*animeEpisode.AnimeEpisode, error)
+	UpsertEpisodes(ctx context.Context, animeID string, episodes []*animeEpisode.AnimeEpisode) error
+	DeleteEpisode(ctx context.Context, id string) error
 }
 
 type AnimeEpisodeService struct {
 	Repository animeEpisode.AnimeEpisodeRepositoryImpl
+	// Events is told about episode writes so scheduleChanged subscribers hear of
+	// them right away; nil leaves them to the airing scheduler's polling
+	Events *airing.Events
 }
 
 func NewAnimeEpisodeService(repository animeEpisode.AnimeEpisodeRepositoryImpl) AnimeEpisodeServiceImpl {
@@ -21,10 +29,59 @@ func NewAnimeEpisodeService(repository animeEpisode.AnimeEpisodeRepositoryImpl)
 	}
 }
 
+// NewAnimeEpisodeServiceWithEvents returns a service that publishes a schedule change
+// for every episode write
+func NewAnimeEpisodeServiceWithEvents(repository animeEpisode.AnimeEpisodeRepositoryImpl, events *airing.Events) AnimeEpisodeServiceImpl {
+	return &AnimeEpisodeService{
+		Repository: repository,
+		Events:     events,
+	}
+}
+
 func (a *AnimeEpisodeService) GetEpisodesByAnimeID(ctx context.Context, animeID string) ([]*animeEpisode.AnimeEpisode, error) {
 	return a.Repository.FindByAnimeID(ctx, animeID)
 }
 
+func (a *AnimeEpisodeService) GetEpisodeByID(ctx context.Context, id string) (*animeEpisode.AnimeEpisode, error) {
+	return a.Repository.FindByID(ctx, id)
+}
+
+func (a *AnimeEpisodeService) UpsertEpisodes(ctx context.Context, animeID string, episodes []*animeEpisode.AnimeEpisode) error {
+	if err := a.Repository.UpsertMany(ctx, animeID, episodes); err != nil {
+		return err
+	}
+	a.publishScheduleChanged(ctx, animeID)
+	return nil
+}
+
+// DeleteEpisode looks the episode up first so the repository can invalidate its anime's caches
+func (a *AnimeEpisodeService) DeleteEpisode(ctx context.Context, id string) error {
+	episode, err := a.Repository.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := a.Repository.Delete(ctx, episode); err != nil {
+		return err
+	}
+	if episode.AnimeID != nil {
+		a.publishScheduleChanged(ctx, *episode.AnimeID)
+	}
+	return nil
+}
+
+// publishScheduleChanged reports a write that already succeeded, so a failure to
+// publish is only logged
+func (a *AnimeEpisodeService) publishScheduleChanged(ctx context.Context, animeID string) {
+	if a.Events == nil {
+		return
+	}
+	err := a.Events.PublishScheduleChanged(ctx, airing.ScheduleChanged{AnimeID: animeID, ChangedAt: time.Now()})
+	if err != nil {
+		log := logger.FromCtx(ctx)
+		log.Warn().Err(err).Str("anime_id", animeID).Msg("Failed to publish schedule change")
+	}
+}
+
 func (a *AnimeEpisodeService) GetNextEpisode(ctx context.Context, animeID string) (*animeEpisode.AnimeEpisode, error) {
 	episodes, err := a.Repository.FindByAnimeID(ctx, animeID)
 	if err != nil {
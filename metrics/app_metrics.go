@@ -46,6 +46,24 @@ func (m *AppMetrics) ResolverMetric(duration float64, resolver string, result st
 	m.metricsImpl.ResolverMetric(duration, labels)
 }
 
+// QueryRejectedMetric counts GraphQL operations rejected before execution, by reason
+func (m *AppMetrics) QueryRejectedMetric(reason string) {
+	m.metricsImpl.CountMetric("graphql_query_rejected_total", map[string]string{
+		"service": m.defaultTags["service"],
+		"reason":  reason,
+		"env":     m.defaultTags["env"],
+	})
+}
+
+// RateLimitedMetric counts requests rejected by the rate limit, by how the client was identified
+func (m *AppMetrics) RateLimitedMetric(clientType string) {
+	m.metricsImpl.CountMetric("http_rate_limited_total", map[string]string{
+		"service":     m.defaultTags["service"],
+		"client_type": clientType,
+		"env":         m.defaultTags["env"],
+	})
+}
+
 // DatabaseMetric records database operation metrics
 func (m *AppMetrics) DatabaseMetric(duration float64, table string, method string, result string) {
 	labels := metricsLib.DatabaseMetricLabels{
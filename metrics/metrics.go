@@ -56,6 +56,10 @@ func initMetrics(prometheusInstance *prometheus.PrometheusClient) {
 		1000,
 	})
 
+	prometheusInstance.CreateCounterVec("graphql_query_rejected_total", "GraphQL operations rejected by the complexity or depth limit", []string{"service", "reason", "env"})
+
+	prometheusInstance.CreateCounterVec("http_rate_limited_total", "Requests rejected by the per-client rate limit", []string{"service", "client_type", "env"})
+
 	// Database connection pool metrics
 	prometheusInstance.CreateGaugeVec("database_connection_pool_open_connections", "Number of open database connections", []string{"service", "env"})
 	prometheusInstance.CreateGaugeVec("database_connection_pool_in_use_connections", "Number of database connections in use", []string{"service", "env"})
@@ -58,10 +58,13 @@ func InitTracing(ctx context.Context) (context.Context, error) {
 	return tracedCtx, nil
 }
 
-// Shutdown gracefully shuts down the tracing system
+// Shutdown gracefully shuts down the tracing system, flushing pending spans.
+// Calls after the first do nothing.
 func Shutdown(ctx context.Context) error {
 	if shutdownFunc != nil {
-		return shutdownFunc(ctx)
+		shutdown := shutdownFunc
+		shutdownFunc = nil
+		return shutdown(ctx)
 	}
 	return nil
 }